@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_fake"
+)
+
+// 偽のAPSサーバーを単体で起動します
+// バックエンドはAPS_BASE_URLにこのサーバーのURLを設定して接続します
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8081", "listen address")
+	translationTime := flag.Duration("translation-time", 0, "time until translation jobs complete (default 10s)")
+	publicURL := flag.String("public-url", "", "URL used for signed upload URLs (default: request host)")
+	flag.Parse()
+
+	var opts aps_fake.Options
+	if *translationTime > 0 {
+		opts.Script = aps_fake.DefaultScript(*translationTime)
+	}
+	opts.PublicURL = *publicURL
+
+	log.Printf("Fake APS server starting on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, aps_fake.NewServer(opts)))
+}
@@ -1,40 +1,112 @@
 package main
 
 import (
+    "context"
+    "flag"
+    "fmt"
     "log"
+    "log/slog"
     "net/http"
     "os"
+    "os/signal"
+    "syscall"
+    "time"
 
     "github.com/gorilla/handlers"
-    "github.com/joho/godotenv"
     _ "github.com/maixhashi/nextgo-aps-viewer/backend/docs" // Swaggerドキュメントのインポート
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/config"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/logging"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/router"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/server"
 )
 
 // @title APS Viewer API
 // @version 1.0
 // @description APS Token Management API
 // @host localhost:8080
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWTを"Bearer <token>"の形式で指定します
 func main() {
-    // 環境変数の読み込み
-    if err := godotenv.Load(); err != nil {
-        log.Fatal("Error loading .env file")
+    configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML設定ファイルのパス")
+    envFile := flag.String("env-file", ".env", ".envファイルのパス（ファイルがなければ読み込まない）")
+    printConfig := flag.Bool("print-config", false, "シークレットを伏せた実際の設定を表示して終了する")
+    flag.Parse()
+
+    // 設定の読み込み（既定値 < YAML < .env < 環境変数）
+    cfg, err := config.Load(config.Options{File: *configFile, EnvFile: *envFile})
+    if err != nil {
+        log.Fatalf("failed to load config: %v", err)
+    }
+    if *printConfig {
+        if err := cfg.Redacted().WriteYAML(os.Stdout); err != nil {
+            log.Fatal(err)
+        }
+        if err := cfg.Validate(); err != nil {
+            fmt.Fprintf(os.Stderr, "invalid config:\n%v\n", err)
+            os.Exit(1)
+        }
+        return
+    }
+    if err := cfg.Validate(); err != nil {
+        log.Fatalf("invalid config:\n%v", err)
+    }
+
+    // 構造化ログの設定（logパッケージの出力もslogへ送る）
+    if _, err := logging.Setup(cfg.Log, os.Stderr); err != nil {
+        log.Fatalf("failed to set up logging: %v", err)
+    }
+
+    // SIGTERM・SIGINTを受け取ったら処理中のリクエストを待って停止する
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+    defer stop()
+
+    // トレースの送信先の設定
+    shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+    if err != nil {
+        log.Fatalf("failed to set up tracing: %v", err)
     }
+    defer func() {
+        // 未送信のスパンを送ってから終了する
+        shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+        defer cancel()
+        if err := shutdownTracing(shutdownCtx); err != nil {
+            slog.Error("failed to flush traces", "error", err)
+        }
+    }()
 
     // ルーターの初期化
-    r := router.NewRouter()
+    r, admin := router.NewRouter(cfg)
 
     // CORS設定
-    corsOrigins := handlers.AllowedOrigins([]string{"*"})
-    corsHeaders := handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", "Authorization"})
-    corsMethods := handlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
+    corsOrigins := handlers.AllowedOrigins(cfg.Server.CORSOrigins)
+    // ブラウザからトレースを引き継げるよう、W3C Trace Contextのヘッダーも許可する
+    corsHeaders := handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", "Authorization", "traceparent", "tracestate", middleware.RequestIDHeader, auth.APIKeyHeader})
+    corsMethods := handlers.AllowedMethods(cfg.Server.CORSMethods)
+    // サポートへの問い合わせに使えるよう、ブラウザからリクエストIDを読めるようにする
+    // レート制限を超えた場合に待つ時間と残りの回数も読めるようにする
+    corsExposed := handlers.ExposedHeaders([]string{middleware.RequestIDHeader, "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining"})
+    corsOptions := []handlers.CORSOption{corsOrigins, corsHeaders, corsMethods, corsExposed}
+    if cfg.Server.CORSAllowCredentials {
+        corsOptions = append(corsOptions, handlers.AllowCredentials())
+    }
+
+    // リクエストID → トレース → アクセスログ → CORS → ルーターの順に処理する
+    apiHandler := middleware.RequestID(middleware.Tracing(r)(middleware.AccessLog(handlers.CORS(corsOptions...)(r))))
     
     // サーバーの起動
-    port := os.Getenv("PORT")
-    if port == "" {
-        port = "8080"
+    var adminHandler http.Handler
+    if admin != nil {
+        adminHandler = admin
+    }
+    if err := server.Run(ctx, cfg.Server, apiHandler, adminHandler); err != nil {
+        log.Fatal(err)
     }
-    
-    log.Printf("Server starting on port %s", port)
-    log.Fatal(http.ListenAndServe(":"+port, handlers.CORS(corsOrigins, corsHeaders, corsMethods)(r)))
 }
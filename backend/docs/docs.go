@@ -15,120 +15,210 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/aps/buckets": {
+        "/api/v1/admin/audit": {
             "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Get list of all buckets",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "バケットの作成・削除、アップロード、翻訳、トークンの発行、権限の変更、拒否した操作の記録を新しい順に返します",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APS Bucket"
+                    "Audit"
+                ],
+                "summary": "監査ログの検索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "呼び出し元のID",
+                        "name": "principal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作（例: bucket:delete）",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "対象の前方一致（例: bucket:teama-）",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "結果（success, failure, denied）",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時以降（RFC 3339）",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前（RFC 3339）",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "件数の上限（最大1000）",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get buckets list",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.APSBucket"
+                                "$ref": "#/definitions/domain.AuditEntry"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "新しいAPSバケットを作成します",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/api/v1/admin/audit/verify": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "すべての記録の通し番号とハッシュの連鎖を確認し、書き換えや削除がないか返します",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APS Bucket"
+                    "Audit"
                 ],
-                "summary": "APSバケット作成",
+                "summary": "監査ログの検証",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.APSBucket"
+                            "$ref": "#/definitions/domain.AuditVerification"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/buckets/{bucketKey}": {
-            "delete": {
-                "description": "指定されたバケットを削除します",
-                "consumes": [
-                    "application/json"
+        "/api/v1/admin/grants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "呼び出し元やグループに付与した、バケットの接頭辞ごとのロールを作成順に返します",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APS Bucket"
-                ],
-                "summary": "APSバケット削除",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "バケットキー",
-                        "name": "bucketKey",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Access"
                 ],
+                "summary": "権限の付与の一覧",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Grant"
+                            }
+                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/aps/buckets/{bucketKey}/details": {
-            "get": {
-                "description": "指定されたバケットの詳細情報を取得します",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "呼び出し元（APIキーの名前、JWTのsub）または\"group:\"に続けたグループに、接頭辞で指定したバケットに対するロールを付与します",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,281 +226,301 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "APS Bucket"
+                    "Access"
                 ],
-                "summary": "APSバケット詳細取得",
+                "summary": "権限の付与",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "バケットキー",
-                        "name": "bucketKey",
-                        "in": "path",
-                        "required": true
+                        "description": "付与する内容（roleはviewer、uploader、admin。bucketPrefixが空の場合はすべてのバケット）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.GrantRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.APSBucketDetail"
+                            "$ref": "#/definitions/domain.Grant"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_bucket.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/buckets/{bucketKey}/objects/signeds3upload": {
-            "post": {
-                "description": "オブジェクトをS3へ保存するためのS3署名付きURLを取得します",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
+        "/api/v1/admin/grants/{grantId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "権限の付与を削除します",
                 "tags": [
-                    "APS Object"
+                    "Access"
                 ],
-                "summary": "S3署名付きURLの取得",
+                "summary": "権限の付与の取り消し",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "バケットキー",
-                        "name": "bucketKey",
+                        "description": "権限の付与のID",
+                        "name": "grantId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "アップロードするファイル",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "パート数",
-                        "name": "parts",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/domain.APSObject"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/buckets/{bucketKey}/objects/upload": {
-            "post": {
-                "description": "S3署名付きURLを取得してオブジェクトをアップロードするシーケンスを実行します",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "APS Object"
-                ],
-                "summary": "APSオブジェクトのアップロードシーケンス",
-                "parameters": [
+        "/api/v1/aps/buckets": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "バケットキー",
-                        "name": "bucketKey",
-                        "in": "path",
-                        "required": true
+                        "Bearer": []
                     },
                     {
-                        "type": "file",
-                        "description": "アップロードするファイル",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "ApiKeyAuth": []
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "パート数",
-                        "name": "parts",
-                        "in": "query"
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Get list of all buckets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Bucket"
+                ],
+                "summary": "Get buckets list",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.APSBucket"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/aps/buckets/{bucketKey}/objects/{objectKey}/signeds3upload": {
+            },
             "post": {
-                "description": "S3へアップロードしたオブジェクトの作成を完了します",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "APS Object"
-                ],
-                "summary": "APSオブジェクトの作成完了",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "バケットキー",
-                        "name": "bucketKey",
-                        "in": "path",
-                        "required": true
-                    },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "オブジェクトキー",
-                        "name": "objectKey",
-                        "in": "path",
-                        "required": true
+                        "ApiKeyAuth": []
                     },
                     {
-                        "description": "アップロードキー",
-                        "name": "uploadKey",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "BearerAuth": []
                     }
                 ],
+                "description": "新しいAPSバケットを作成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Bucket"
+                ],
+                "summary": "APSバケット作成",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.APSObject"
+                            "$ref": "#/definitions/domain.APSBucket"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/objects/signeds3upload": {
-            "put": {
-                "description": "S3署名付きURLを使用してオブジェクトをS3へアップロードします",
+        "/api/v1/aps/buckets/{bucketKey}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたバケットを削除します",
                 "consumes": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APS Object"
+                    "APS Bucket"
                 ],
-                "summary": "S3署名付きURLを使用したオブジェクトのアップロード",
+                "summary": "APSバケット削除",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "署名付きURL",
-                        "name": "signedURL",
-                        "in": "query",
+                        "description": "バケットキー",
+                        "name": "bucketKey",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "アップロードするファイルのバイナリデータ",
-                        "name": "file",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/objects/{objectId}/base64urn": {
+        "/api/v1/aps/buckets/{bucketKey}/details": {
             "get": {
-                "description": "オブジェクトIDをBase64エンコードしたURNを生成します",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたバケットの詳細情報を取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -418,14 +528,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "APS Object"
+                    "APS Bucket"
                 ],
-                "summary": "オブジェクトURNのBase64エンコード",
+                "summary": "APSバケット詳細取得",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "オブジェクトID",
-                        "name": "objectId",
+                        "description": "バケットキー",
+                        "name": "bucketKey",
                         "in": "path",
                         "required": true
                     }
@@ -434,32 +544,55 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.APSBucketDetail"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/objects/{objectId}/translate": {
+        "/api/v1/aps/buckets/{bucketKey}/objects/signeds3upload": {
             "post": {
-                "description": "オブジェクトの翻訳ジョブを作成します",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "オブジェクトをS3へ保存するためのS3署名付きURLを取得します",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -467,50 +600,89 @@ const docTemplate = `{
                 "tags": [
                     "APS Object"
                 ],
-                "summary": "APSオブジェクトの翻訳ジョブ作成",
+                "summary": "S3署名付きURLの取得",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "オブジェクトID",
-                        "name": "objectId",
+                        "description": "バケットキー",
+                        "name": "bucketKey",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "オブジェクトキー",
-                        "name": "objectKey",
-                        "in": "path",
+                        "type": "file",
+                        "description": "アップロードするファイル",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "パート数",
+                        "name": "parts",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TranslateJobResponse"
+                            "$ref": "#/definitions/domain.APSObject"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/objects/{urn}/status": {
-            "get": {
-                "description": "翻訳ジョブの進捗状況を確認します",
+        "/api/v1/aps/buckets/{bucketKey}/objects/upload": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "S3署名付きURLを取得してオブジェクトをアップロードするシーケンスを実行します",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -518,41 +690,90 @@ const docTemplate = `{
                 "tags": [
                     "APS Object"
                 ],
-                "summary": "翻訳ジョブのステータス確認",
+                "summary": "APSオブジェクトのアップロードシーケンス",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Base64エンコードされたURN",
-                        "name": "urn",
+                        "description": "バケットキー",
+                        "name": "bucketKey",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "アップロードするファイル",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "パート数",
+                        "name": "parts",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TranslationStatus"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/aps_object.ErrorResponse"
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     }
                 }
             }
         },
-        "/api/v1/aps/token": {
+        "/api/v1/aps/buckets/{bucketKey}/objects/{objectKey}/signeds3upload": {
             "post": {
-                "description": "2-legged認証でAPSアクセストークンを取得します",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "S3へアップロードしたオブジェクトの作成を完了します",
                 "consumes": [
                     "application/json"
                 ],
@@ -560,261 +781,4326 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "APS Token"
+                    "APS Object"
                 ],
-                "summary": "APSトークン取得",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "APSオブジェクトの作成完了",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バケットキー",
+                        "name": "bucketKey",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "オブジェクトキー",
+                        "name": "objectKey",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "アップロードキー",
+                        "name": "uploadKey",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.APSToken"
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APSObject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/signeds3upload": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "S3署名付きURLを使用してオブジェクトをS3へアップロードします",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Object"
+                ],
+                "summary": "S3署名付きURLを使用したオブジェクトのアップロード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "署名付きURL",
+                        "name": "signedURL",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "アップロードするファイルのバイナリデータ",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "aps_bucket.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
+        },
+        "/api/v1/aps/objects/{objectId}/base64urn": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "オブジェクトIDをBase64エンコードしたURNを生成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Object"
+                ],
+                "summary": "オブジェクトURNのBase64エンコード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "オブジェクトID",
+                        "name": "objectId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{objectId}/translate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "オブジェクトの翻訳ジョブを作成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Object"
+                ],
+                "summary": "APSオブジェクトの翻訳ジョブ作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "オブジェクトID",
+                        "name": "objectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "オブジェクトキー",
+                        "name": "objectKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TranslateJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{urn}/bundle": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "マニフェストを辿ってSVFの派生ファイルをすべてダウンロードし、Viewerのローカルモードで読み込めるzipとして返します\nSVF2のみに翻訳したモデルはローカルモードで読み込めないため422を返します",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "APS Derivative"
+                ],
+                "summary": "オフライン閲覧用バンドルのエクスポート",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{urn}/exports": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルのエクスポート履歴を新しい順に返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Export"
+                ],
+                "summary": "エクスポート履歴の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ExportRecord"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルビューのGUIDとobjectIdsを指定して、選択した要素をOBJ（MTLとのzip）またはSTL（ascii/binary）としてエクスポートします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Export"
+                ],
+                "summary": "選択した要素のエクスポート作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "エクスポートの内容（formatはobjまたはstl、encodingはstlの場合のみasciiまたはbinary）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ExportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ExportRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{urn}/exports/{exportId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "エクスポートの状況を返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Export"
+                ],
+                "summary": "エクスポートの取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "エクスポートID",
+                        "name": "exportId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ExportRecord"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{urn}/exports/{exportId}/download": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "完了したエクスポートのファイルをダウンロードします。何度でもダウンロードできます",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "APS Export"
+                ],
+                "summary": "エクスポートのダウンロード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "エクスポートID",
+                        "name": "exportId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{urn}/glb": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "変換済みのGLBを返します。React Three FiberのuseGLTFから直接読み込めます。変換中は202と変換状況を返します",
+                "produces": [
+                    "model/gltf-binary"
+                ],
+                "tags": [
+                    "APS Derivative"
+                ],
+                "summary": "変換済みGLBの取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "モデルビューのGUID",
+                        "name": "modelGuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "カンマ区切りのobjectIds（modelGuidが必要）",
+                        "name": "objectIds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GLBConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "OBJ派生ファイルを作成してバイナリglTFに変換します。変換中は202を返すため、successになるまで再送してください",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Derivative"
+                ],
+                "summary": "OBJ派生ファイルからGLBへの変換",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "モデルビューのGUIDと対象のobjectIds（省略時はモデル全体。objectIdsにはmodelGuidが必要）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aps_derivative.ConvertToGLBRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GLBConversion"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GLBConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/objects/{urn}/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "翻訳ジョブの進捗状況を確認します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Object"
+                ],
+                "summary": "翻訳ジョブのステータス確認",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64エンコードされたURN",
+                        "name": "urn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TranslationStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/proxy/{path}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ViewerのModel Derivativeへのリクエストをサーバーのトークンで転送し、派生ファイルをディスクにキャッシュします",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "APS Derivative"
+                ],
+                "summary": "Viewer用派生ファイルプロキシ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "developer.api.autodesk.com以下のパス（例: modelderivative/v2/designdata/{urn}/manifest）",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/aps/token": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "2-legged認証でViewer用のAPSアクセストークンを取得します\nトークンのスコープはviewables:readのみで、バケットやオブジェクトの作成・変更・削除には使えません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APS Token"
+                ],
+                "summary": "APSトークン取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APSToken"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "認証された呼び出し元のID・認証方法・ロール・グループを返します。フロントエンドで操作の表示を切り替えるのに使います",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "呼び出し元の確認",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Principal"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/meshes/glb": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Viewerから抽出したメッシュ（dbIdごとの頂点とインデックス）を受け取り、頂点の溶接・マテリアルごとの結合・目標三角形数までの間引きを行ってGLBを返します。バウンディングボックスと三角形数はscene.extrasとX-Meshヘッダーに含まれます\n間引きで要素を取り除くことはありません。目標の三角形数まで間引けなかった要素は間引く前の形状のまま残し、そのdbIdをscene.extras.unmetDbIdsに、件数をX-Mesh-Unmet-Elementsヘッダーに含めます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "model/gltf-binary"
+                ],
+                "tags": [
+                    "Mesh"
+                ],
+                "summary": "抽出したメッシュのGLB変換",
+                "parameters": [
+                    {
+                        "description": "抽出したメッシュと後処理の設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MeshProcessRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/models": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アップロードしたモデルのカタログを条件で絞り込み、1ページ分返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Model"
+                ],
+                "summary": "モデルの一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バケットキー",
+                        "name": "bucketKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状態（uploaded, pending, inprogress, success, failed, timeout）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "拡張子（例: rvt）",
+                        "name": "fileType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "アップロードした呼び出し元のID",
+                        "name": "uploadedBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タグ",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "フォルダのID（rootでプロジェクト直下）",
+                        "name": "folderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "名前またはファイル名の部分一致",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "並び順（createdAt, name, size。先頭に-で降順）",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "件数（最大200）",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "先頭から飛ばす件数",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ModelList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/models/{modelId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "カタログのモデルを返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Model"
+                ],
+                "summary": "モデルの詳細",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Model"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "カタログからモデルを削除します。OSSのオブジェクトと翻訳した派生ファイルは削除しません",
+                "tags": [
+                    "Model"
+                ],
+                "summary": "モデルの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルの名前・説明・タグのうち、指定した項目を更新します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Model"
+                ],
+                "summary": "モデルの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新する項目",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ModelUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Model"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/models/{modelId}/move": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルを別のプロジェクトやフォルダへ移動します。再アップロードは不要です\nfolderIdを指定するとそのフォルダへ、projectIdのみの場合はプロジェクト直下へ移動し、どちらも空の場合はプロジェクトから外します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "モデルの移動",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "移動先",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ModelMove"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Model"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/models/{modelId}/shares": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルの共有リンクを新しい順に返します。取り消したものと期限切れのものも含みます",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "共有リンクの一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Share"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アカウントのない相手にモデルを見せるための共有リンクを作成します。有効期限・パスワード・閲覧できる回数の上限を設定できます\nトークンはこのレスポンスでのみ返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "共有リンクの作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "有効期間・パスワード・閲覧できる回数の上限",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreatedShare"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/models/{modelId}/shares/{shareId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "共有リンクを取り消します。引き換え済みのViewer用トークンも使えなくなります",
+                "tags": [
+                    "Share"
+                ],
+                "summary": "共有リンクの取り消し",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "モデルのID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "共有リンクのID",
+                        "name": "shareId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "すべてのプロジェクトを名前順に返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "プロジェクトの一覧",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Project"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルを整理するプロジェクトを作成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "プロジェクトの作成",
+                "parameters": [
+                    {
+                        "description": "プロジェクトの名前と説明",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクトを返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "プロジェクトの詳細",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクトとフォルダを削除します。モデルはプロジェクトから外れ、カタログには残ります\npurge=trueの場合は、プロジェクトのモデルのOSSのオブジェクト・翻訳の派生ファイル・カタログの項目も削除します\ndryRun=trueの場合は削除せずに、削除する内容を返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "プロジェクトの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "OSSのオブジェクトと派生ファイルも削除する",
+                        "name": "purge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "削除せずに削除する内容のみ返す",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectDeletion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクトの名前と説明のうち、指定した項目を更新します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "プロジェクトの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新する項目",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectId}/folders": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクトのすべてのフォルダを返します。parentIdをたどるとフォルダの階層になります",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "フォルダの一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Folder"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクト直下、またはparentIdのフォルダの中にフォルダを作成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "フォルダの作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "フォルダの名前と親フォルダ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Folder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectId}/folders/{folderId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "空のフォルダを削除します。サブフォルダやモデルがある場合は409を返します",
+                "tags": [
+                    "Project"
+                ],
+                "summary": "フォルダの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "フォルダのID",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "フォルダの名前を変更し、parentIdを指定した場合は別の親フォルダへ移動します（空文字列でプロジェクト直下）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "フォルダの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "フォルダのID",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新する項目",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FolderUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Folder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{projectId}/folders/{folderId}/contents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "フォルダ直下のサブフォルダと、モデルを名前順に1ページ分返します。モデルには翻訳の状態とサムネイルのURLを含めます\nfolderIdにrootを指定するとプロジェクト直下を返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "フォルダの中身",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "フォルダのID（rootでプロジェクト直下）",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "モデルの件数（最大200）",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "先頭から飛ばすモデルの件数",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FolderContents"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/shares/exchange": {
+            "post": {
+                "description": "共有リンクのトークン（とパスワード）を、共有したモデルの派生ファイルのプロキシでのみ使える短命のViewer用トークンと引き換えます。認証は不要です\n引き換えるたびに閲覧を1回と数えます。期限切れ・取り消し済み・閲覧の上限に達した共有リンクは410を返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "共有リンクとViewer用トークンの引き換え",
+                "parameters": [
+                    {
+                        "description": "共有リンクのトークンとパスワード",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ShareExchangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ShareViewerToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/shares/refresh": {
+            "post": {
+                "description": "有効期限内のViewer用トークンを新しいものと交換します。認証は不要です\n閲覧の回数には数えません。トークンが不正または期限切れの場合は401、共有リンクが期限切れ・取り消し済みの場合は410を返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Viewer用トークンの更新",
+                "parameters": [
+                    {
+                        "description": "有効期限内のViewer用トークン",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ShareRefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ShareViewerToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "モデルの名前・説明・ファイル名・タグと、翻訳済みのモデルの要素の名前とプロパティの値から検索語に一致するモデルを探し、一致の度合いの順に1ページ分返します\n空白区切りの語はすべてを含むモデルに一致します。\"Level 3\"のように引用符で囲むと語句として、fire*のように末尾に*を付けると前方一致で探します\nfacetsには、ファイルの種類・プロジェクト・状態ごとの件数を、その項目以外の絞り込みを適用して返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "モデルの全文検索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "検索語",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "拡張子（例: rvt）",
+                        "name": "fileType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "プロジェクトのID（rootでプロジェクトに入っていないモデル）",
+                        "name": "projectId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状態（uploaded, pending, inprogress, success, failed, timeout）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "件数（最大100）",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "先頭から飛ばす件数",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/embed/{token}": {
+            "get": {
+                "description": "共有リンクのトークンでViewer用トークンを引き換え、APS Viewerでモデルを表示するページを返します。認証は不要です\nパスワードを設定した共有リンクはページでパスワードを入力します。iframeでの表示は設定したドメインからのみ許可します",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "共有リンクの埋め込みページ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "共有リンクのトークン",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "HTML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "HTML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/embed/{token}/thumbnail": {
+            "get": {
+                "description": "共有しているモデルのサムネイル（400x400）を返します。認証は不要です。パスワードを設定した共有リンクは401を返します",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "共有リンクのサムネイル",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "共有リンクのトークン",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "プロセスが動作していることを返します。APSやストアへの接続は確認しません",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "生存確認",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/oembed": {
+            "get": {
+                "description": "共有リンクのURL（/embed/{token}）に対するoEmbed（type=rich）を返します。htmlは埋め込みページを表示するiframeです。認証は不要です\n期限切れや取り消し済みの共有リンクは410を返します。パスワードを設定した共有リンクにはサムネイルを含めません",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "oEmbed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "共有リンクのURL",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "iframeの幅の上限",
+                        "name": "maxwidth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "iframeの高さの上限",
+                        "name": "maxheight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "形式（jsonのみ対応）",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OEmbed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "APSの認証情報でトークンを取得できるか、OSSへ接続できるか、ローカルのストアに書き込めるかを確認し、項目ごとの結果と所要時間を返します\nAPSの確認結果は一定時間キャッシュします",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "準備状態の確認",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "aps_derivative.ConvertToGLBRequest": {
+            "type": "object",
+            "properties": {
+                "modelGuid": {
+                    "type": "string"
+                },
+                "objectIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.APSBucket": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "createdDate": {
+                    "type": "integer"
+                },
+                "policyKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.APSBucketDetail": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "bucketOwner": {
+                    "type": "string"
+                },
+                "createdDate": {
+                    "type": "integer"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    }
+                },
+                "policyKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.APSObject": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "objectId": {
+                    "type": "string"
+                },
+                "objectKey": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploadExpiration": {
+                    "type": "string"
+                },
+                "uploadKey": {
+                    "type": "string"
+                },
+                "urlExpiration": {
+                    "type": "string"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.APSToken": {
+            "description": "APSトークンレスポンス",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 3599
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": ""
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "domain.Action": {
+            "type": "string",
+            "enum": [
+                "grant:create",
+                "grant:delete",
+                "project:create",
+                "project:update",
+                "project:delete",
+                "folder:create",
+                "folder:update",
+                "folder:delete",
+                "model:move",
+                "object:delete",
+                "manifest:delete",
+                "bucket:read",
+                "bucket:create",
+                "bucket:delete",
+                "object:read",
+                "object:upload",
+                "object:translate",
+                "export:create",
+                "token:create",
+                "mesh:process",
+                "grant:manage",
+                "audit:read",
+                "model:update",
+                "model:delete",
+                "share:create",
+                "share:revoke",
+                "share:view"
+            ],
+            "x-enum-varnames": [
+                "ActionGrantCreate",
+                "ActionGrantDelete",
+                "ActionProjectCreate",
+                "ActionProjectUpdate",
+                "ActionProjectDelete",
+                "ActionFolderCreate",
+                "ActionFolderUpdate",
+                "ActionFolderDelete",
+                "ActionModelMove",
+                "ActionObjectDelete",
+                "ActionManifestDelete",
+                "ActionBucketRead",
+                "ActionBucketCreate",
+                "ActionBucketDelete",
+                "ActionObjectRead",
+                "ActionObjectUpload",
+                "ActionObjectTranslate",
+                "ActionExportCreate",
+                "ActionTokenCreate",
+                "ActionMeshProcess",
+                "ActionGrantManage",
+                "ActionAuditRead",
+                "ActionModelUpdate",
+                "ActionModelDelete",
+                "ActionShareCreate",
+                "ActionShareRevoke",
+                "ActionShareView"
+            ]
+        },
+        "domain.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Action"
+                        }
+                    ],
+                    "example": "bucket:delete"
+                },
+                "detail": {
+                    "description": "失敗や拒否の理由",
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "prevHash": {
+                    "type": "string"
+                },
+                "principal": {
+                    "description": "呼び出し元のIDと認証方法。バックグラウンドの処理では空",
+                    "type": "string"
+                },
+                "principalMethod": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "1から始まる通し番号",
+                    "type": "integer"
+                },
+                "target": {
+                    "description": "操作の対象（例: bucket:my-bucket, object:my-bucket/model.rvt, grant:\u003cID\u003e）",
+                    "type": "string",
+                    "example": "bucket:my-bucket"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AuditVerification": {
+            "type": "object",
+            "properties": {
+                "brokenAt": {
+                    "description": "最初に不整合が見つかった記録の通し番号",
+                    "type": "integer"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "incomplete": {
+                    "description": "書き込みの途中で中断した記録の数。プロセスの異常終了などで最後の行が途中までしかない場合に数えます",
+                    "type": "integer"
+                },
+                "ok": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.Children": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Resource"
+                    }
+                },
+                "guid": {
+                    "type": "string"
+                },
+                "hasThumbnail": {
+                    "type": "string"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Message"
+                    }
+                },
+                "mime": {
+                    "type": "string"
+                },
+                "modelGuid": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "objectIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "progress": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "urn": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreatedShare": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "hasPassword": {
+                    "description": "パスワードを設定しているか",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastViewedAt": {
+                    "type": "string"
+                },
+                "maxViews": {
+                    "description": "閲覧できる回数の上限。0の場合は制限しません",
+                    "type": "integer"
+                },
+                "modelId": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "埋め込みページのURL。oEmbedにもこのURLを渡します",
+                    "type": "string",
+                    "example": "https://viewer.example.com/embed/3q2-7w..."
+                },
+                "urn": {
+                    "type": "string"
+                },
+                "viewCount": {
+                    "description": "Viewer用トークンと引き換えた回数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DeletedModel": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "objectKey": {
+                    "type": "string"
+                },
+                "urn": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Derivative": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Children"
+                    }
+                },
+                "hasThumbnail": {
+                    "type": "string"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Message"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputType": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExportRecord": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "encoding": {
+                    "description": "stlの場合のみ ascii または binary",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "format": {
+                    "description": "obj または stl",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "modelGuid": {
+                    "type": "string"
+                },
+                "objectIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "progress": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, inprogress, success, failed",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "urn": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExportRequest": {
+            "type": "object",
+            "properties": {
+                "encoding": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "modelGuid": {
+                    "type": "string"
+                },
+                "objectIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.Folder": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Structure"
+                },
+                "parentId": {
+                    "description": "親フォルダのID。プロジェクト直下の場合は空",
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FolderContents": {
+            "type": "object",
+            "properties": {
+                "folder": {
+                    "description": "表示しているフォルダ。プロジェクト直下の場合はnull",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Folder"
+                        }
+                    ]
+                },
+                "folders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Folder"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FolderItem"
+                    }
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "path": {
+                    "description": "プロジェクト直下から表示しているフォルダまでのフォルダ",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Folder"
+                    }
+                },
+                "project": {
+                    "$ref": "#/definitions/domain.Project"
+                },
+                "total": {
+                    "description": "フォルダ直下のモデルのすべての件数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FolderItem": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "description": "元のファイル名と、小文字にした拡張子（ドットなし）",
+                    "type": "string",
+                    "example": "office.rvt"
+                },
+                "fileType": {
+                    "type": "string",
+                    "example": "rvt"
+                },
+                "folderId": {
+                    "type": "string"
+                },
+                "hasThumbnail": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "表示名。既定は元のファイル名",
+                    "type": "string",
+                    "example": "office.rvt"
+                },
+                "objectId": {
+                    "type": "string"
+                },
+                "objectKey": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "string",
+                    "example": "complete"
+                },
+                "projectId": {
+                    "description": "整理先のプロジェクトとフォルダ。プロジェクト直下の場合はFolderIDが空",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thumbnailUrl": {
+                    "description": "サムネイルを取得するURL。サムネイルがない場合は空",
+                    "type": "string"
+                },
+                "translationProfile": {
+                    "description": "翻訳の出力形式とビュー（例: svf:2d,3d）。翻訳していない場合は空",
+                    "type": "string",
+                    "example": "svf:2d,3d"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "description": "アップロードした呼び出し元のID",
+                    "type": "string"
+                },
+                "urn": {
+                    "description": "Base64エンコードしたオブジェクトのURN",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FolderRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Structure"
+                },
+                "parentId": {
+                    "description": "親フォルダのID。空の場合はプロジェクト直下",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FolderUpdate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "移動先の親フォルダのID。空文字列の場合はプロジェクト直下へ移動します",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GLBConversion": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "modelGuid": {
+                    "type": "string"
+                },
+                "objectIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "progress": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "urn": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Grant": {
+            "type": "object",
+            "properties": {
+                "bucketPrefix": {
+                    "description": "対象のバケットキーの接頭辞。空の場合はすべてのバケット",
+                    "type": "string",
+                    "example": "teama-"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "uploader"
+                },
+                "subject": {
+                    "description": "呼び出し元のID（APIキーの名前、JWTのsub）または\"group:\"に続けたグループ名",
+                    "type": "string",
+                    "example": "group:teama"
+                }
+            }
+        },
+        "domain.GrantRequest": {
+            "type": "object",
+            "properties": {
+                "bucketPrefix": {
+                    "type": "string",
+                    "example": "teama-"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "uploader"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "group:teama"
+                }
+            }
+        },
+        "domain.HealthCheckResult": {
+            "description": "確認項目ごとの結果",
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "キャッシュした結果を返した場合はtrue",
+                    "type": "boolean"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 123.4
+                },
+                "name": {
+                    "type": "string",
+                    "example": "aps_token"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "domain.HealthReport": {
+            "description": "生存確認・準備状態の確認の結果",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.HealthCheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "domain.MeshGeometryInput": {
+            "type": "object",
+            "properties": {
+                "indices": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "normals": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "vertices": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "domain.MeshInput": {
+            "type": "object",
+            "properties": {
+                "dbId": {
+                    "type": "integer"
+                },
+                "fragId": {
+                    "type": "integer"
+                },
+                "geometryData": {
+                    "$ref": "#/definitions/domain.MeshGeometryInput"
+                },
+                "material": {
+                    "$ref": "#/definitions/domain.MeshMaterialInput"
+                },
+                "matrixWorld": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "domain.MeshMaterialInput": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opacity": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.MeshProcessOptions": {
+            "type": "object",
+            "properties": {
+                "mergeByMaterial": {
+                    "type": "boolean"
+                },
+                "targetTriangles": {
+                    "type": "integer"
+                },
+                "weldTolerance": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.MeshProcessRequest": {
+            "type": "object",
+            "properties": {
+                "meshes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MeshInput"
+                    }
+                },
+                "options": {
+                    "$ref": "#/definitions/domain.MeshProcessOptions"
+                }
+            }
+        },
+        "domain.Message": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Model": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "description": "元のファイル名と、小文字にした拡張子（ドットなし）",
+                    "type": "string",
+                    "example": "office.rvt"
+                },
+                "fileType": {
+                    "type": "string",
+                    "example": "rvt"
+                },
+                "folderId": {
+                    "type": "string"
+                },
+                "hasThumbnail": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "表示名。既定は元のファイル名",
+                    "type": "string",
+                    "example": "office.rvt"
+                },
+                "objectId": {
+                    "type": "string"
+                },
+                "objectKey": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "string",
+                    "example": "complete"
+                },
+                "projectId": {
+                    "description": "整理先のプロジェクトとフォルダ。プロジェクト直下の場合はFolderIDが空",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translationProfile": {
+                    "description": "翻訳の出力形式とビュー（例: svf:2d,3d）。翻訳していない場合は空",
+                    "type": "string",
+                    "example": "svf:2d,3d"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "description": "アップロードした呼び出し元のID",
+                    "type": "string"
+                },
+                "urn": {
+                    "description": "Base64エンコードしたオブジェクトのURN",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ModelList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Model"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "条件に一致したすべての件数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ModelMove": {
+            "type": "object",
+            "properties": {
+                "folderId": {
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ModelUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.OEmbed": {
+            "type": "object",
+            "properties": {
+                "cache_age": {
+                    "description": "共有リンクの有効期限までの秒数",
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "html": {
+                    "description": "埋め込みページを表示するiframe",
+                    "type": "string"
+                },
+                "provider_name": {
+                    "type": "string"
+                },
+                "provider_url": {
+                    "type": "string"
+                },
+                "thumbnail_height": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "description": "パスワードを設定した共有リンクでは返しません",
+                    "type": "string"
+                },
+                "thumbnail_width": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "rich"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Permission": {
+            "type": "object",
+            "properties": {
+                "access": {
+                    "type": "string"
+                },
+                "authId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Principal": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "所属するグループ。グループへの権限の付与に使います",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "呼び出し元の識別子（APIキーの名前、JWTのsub）",
+                    "type": "string"
+                },
+                "issuer": {
+                    "description": "JWTの発行者（iss）",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "認証方法（api_key, jwt, share, anonymous, disabled）",
+                    "type": "string"
+                },
+                "roles": {
+                    "description": "すべてのバケットに対するロール（viewer, uploader, admin）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "urn": {
+                    "description": "共有リンクで発行したトークンの場合、閲覧できるモデルのURN。このURNの参照以外は許可しません",
                     "type": "string"
                 }
             }
         },
-        "aps_object.ErrorResponse": {
+        "domain.Project": {
             "type": "object",
             "properties": {
-                "error": {
+                "createdAt": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.APSBucket": {
-            "type": "object",
-            "properties": {
-                "bucketKey": {
+                },
+                "createdBy": {
                     "type": "string"
                 },
-                "createdDate": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "policyKey": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Office Building"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "domain.APSBucketDetail": {
+        "domain.ProjectDeletion": {
             "type": "object",
             "properties": {
-                "bucketKey": {
-                    "type": "string"
+                "dryRun": {
+                    "type": "boolean"
                 },
-                "bucketOwner": {
-                    "type": "string"
-                },
-                "createdDate": {
+                "folders": {
                     "type": "integer"
                 },
-                "permissions": {
+                "models": {
+                    "description": "プロジェクトのモデル。Purgeでない場合はプロジェクトから外すのみで、カタログには残ります",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Permission"
+                        "$ref": "#/definitions/domain.DeletedModel"
                     }
                 },
-                "policyKey": {
-                    "type": "string"
+                "project": {
+                    "$ref": "#/definitions/domain.Project"
+                },
+                "purge": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.APSObject": {
+        "domain.ProjectRequest": {
             "type": "object",
             "properties": {
-                "bucketKey": {
+                "description": {
                     "type": "string"
                 },
-                "contentType": {
+                "name": {
+                    "type": "string",
+                    "example": "Office Building"
+                }
+            }
+        },
+        "domain.ProjectUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "location": {
+                "name": {
                     "type": "string"
-                },
-                "objectId": {
+                }
+            }
+        },
+        "domain.Resource": {
+            "type": "object",
+            "properties": {
+                "guid": {
                     "type": "string"
                 },
-                "objectKey": {
+                "mime": {
                     "type": "string"
                 },
-                "size": {
-                    "type": "integer"
+                "resolution": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
                 },
-                "uploadExpiration": {
+                "role": {
                     "type": "string"
                 },
-                "uploadKey": {
+                "type": {
                     "type": "string"
                 },
-                "urlExpiration": {
+                "urn": {
                     "type": "string"
-                },
-                "urls": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "domain.APSToken": {
-            "description": "APSトークンレスポンス",
+        "domain.SearchFacet": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "expires_in": {
-                    "type": "integer",
-                    "example": 3599
-                },
-                "refresh_token": {
-                    "type": "string",
-                    "example": ""
+                "count": {
+                    "type": "integer"
                 },
-                "token_type": {
+                "value": {
                     "type": "string",
-                    "example": "Bearer"
+                    "example": "rvt"
                 }
             }
         },
-        "domain.Children": {
+        "domain.SearchFacets": {
             "type": "object",
             "properties": {
-                "children": {
+                "fileType": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Resource"
+                        "$ref": "#/definitions/domain.SearchFacet"
                     }
                 },
-                "guid": {
-                    "type": "string"
-                },
-                "hasThumbnail": {
-                    "type": "string"
+                "projectId": {
+                    "description": "プロジェクトに入っていないモデルは値が空",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchFacet"
+                    }
                 },
-                "messages": {
+                "status": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Message"
+                        "$ref": "#/definitions/domain.SearchFacet"
                     }
+                }
+            }
+        },
+        "domain.SearchHit": {
+            "type": "object",
+            "properties": {
+                "bucketKey": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "description": "元のファイル名と、小文字にした拡張子（ドットなし）",
+                    "type": "string",
+                    "example": "office.rvt"
+                },
+                "fileType": {
+                    "type": "string",
+                    "example": "rvt"
+                },
+                "folderId": {
+                    "type": "string"
+                },
+                "hasThumbnail": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "name": {
+                    "description": "表示名。既定は元のファイル名",
+                    "type": "string",
+                    "example": "office.rvt"
+                },
+                "objectId": {
                     "type": "string"
                 },
-                "progress": {
+                "objectKey": {
                     "type": "string"
                 },
-                "role": {
+                "progress": {
+                    "type": "string",
+                    "example": "complete"
+                },
+                "projectId": {
+                    "description": "整理先のプロジェクトとフォルダ。プロジェクト直下の場合はFolderIDが空",
                     "type": "string"
                 },
+                "score": {
+                    "description": "一致の度合い。大きいほどよく一致しています",
+                    "type": "number"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "snippet": {
+                    "description": "一致した箇所の抜粋。一致した語は[]で囲みます",
+                    "type": "string",
+                    "example": "... [Fire] [Damper] Schedule ..."
+                },
                 "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translationProfile": {
+                    "description": "翻訳の出力形式とビュー（例: svf:2d,3d）。翻訳していない場合は空",
+                    "type": "string",
+                    "example": "svf:2d,3d"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
-                "type": {
+                "uploadedBy": {
+                    "description": "アップロードした呼び出し元のID",
+                    "type": "string"
+                },
+                "urn": {
+                    "description": "Base64エンコードしたオブジェクトのURN",
                     "type": "string"
                 }
             }
         },
-        "domain.Derivative": {
+        "domain.SearchResult": {
             "type": "object",
             "properties": {
-                "children": {
+                "facets": {
+                    "$ref": "#/definitions/domain.SearchFacets"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Children"
+                        "$ref": "#/definitions/domain.SearchHit"
                     }
                 },
-                "hasThumbnail": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Share": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.Message"
-                    }
+                "createdBy": {
+                    "type": "string"
                 },
-                "name": {
+                "expiresAt": {
                     "type": "string"
                 },
-                "outputType": {
+                "hasPassword": {
+                    "description": "パスワードを設定しているか",
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "string"
                 },
-                "progress": {
+                "lastViewedAt": {
                     "type": "string"
                 },
-                "status": {
+                "maxViews": {
+                    "description": "閲覧できる回数の上限。0の場合は制限しません",
+                    "type": "integer"
+                },
+                "modelId": {
+                    "type": "string"
+                },
+                "revokedAt": {
                     "type": "string"
+                },
+                "urn": {
+                    "type": "string"
+                },
+                "viewCount": {
+                    "description": "Viewer用トークンと引き換えた回数",
+                    "type": "integer"
                 }
             }
         },
-        "domain.Message": {
+        "domain.ShareExchangeRequest": {
             "type": "object",
             "properties": {
-                "code": {
+                "password": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "type": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "domain.Permission": {
+        "domain.ShareRefreshRequest": {
             "type": "object",
             "properties": {
-                "access": {
+                "access_token": {
+                    "description": "有効期限内のViewer用トークン",
                     "type": "string"
+                }
+            }
+        },
+        "domain.ShareRequest": {
+            "type": "object",
+            "properties": {
+                "expiresIn": {
+                    "description": "有効期間（秒）。省略した場合はサーバーの既定値",
+                    "type": "integer",
+                    "example": 604800
                 },
-                "authId": {
+                "maxViews": {
+                    "description": "閲覧できる回数の上限。0の場合は制限しません",
+                    "type": "integer",
+                    "example": 10
+                },
+                "password": {
+                    "description": "設定した場合は引き換えにパスワードが必要です",
                     "type": "string"
                 }
             }
         },
-        "domain.Resource": {
+        "domain.ShareViewerToken": {
             "type": "object",
             "properties": {
-                "guid": {
-                    "type": "string"
-                },
-                "mime": {
+                "access_token": {
                     "type": "string"
                 },
-                "resolution": {
-                    "type": "array",
-                    "items": {
-                        "type": "number"
-                    }
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
                 },
-                "role": {
+                "modelName": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
                 },
                 "urn": {
                     "type": "string"
@@ -887,6 +5173,56 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "problem.Details": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "errorCode": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/aps/buckets/my-bucket/details"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                },
+                "upstreamStatus": {
+                    "type": "integer",
+                    "example": 404
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWTを\"Bearer \u003ctoken\u003e\"の形式で指定します",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,340 @@
+package config
+
+import (
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_client"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/logging"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/ratelimit"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+// 伏せ字にした値
+const redacted = "REDACTED"
+
+// Config はバックエンドの設定
+// 既定値、YAMLファイル、.envファイル、環境変数の順に読み込み、後のものほど優先します
+type Config struct {
+	Server  Server  `yaml:"server"`
+	APS     APS     `yaml:"aps"`
+	Upload  Upload  `yaml:"upload"`
+	Storage Storage `yaml:"storage"`
+	Mesh    Mesh    `yaml:"mesh"`
+	Search  Search  `yaml:"search"`
+	Share   Share   `yaml:"share"`
+	// TRACING_EXPORTER・TRACING_OTLP_ENDPOINT・TRACING_SERVICE_NAME・TRACING_SAMPLE_RATIO
+	Tracing tracing.Options `yaml:"tracing"`
+	// LOG_LEVEL・LOG_FORMAT
+	Log logging.Options `yaml:"log"`
+	// AUTH_API_KEYS（name:key:role をカンマ区切り）・AUTH_JWT_JWKS_FILE・AUTH_JWT_HMAC_SECRET・AUTH_JWT_ISSUER・AUTH_JWT_AUDIENCE・AUTH_JWT_LEEWAY
+	// AUTH_JWT_ROLES_CLAIM・AUTH_JWT_GROUPS_CLAIM
+	Auth auth.Options `yaml:"auth"`
+	// RATE_LIMIT_ENABLED・RATE_LIMIT_BACKEND・RATE_LIMIT_REDIS_URL・RATE_LIMIT_TRUST_FORWARDED_FOR
+	// RATE_LIMIT_TOKEN・RATE_LIMIT_UPLOAD・RATE_LIMIT_TRANSLATE・RATE_LIMIT_READ（1分あたりの回数:バースト）
+	RateLimit ratelimit.Options `yaml:"rateLimit"`
+}
+
+// Server はHTTPサーバーの設定
+type Server struct {
+	// 待ち受けるポート（PORT）
+	Port string `yaml:"port"`
+	// CORSで許可するオリジン（CORS_ORIGINS、カンマ区切り）。*ですべて許可します
+	CORSOrigins []string `yaml:"corsOrigins"`
+	// CORSで許可するメソッド（CORS_METHODS、カンマ区切り）
+	CORSMethods []string `yaml:"corsMethods"`
+	// Cookieなどの資格情報を含むリクエストを許可するか（CORS_ALLOW_CREDENTIALS）。オリジンに*を使う場合は有効にできません
+	CORSAllowCredentials bool `yaml:"corsAllowCredentials"`
+
+	// SERVER_READ_HEADER_TIMEOUT・SERVER_READ_TIMEOUT・SERVER_WRITE_TIMEOUT・SERVER_IDLE_TIMEOUT。0の場合はタイムアウトしません
+	// 読み込みはアップロード、書き込みはバンドルや派生ファイルのダウンロードを含むため長めにします
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	// 停止時に処理中のリクエストの完了を待つ時間（SERVER_SHUTDOWN_TIMEOUT）
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+
+	// TLS_CERT_FILE・TLS_KEY_FILE・TLS_CLIENT_CA_FILE
+	TLS TLS `yaml:"tls"`
+
+	// 管理用エンドポイント（/debug/varsなど）を提供するアドレス（ADMIN_ADDR、例: 127.0.0.1:9090）
+	// 空の場合はAPIと同じポートで提供します
+	AdminAddr string `yaml:"adminAddr"`
+}
+
+// TLS はAPIサーバーのTLSの設定。証明書を指定しない場合はHTTPで待ち受けます
+type TLS struct {
+	// サーバー証明書と秘密鍵のPEMファイル
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	// クライアント証明書を検証するCA証明書のPEMファイル。指定した場合はクライアント証明書を必須にします（mTLS）
+	ClientCAFile string `yaml:"clientCaFile"`
+}
+
+// Enabled はTLSで待ち受けるか判定します
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// APS はAPS呼び出しの設定
+type APS struct {
+	// APS_CLIENT_ID
+	ClientID string `yaml:"clientId"`
+	// APS_CLIENT_SECRET
+	ClientSecret string `yaml:"clientSecret"`
+
+	// APS_BASE_URL。空の場合はhttps://developer.api.autodesk.com
+	BaseURL string `yaml:"baseUrl"`
+	// APS_AUTH_BASE_URL・APS_OSS_BASE_URL・APS_MD_BASE_URL・APS_VIEWER_BASE_URL。空の場合はBaseURLから決めます
+	AuthBaseURL   string `yaml:"authBaseUrl"`
+	OSSBaseURL    string `yaml:"ossBaseUrl"`
+	MDBaseURL     string `yaml:"mdBaseUrl"`
+	ViewerBaseURL string `yaml:"viewerBaseUrl"`
+	// APS_SIGNED_HOSTS（カンマ区切り）。空の場合は既定のホストとBaseURLのホスト
+	SignedHosts []string `yaml:"signedHosts"`
+
+	// APS_FAKE・APS_FAKE_TRANSLATION_TIME
+	Fake                bool          `yaml:"fake"`
+	FakeTranslationTime time.Duration `yaml:"fakeTranslationTime"`
+
+	// APS_CASSETTE_MODE（off, record, replay）・APS_CASSETTE
+	CassetteMode string `yaml:"cassetteMode"`
+	Cassette     string `yaml:"cassette"`
+
+	// APS_TIMEOUT_AUTH・APS_TIMEOUT_API・APS_TIMEOUT_UPLOAD・APS_TIMEOUT_DOWNLOAD
+	Timeouts aps_timeout.Timeouts `yaml:"timeouts"`
+	// APS_RETRY_MAX・APS_RETRY_BASE_DELAY・APS_RETRY_MAX_DELAY
+	Retry aps_client.RetryPolicy `yaml:"retry"`
+
+	// /readyzでAPSの確認結果を再利用する時間（APS_HEALTH_CACHE_TTL）
+	HealthCacheTTL time.Duration `yaml:"healthCacheTtl"`
+}
+
+// Upload はファイルのアップロードの設定
+type Upload struct {
+	// アップロードシーケンスで受け付けるファイルの上限バイト数（UPLOAD_MAX_BYTES）
+	MaxBytes int64 `yaml:"maxBytes"`
+	// 署名付きURLへのPUTで受け付けるパートの上限バイト数（UPLOAD_MAX_PART_BYTES）
+	MaxPartBytes int64 `yaml:"maxPartBytes"`
+}
+
+// Storage はローカルに保存するデータの設定
+type Storage struct {
+	// DATA_DIR
+	DataDir string `yaml:"dataDir"`
+	// モデルのカタログのSQLiteファイル（CATALOG_PATH）。空の場合はDataDir配下のcatalog.db
+	CatalogPath string `yaml:"catalogPath"`
+	// APS_BUNDLE_WORK_DIR。空の場合はOSの一時ディレクトリ配下
+	BundleWorkDir string `yaml:"bundleWorkDir"`
+	// APS_BUNDLE_CONCURRENCY
+	BundleConcurrency int `yaml:"bundleConcurrency"`
+	// APS_PROXY_CACHE_DIR。空の場合はOSの一時ディレクトリ配下
+	ProxyCacheDir string `yaml:"proxyCacheDir"`
+	// APS_PROXY_CACHE_MAX_BYTES
+	ProxyCacheMaxBytes int64 `yaml:"proxyCacheMaxBytes"`
+}
+
+// Mesh はメッシュのGLB変換の設定
+type Mesh struct {
+	// MESH_MAX_REQUEST_BYTES・MESH_MAX_VERTICES・MESH_MAX_TRIANGLES
+	MaxRequestBytes int64 `yaml:"maxRequestBytes"`
+	MaxVertices     int   `yaml:"maxVertices"`
+	MaxTriangles    int   `yaml:"maxTriangles"`
+	// MESH_TARGET_TRIANGLES・MESH_WELD_TOLERANCE
+	TargetTriangles int     `yaml:"targetTriangles"`
+	WeldTolerance   float64 `yaml:"weldTolerance"`
+}
+
+// Search はモデルの全文検索の設定
+type Search struct {
+	// 翻訳の完了後に要素の名前とプロパティの値を索引に登録するか（SEARCH_INDEX_PROPERTIES）
+	IndexProperties bool `yaml:"indexProperties"`
+	// 索引に登録するビューごとのプロパティのレスポンスの上限バイト数（SEARCH_MAX_PROPERTY_BYTES）
+	MaxPropertyBytes int64 `yaml:"maxPropertyBytes"`
+}
+
+// Share はモデルの共有リンクの設定
+type Share struct {
+	// 共有リンクで発行するViewer用トークンの署名鍵（SHARE_TOKEN_SECRET、32バイト以上）
+	// 空の場合は起動ごとに生成するため、再起動やレプリカ間で発行済みのViewer用トークンが無効になります
+	TokenSecret string `yaml:"tokenSecret"`
+	// 有効期限を指定しない共有リンクの有効期間（SHARE_DEFAULT_TTL）と、指定できる有効期間の上限（SHARE_MAX_TTL）
+	DefaultTTL time.Duration `yaml:"defaultTtl"`
+	MaxTTL     time.Duration `yaml:"maxTtl"`
+	// 共有リンクと引き換えに発行するViewer用トークンの有効期間（SHARE_VIEWER_TOKEN_TTL）
+	ViewerTokenTTL time.Duration `yaml:"viewerTokenTtl"`
+
+	// 共有リンクと埋め込みのURLに使う、外部から見たバックエンドのベースURL（SHARE_PUBLIC_URL）
+	// 空の場合はリクエストのホストから組み立て、共有リンクの作成のレスポンスにURLを含めません
+	PublicURL string `yaml:"publicUrl"`
+	// 埋め込みページをiframeで表示できるドメイン（SHARE_EMBED_DOMAINS、カンマ区切り）。*.example.comの形式でサブドメインを許可します
+	// 空の場合は同じオリジンからのみ表示できます
+	EmbedDomains []string `yaml:"embedDomains"`
+	// oEmbedで返すiframeの既定の幅と高さ（SHARE_EMBED_WIDTH・SHARE_EMBED_HEIGHT）
+	EmbedWidth  int `yaml:"embedWidth"`
+	EmbedHeight int `yaml:"embedHeight"`
+}
+
+// Default は既定の設定を返します
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Port:              "8080",
+			CORSOrigins:       []string{"*"},
+			CORSMethods:       []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       15 * time.Minute,
+			WriteTimeout:      30 * time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   time.Minute,
+		},
+		APS: APS{
+			FakeTranslationTime: 10 * time.Second,
+			Timeouts:            aps_timeout.Default(),
+			Retry:               aps_client.DefaultRetryPolicy(),
+			HealthCacheTTL:      30 * time.Second,
+		},
+		Upload: Upload{
+			MaxBytes:     1 << 30,
+			MaxPartBytes: 32 << 20,
+		},
+		Storage: Storage{
+			DataDir:            "data",
+			BundleConcurrency:  4,
+			ProxyCacheMaxBytes: 5 << 30,
+		},
+		Mesh: Mesh{
+			MaxRequestBytes: 256 << 20,
+			MaxVertices:     5_000_000,
+			MaxTriangles:    5_000_000,
+			TargetTriangles: 500_000,
+			WeldTolerance:   1e-4,
+		},
+		Search: Search{
+			MaxPropertyBytes: 64 << 20,
+		},
+		Share: Share{
+			DefaultTTL:     7 * 24 * time.Hour,
+			MaxTTL:         90 * 24 * time.Hour,
+			ViewerTokenTTL: 15 * time.Minute,
+			EmbedWidth:     800,
+			EmbedHeight:    600,
+		},
+		Tracing:   tracing.DefaultOptions(),
+		Log:       logging.DefaultOptions(),
+		Auth:      auth.DefaultOptions(),
+		RateLimit: ratelimit.DefaultOptions(),
+	}
+}
+
+// Endpoints はAPSのベースURLを返します
+func (a APS) Endpoints() aps_endpoint.Endpoints {
+	e := aps_endpoint.Default()
+	if a.BaseURL != "" {
+		e = aps_endpoint.ForHost(a.BaseURL, e.SignedHosts...)
+	}
+	for _, o := range []struct {
+		value string
+		dst   *string
+	}{
+		{a.AuthBaseURL, &e.Auth},
+		{a.OSSBaseURL, &e.OSS},
+		{a.MDBaseURL, &e.ModelDerivative},
+		{a.ViewerBaseURL, &e.Viewer},
+	} {
+		if o.value != "" {
+			*o.dst = trimSlash(o.value)
+		}
+	}
+	if len(a.SignedHosts) > 0 {
+		e.SignedHosts = nil
+		for _, host := range a.SignedHosts {
+			e.SignedHosts = append(e.SignedHosts, strings.ToLower(host))
+		}
+	}
+	return e
+}
+
+// CatalogFile はモデルのカタログのSQLiteファイルのパスを返します
+func (s Storage) CatalogFile() string {
+	if s.CatalogPath != "" {
+		return s.CatalogPath
+	}
+	return filepath.Join(s.DataDir, "catalog.db")
+}
+
+// Limits はメッシュのGLB変換の上限を返します
+func (m Mesh) Limits() domain.MeshLimits {
+	return domain.MeshLimits{
+		MaxRequestBytes:        m.MaxRequestBytes,
+		MaxVertices:            m.MaxVertices,
+		MaxTriangles:           m.MaxTriangles,
+		DefaultTargetTriangles: m.TargetTriangles,
+		DefaultWeldTolerance:   m.WeldTolerance,
+	}
+}
+
+// Policy は共有リンクのユースケースに渡す有効期間の既定値と上限を返します
+func (s Share) Policy() domain.SharePolicy {
+	return domain.SharePolicy{
+		DefaultTTL:     s.DefaultTTL,
+		MaxTTL:         s.MaxTTL,
+		ViewerTokenTTL: s.ViewerTokenTTL,
+		PublicURL:      trimSlash(s.PublicURL),
+	}
+}
+
+// Redacted はシークレットを伏せ字にしたコピーを返します
+func (c *Config) Redacted() *Config {
+	r := *c
+	if r.APS.ClientSecret != "" {
+		r.APS.ClientSecret = redacted
+	}
+	r.Auth.APIKeys = nil
+	for _, key := range c.Auth.APIKeys {
+		key.Key = redacted
+		r.Auth.APIKeys = append(r.Auth.APIKeys, key)
+	}
+	if r.Auth.JWT.HMACSecret != "" {
+		r.Auth.JWT.HMACSecret = redacted
+	}
+	if r.Share.TokenSecret != "" {
+		r.Share.TokenSecret = redacted
+	}
+	if r.RateLimit.RedisURL != "" {
+		r.RateLimit.RedisURL = redactURLPassword(r.RateLimit.RedisURL)
+	}
+	return &r
+}
+
+// redactURLPassword はURLに含まれるパスワードを伏せ字にします
+func redactURLPassword(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return redacted
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
+}
+
+// WriteYAML は設定をYAMLで書き出します
+func (c *Config) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/ratelimit"
+)
+
+// Options は設定の読み込み元
+type Options struct {
+	// YAMLファイルのパス。空の場合は読み込みません
+	File string
+	// .envファイルのパス。ファイルがない場合は読み込みません
+	EnvFile string
+}
+
+// Load は既定値にYAMLファイル、.envファイル、環境変数の順に上書きした設定を返します
+// .envの値は同じ名前の環境変数がない場合のみ使います。値の検証はValidateで行います
+func Load(opts Options) (*Config, error) {
+	c := Default()
+
+	if opts.File != "" {
+		data, err := os.ReadFile(opts.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		// 項目名の誤りに気付けるよう、未知の項目はエラーにする
+		dec.KnownFields(true)
+		if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", opts.File, err)
+		}
+	}
+
+	dotenv := map[string]string{}
+	if opts.EnvFile != "" {
+		values, err := godotenv.Read(opts.EnvFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", opts.EnvFile, err)
+		}
+		if values != nil {
+			dotenv = values
+		}
+	}
+
+	lookup := func(name string) (string, bool) {
+		if v, ok := os.LookupEnv(name); ok {
+			return v, true
+		}
+		v, ok := dotenv[name]
+		return v, ok
+	}
+	if err := c.applyEnv(lookup); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// applyEnv は環境変数で設定を上書きします。値が空の環境変数は未設定として扱います
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	b := &binder{lookup: lookup}
+
+	b.string("PORT", &c.Server.Port)
+	b.list("CORS_ORIGINS", &c.Server.CORSOrigins)
+	b.list("CORS_METHODS", &c.Server.CORSMethods)
+	b.bool("CORS_ALLOW_CREDENTIALS", &c.Server.CORSAllowCredentials)
+	b.duration("SERVER_READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout)
+	b.duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	b.duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	b.duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	b.duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	b.string("TLS_CERT_FILE", &c.Server.TLS.CertFile)
+	b.string("TLS_KEY_FILE", &c.Server.TLS.KeyFile)
+	b.string("TLS_CLIENT_CA_FILE", &c.Server.TLS.ClientCAFile)
+	b.string("ADMIN_ADDR", &c.Server.AdminAddr)
+
+	b.string("APS_CLIENT_ID", &c.APS.ClientID)
+	b.string("APS_CLIENT_SECRET", &c.APS.ClientSecret)
+	b.string("APS_BASE_URL", &c.APS.BaseURL)
+	b.string("APS_AUTH_BASE_URL", &c.APS.AuthBaseURL)
+	b.string("APS_OSS_BASE_URL", &c.APS.OSSBaseURL)
+	b.string("APS_MD_BASE_URL", &c.APS.MDBaseURL)
+	b.string("APS_VIEWER_BASE_URL", &c.APS.ViewerBaseURL)
+	b.list("APS_SIGNED_HOSTS", &c.APS.SignedHosts)
+	b.bool("APS_FAKE", &c.APS.Fake)
+	b.duration("APS_FAKE_TRANSLATION_TIME", &c.APS.FakeTranslationTime)
+	b.string("APS_CASSETTE_MODE", &c.APS.CassetteMode)
+	b.string("APS_CASSETTE", &c.APS.Cassette)
+	b.duration("APS_TIMEOUT_AUTH", &c.APS.Timeouts.Auth)
+	b.duration("APS_TIMEOUT_API", &c.APS.Timeouts.API)
+	b.duration("APS_TIMEOUT_UPLOAD", &c.APS.Timeouts.Upload)
+	b.duration("APS_TIMEOUT_DOWNLOAD", &c.APS.Timeouts.Download)
+	b.int("APS_RETRY_MAX", &c.APS.Retry.MaxRetries)
+	b.duration("APS_RETRY_BASE_DELAY", &c.APS.Retry.BaseDelay)
+	b.duration("APS_RETRY_MAX_DELAY", &c.APS.Retry.MaxDelay)
+	b.duration("APS_HEALTH_CACHE_TTL", &c.APS.HealthCacheTTL)
+
+	b.int64("UPLOAD_MAX_BYTES", &c.Upload.MaxBytes)
+	b.int64("UPLOAD_MAX_PART_BYTES", &c.Upload.MaxPartBytes)
+
+	b.string("DATA_DIR", &c.Storage.DataDir)
+	b.string("CATALOG_PATH", &c.Storage.CatalogPath)
+	b.string("APS_BUNDLE_WORK_DIR", &c.Storage.BundleWorkDir)
+	b.int("APS_BUNDLE_CONCURRENCY", &c.Storage.BundleConcurrency)
+	b.string("APS_PROXY_CACHE_DIR", &c.Storage.ProxyCacheDir)
+	b.int64("APS_PROXY_CACHE_MAX_BYTES", &c.Storage.ProxyCacheMaxBytes)
+
+	b.int64("MESH_MAX_REQUEST_BYTES", &c.Mesh.MaxRequestBytes)
+	b.int("MESH_MAX_VERTICES", &c.Mesh.MaxVertices)
+	b.int("MESH_MAX_TRIANGLES", &c.Mesh.MaxTriangles)
+	b.int("MESH_TARGET_TRIANGLES", &c.Mesh.TargetTriangles)
+	b.float("MESH_WELD_TOLERANCE", &c.Mesh.WeldTolerance)
+
+	b.bool("SEARCH_INDEX_PROPERTIES", &c.Search.IndexProperties)
+	b.int64("SEARCH_MAX_PROPERTY_BYTES", &c.Search.MaxPropertyBytes)
+
+	b.string("SHARE_TOKEN_SECRET", &c.Share.TokenSecret)
+	b.duration("SHARE_DEFAULT_TTL", &c.Share.DefaultTTL)
+	b.duration("SHARE_MAX_TTL", &c.Share.MaxTTL)
+	b.duration("SHARE_VIEWER_TOKEN_TTL", &c.Share.ViewerTokenTTL)
+	b.string("SHARE_PUBLIC_URL", &c.Share.PublicURL)
+	b.list("SHARE_EMBED_DOMAINS", &c.Share.EmbedDomains)
+	b.int("SHARE_EMBED_WIDTH", &c.Share.EmbedWidth)
+	b.int("SHARE_EMBED_HEIGHT", &c.Share.EmbedHeight)
+
+	b.string("TRACING_EXPORTER", &c.Tracing.Exporter)
+	b.string("TRACING_OTLP_ENDPOINT", &c.Tracing.OTLPEndpoint)
+	b.string("TRACING_SERVICE_NAME", &c.Tracing.ServiceName)
+	b.float("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	b.string("LOG_LEVEL", &c.Log.Level)
+	b.string("LOG_FORMAT", &c.Log.Format)
+
+	b.bool("AUTH_DISABLED", &c.Auth.Disabled)
+	b.apiKeys("AUTH_API_KEYS", &c.Auth.APIKeys)
+	b.string("AUTH_JWT_JWKS_FILE", &c.Auth.JWT.JWKSFile)
+	b.string("AUTH_JWT_HMAC_SECRET", &c.Auth.JWT.HMACSecret)
+	b.string("AUTH_JWT_ISSUER", &c.Auth.JWT.Issuer)
+	b.string("AUTH_JWT_AUDIENCE", &c.Auth.JWT.Audience)
+	b.duration("AUTH_JWT_LEEWAY", &c.Auth.JWT.Leeway)
+	b.string("AUTH_JWT_ROLES_CLAIM", &c.Auth.JWT.RolesClaim)
+	b.string("AUTH_JWT_GROUPS_CLAIM", &c.Auth.JWT.GroupsClaim)
+
+	b.bool("RATE_LIMIT_ENABLED", &c.RateLimit.Enabled)
+	b.string("RATE_LIMIT_BACKEND", &c.RateLimit.Backend)
+	b.string("RATE_LIMIT_REDIS_URL", &c.RateLimit.RedisURL)
+	b.bool("RATE_LIMIT_TRUST_FORWARDED_FOR", &c.RateLimit.TrustForwardedFor)
+	b.rateLimit("RATE_LIMIT_TOKEN", &c.RateLimit.Token)
+	b.rateLimit("RATE_LIMIT_UPLOAD", &c.RateLimit.Upload)
+	b.rateLimit("RATE_LIMIT_TRANSLATE", &c.RateLimit.Translate)
+	b.rateLimit("RATE_LIMIT_READ", &c.RateLimit.Read)
+
+	return errors.Join(b.errs...)
+}
+
+// binder は環境変数の値を型に合わせて変換し、変換できない値をまとめて報告します
+type binder struct {
+	lookup func(string) (string, bool)
+	errs   []error
+}
+
+func (b *binder) value(name string) (string, bool) {
+	v, ok := b.lookup(name)
+	v = strings.TrimSpace(v)
+	return v, ok && v != ""
+}
+
+func (b *binder) invalid(name string, v string) {
+	b.errs = append(b.errs, fmt.Errorf("invalid %s: %q", name, v))
+}
+
+func (b *binder) string(name string, dst *string) {
+	if v, ok := b.value(name); ok {
+		*dst = v
+	}
+}
+
+func (b *binder) list(name string, dst *[]string) {
+	v, ok := b.value(name)
+	if !ok {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+// apiKeys は name:key または name:key:role をカンマ区切りで並べたAPIキーを読み込みます
+func (b *binder) apiKeys(name string, dst *[]auth.APIKey) {
+	var items []string
+	b.list(name, &items)
+	if items == nil {
+		return
+	}
+	var keys []auth.APIKey
+	for _, item := range items {
+		fields := strings.Split(item, ":")
+		if len(fields) < 2 || len(fields) > 3 || fields[0] == "" || fields[1] == "" {
+			// キーの値を含むため、エラーには名前のみ出す
+			b.errs = append(b.errs, fmt.Errorf("invalid %s: entries must be name:key or name:key:role (%q)", name, fields[0]))
+			return
+		}
+		key := auth.APIKey{Name: strings.TrimSpace(fields[0]), Key: strings.TrimSpace(fields[1])}
+		if len(fields) == 3 {
+			key.Roles = []string{strings.TrimSpace(fields[2])}
+		}
+		keys = append(keys, key)
+	}
+	*dst = keys
+}
+
+// rateLimit は perMinute:burst の形式のレート制限を読み込みます。バーストを省略した場合は1分あたりの回数と同じにします
+func (b *binder) rateLimit(name string, dst *ratelimit.Limit) {
+	v, ok := b.value(name)
+	if !ok {
+		return
+	}
+	perMinute, burst, hasBurst := strings.Cut(v, ":")
+	limit := ratelimit.Limit{}
+	var err error
+	if limit.PerMinute, err = strconv.ParseFloat(strings.TrimSpace(perMinute), 64); err != nil {
+		b.invalid(name, v)
+		return
+	}
+	limit.Burst = int(limit.PerMinute)
+	if hasBurst {
+		if limit.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil {
+			b.invalid(name, v)
+			return
+		}
+	}
+	*dst = limit
+}
+
+func (b *binder) bool(name string, dst *bool) {
+	if v, ok := b.value(name); ok {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			b.invalid(name, v)
+			return
+		}
+		*dst = parsed
+	}
+}
+
+func (b *binder) int(name string, dst *int) {
+	if v, ok := b.value(name); ok {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			b.invalid(name, v)
+			return
+		}
+		*dst = parsed
+	}
+}
+
+func (b *binder) int64(name string, dst *int64) {
+	if v, ok := b.value(name); ok {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			b.invalid(name, v)
+			return
+		}
+		*dst = parsed
+	}
+}
+
+func (b *binder) float(name string, dst *float64) {
+	if v, ok := b.value(name); ok {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			b.invalid(name, v)
+			return
+		}
+		*dst = parsed
+	}
+}
+
+func (b *binder) duration(name string, dst *time.Duration) {
+	if v, ok := b.value(name); ok {
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			b.invalid(name, v)
+			return
+		}
+		*dst = parsed
+	}
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/logging"
+)
+
+// Validate は設定の値を検証し、問題をまとめて返します
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port <= 65535, "server.port must be between 1 and 65535: %q", c.Server.Port)
+	for _, origin := range c.Server.CORSOrigins {
+		check(origin == "*" || isOrigin(origin), "server.corsOrigins must be * or an origin like https://example.com: %q", origin)
+		// ブラウザは資格情報付きのリクエストにAccess-Control-Allow-Origin: *を受け付けない
+		check(origin != "*" || !c.Server.CORSAllowCredentials, "server.corsAllowCredentials requires explicit server.corsOrigins instead of *")
+	}
+	check(len(c.Server.CORSMethods) > 0, "server.corsMethods must not be empty")
+	for _, method := range c.Server.CORSMethods {
+		check(corsMethods[method], "server.corsMethods must be GET, HEAD, POST, PUT, PATCH, DELETE or OPTIONS: %q", method)
+	}
+	check(c.Server.ReadHeaderTimeout >= 0 && c.Server.ReadTimeout >= 0 && c.Server.WriteTimeout >= 0 && c.Server.IdleTimeout >= 0,
+		"server timeouts must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+	tls := c.Server.TLS
+	check((tls.CertFile == "") == (tls.KeyFile == ""), "server.tls.certFile and server.tls.keyFile must be set together")
+	check(tls.ClientCAFile == "" || tls.Enabled(), "server.tls.clientCaFile requires server.tls.certFile")
+	for name, file := range map[string]string{
+		"server.tls.certFile":     tls.CertFile,
+		"server.tls.keyFile":      tls.KeyFile,
+		"server.tls.clientCaFile": tls.ClientCAFile,
+	} {
+		if file != "" {
+			_, err := os.Stat(file)
+			check(err == nil, "%s: %v", name, err)
+		}
+	}
+	if c.Server.AdminAddr != "" {
+		_, adminPort, err := net.SplitHostPort(c.Server.AdminAddr)
+		check(err == nil, "server.adminAddr must be host:port: %q", c.Server.AdminAddr)
+		check(err != nil || adminPort != c.Server.Port, "server.adminAddr must use a different port from server.port")
+	}
+
+	// 偽のAPSサーバーやカセットの再生ではAPSに接続しないため認証情報は不要
+	if !c.APS.Fake && c.APS.CassetteMode != "replay" {
+		check(c.APS.ClientID != "", "aps.clientId (APS_CLIENT_ID) is required")
+		check(c.APS.ClientSecret != "", "aps.clientSecret (APS_CLIENT_SECRET) is required")
+	}
+	for name, v := range map[string]string{
+		"aps.baseUrl":       c.APS.BaseURL,
+		"aps.authBaseUrl":   c.APS.AuthBaseURL,
+		"aps.ossBaseUrl":    c.APS.OSSBaseURL,
+		"aps.mdBaseUrl":     c.APS.MDBaseURL,
+		"aps.viewerBaseUrl": c.APS.ViewerBaseURL,
+	} {
+		check(v == "" || isBaseURL(v), "%s must be an http(s) URL: %q", name, v)
+	}
+	check(c.APS.FakeTranslationTime > 0, "aps.fakeTranslationTime must be positive")
+	switch c.APS.CassetteMode {
+	case "", "off":
+	case "record", "replay":
+		check(c.APS.Cassette != "", "aps.cassette (APS_CASSETTE) is required when aps.cassetteMode is %s", c.APS.CassetteMode)
+	default:
+		check(false, "aps.cassetteMode must be off, record or replay: %q", c.APS.CassetteMode)
+	}
+	check(c.APS.Timeouts.Auth >= 0 && c.APS.Timeouts.API >= 0 && c.APS.Timeouts.Upload >= 0 && c.APS.Timeouts.Download >= 0,
+		"aps.timeouts must not be negative")
+	check(c.APS.Retry.MaxRetries >= 0, "aps.retry.maxRetries must not be negative")
+	check(c.APS.Retry.BaseDelay > 0 && c.APS.Retry.MaxDelay > 0, "aps.retry delays must be positive")
+	check(c.APS.HealthCacheTTL >= 0, "aps.healthCacheTtl must not be negative")
+
+	check(c.Upload.MaxBytes > 0, "upload.maxBytes must be positive")
+	check(c.Upload.MaxPartBytes > 0, "upload.maxPartBytes must be positive")
+
+	check(c.Storage.DataDir != "", "storage.dataDir is required")
+	check(c.Storage.BundleConcurrency > 0, "storage.bundleConcurrency must be positive")
+	check(c.Storage.ProxyCacheMaxBytes > 0, "storage.proxyCacheMaxBytes must be positive")
+
+	check(c.Mesh.MaxRequestBytes > 0, "mesh.maxRequestBytes must be positive")
+	check(c.Mesh.MaxVertices > 0, "mesh.maxVertices must be positive")
+	check(c.Mesh.MaxTriangles > 0, "mesh.maxTriangles must be positive")
+	check(c.Mesh.TargetTriangles > 0, "mesh.targetTriangles must be positive")
+	check(c.Mesh.WeldTolerance >= 0, "mesh.weldTolerance must not be negative")
+
+	check(c.Search.MaxPropertyBytes > 0, "search.maxPropertyBytes must be positive")
+
+	check(c.Share.TokenSecret == "" || len(c.Share.TokenSecret) >= 32, "share.tokenSecret must be at least 32 bytes")
+	check(c.Share.DefaultTTL > 0, "share.defaultTtl must be positive")
+	check(c.Share.MaxTTL >= c.Share.DefaultTTL, "share.maxTtl must not be shorter than share.defaultTtl")
+	check(c.Share.ViewerTokenTTL > 0, "share.viewerTokenTtl must be positive")
+	check(c.Share.PublicURL == "" || isBaseURL(c.Share.PublicURL), "share.publicUrl must be an http(s) URL: %q", c.Share.PublicURL)
+	for _, domain := range c.Share.EmbedDomains {
+		check(isEmbedDomain(domain), "share.embedDomains must be hosts such as wiki.example.com or *.example.com: %q", domain)
+	}
+	check(c.Share.EmbedWidth > 0 && c.Share.EmbedHeight > 0, "share.embedWidth and share.embedHeight must be positive")
+
+	switch c.Tracing.Exporter {
+	case "", "none", "stdout", "otlp":
+	default:
+		check(false, "tracing.exporter must be none, stdout or otlp: %q", c.Tracing.Exporter)
+	}
+	check(c.Tracing.OTLPEndpoint == "" || isBaseURL(c.Tracing.OTLPEndpoint), "tracing.otlpEndpoint must be an http(s) URL: %q", c.Tracing.OTLPEndpoint)
+	check(c.Tracing.ServiceName != "", "tracing.serviceName is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio must be between 0 and 1")
+
+	_, err = logging.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level must be debug, info, warn or error: %q", c.Log.Level)
+	check(c.Log.Format == "text" || c.Log.Format == "json", "log.format must be text or json: %q", c.Log.Format)
+
+	// 設定漏れで認証なしのまま公開しないよう、認証を無効にするには明示的な指定を必要とする
+	check(c.Auth.Enabled() || c.Auth.Disabled, "auth is not configured: set auth.apiKeys (AUTH_API_KEYS) or auth.jwt, or auth.disabled (AUTH_DISABLED) for local development only")
+	check(!c.Auth.Enabled() || !c.Auth.Disabled, "auth.disabled cannot be combined with auth.apiKeys or auth.jwt")
+	names := map[string]bool{}
+	for i, key := range c.Auth.APIKeys {
+		check(key.Name != "", "auth.apiKeys[%d].name is required", i)
+		check(!names[key.Name], "auth.apiKeys names must be unique: %q", key.Name)
+		names[key.Name] = true
+		if _, err := auth.HashAPIKey(key.Key); err != nil {
+			check(false, "auth.apiKeys[%d].key: %v", i, err)
+		} else if !strings.HasPrefix(key.Key, "sha256:") {
+			check(len(key.Key) >= 16, "auth.apiKeys[%d].key must be at least 16 characters", i)
+		}
+		for _, role := range key.Roles {
+			check(domain.RoleRank(role) > 0, "auth.apiKeys[%d].roles must be viewer, uploader or admin: %q", i, role)
+		}
+	}
+	jwt := c.Auth.JWT
+	check(jwt.HMACSecret == "" || len(jwt.HMACSecret) >= 32, "auth.jwt.hmacSecret must be at least 32 bytes")
+	if jwt.JWKSFile != "" {
+		_, err := os.Stat(jwt.JWKSFile)
+		check(err == nil, "auth.jwt.jwksFile: %v", err)
+	}
+	check(jwt.Leeway >= 0, "auth.jwt.leeway must not be negative")
+
+	rl := c.RateLimit
+	switch rl.Backend {
+	case "", "memory":
+	case "redis":
+		check(rl.RedisURL != "", "rateLimit.redisUrl (RATE_LIMIT_REDIS_URL) is required when rateLimit.backend is redis")
+		u, err := url.Parse(rl.RedisURL)
+		check(rl.RedisURL == "" || err == nil && (u.Scheme == "redis" || u.Scheme == "rediss"), "rateLimit.redisUrl must be a redis:// or rediss:// URL")
+	default:
+		check(false, "rateLimit.backend must be memory or redis: %q", rl.Backend)
+	}
+	for class, limit := range rl.Limits() {
+		check(limit.PerMinute >= 0, "rateLimit.%s.perMinute must not be negative", class)
+		check(!limit.Enabled() || limit.Burst >= 1, "rateLimit.%s.burst must be at least 1", class)
+	}
+
+	return errors.Join(errs...)
+}
+
+// CORSで許可できるメソッド
+var corsMethods = map[string]bool{"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "OPTIONS": true}
+
+// isBaseURL はベースURLとして使えるhttp(s)のURLか判定します
+func isBaseURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+// isOrigin はパスを含まないhttp(s)のオリジンか判定します
+func isOrigin(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && isBaseURL(s) && (u.Path == "" || u.Path == "/") && u.RawQuery == ""
+}
+
+// isEmbedDomain はCSPのframe-ancestorsに指定できるホスト（先頭の*.とhttp(s)://、末尾のポートを許可）か判定します
+func isEmbedDomain(s string) bool {
+	host := s
+	for _, scheme := range []string{"https://", "http://"} {
+		host = strings.TrimPrefix(host, scheme)
+	}
+	host = strings.TrimPrefix(host, "*.")
+	if h, port, ok := strings.Cut(host, ":"); ok {
+		if port == "" || strings.Trim(port, "0123456789") != "" {
+			return false
+		}
+		host = h
+	}
+	return host != "" && strings.Trim(strings.ToLower(host), "abcdefghijklmnopqrstuvwxyz0123456789.-") == ""
+}
+
+// trimSlash は末尾のスラッシュを取り除きます
+func trimSlash(s string) string {
+	return strings.TrimRight(s, "/")
+}
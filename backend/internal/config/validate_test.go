@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+)
+
+func TestValidateRequiresAuthentication(t *testing.T) {
+	apiKeys := []auth.APIKey{{Name: "ci", Key: "ci-key-0123456789", Roles: []string{"viewer"}}}
+	tests := []struct {
+		name    string
+		env     map[string]string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{name: "no authentication", wantErr: "auth is not configured"},
+		{name: "api keys", modify: func(c *Config) { c.Auth.APIKeys = apiKeys }},
+		{name: "jwt", modify: func(c *Config) { c.Auth.JWT.HMACSecret = "0123456789abcdef0123456789abcdef" }},
+		{name: "explicitly disabled", env: map[string]string{"AUTH_DISABLED": "true"}},
+		{
+			name:    "disabled with api keys",
+			env:     map[string]string{"AUTH_DISABLED": "true"},
+			modify:  func(c *Config) { c.Auth.APIKeys = apiKeys },
+			wantErr: "auth.disabled cannot be combined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			// APSの認証情報の検証を省くため偽のAPSサーバーを使う
+			c.APS.Fake = true
+			if err := c.applyEnv(func(name string) (string, bool) {
+				v, ok := tt.env[name]
+				return v, ok
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.modify != nil {
+				tt.modify(c)
+			}
+
+			err := c.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
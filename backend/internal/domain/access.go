@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrAccessDenied は呼び出し元のロールでは操作が許可されていない場合のエラー
+	ErrAccessDenied = errors.New("access denied")
+	// ErrGrantNotFound は権限の付与が見つからない場合のエラー
+	ErrGrantNotFound = errors.New("grant not found")
+	// ErrInvalidGrant は権限の付与のリクエストが不正な場合のエラー
+	ErrInvalidGrant = errors.New("invalid grant")
+)
+
+// ロール。後のものほど強く、前のロールで許可される操作をすべて含みます
+const (
+	// モデルの閲覧とバケット・翻訳状況の参照
+	RoleViewer = "viewer"
+	// ファイルのアップロード、翻訳の開始、エクスポートの作成
+	RoleUploader = "uploader"
+	// バケットの作成・削除、権限の管理、監査ログの参照
+	RoleAdmin = "admin"
+)
+
+// Roles はロールを弱い順に並べたもの
+var Roles = []string{RoleViewer, RoleUploader, RoleAdmin}
+
+// RoleRank はロールの強さを返します。未知のロールは0を返します
+func RoleRank(role string) int {
+	for i, r := range Roles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Action は権限を確認する操作
+type Action string
+
+const (
+	ActionBucketRead      Action = "bucket:read"
+	ActionBucketCreate    Action = "bucket:create"
+	ActionBucketDelete    Action = "bucket:delete"
+	ActionObjectRead      Action = "object:read"
+	ActionObjectUpload    Action = "object:upload"
+	ActionObjectTranslate Action = "object:translate"
+	ActionExportCreate    Action = "export:create"
+	ActionTokenCreate     Action = "token:create"
+	ActionMeshProcess     Action = "mesh:process"
+	ActionGrantManage     Action = "grant:manage"
+	ActionAuditRead       Action = "audit:read"
+)
+
+// RequiredRoles は操作に必要なロール
+var RequiredRoles = map[Action]string{
+	ActionBucketRead:      RoleViewer,
+	ActionBucketCreate:    RoleAdmin,
+	ActionBucketDelete:    RoleAdmin,
+	ActionObjectRead:      RoleViewer,
+	ActionObjectUpload:    RoleUploader,
+	ActionObjectTranslate: RoleUploader,
+	ActionExportCreate:    RoleUploader,
+	// 発行するトークンは閲覧のみのスコープ（viewables:read）のため、viewerに許可します
+	ActionTokenCreate:     RoleViewer,
+	ActionMeshProcess:     RoleViewer,
+	ActionGrantManage:     RoleAdmin,
+	ActionAuditRead:       RoleAdmin,
+}
+
+// GroupSubjectPrefix はグループに付与する場合のSubjectの接頭辞
+const GroupSubjectPrefix = "group:"
+
+// Grant はバケットの接頭辞ごとに呼び出し元へ付与したロール
+// 例: Subjectが"group:teama"、BucketPrefixが"teama-"、Roleがuploaderの場合、チームAはteama-で始まるバケットにアップロードできます
+type Grant struct {
+	ID string `json:"id"`
+	// 呼び出し元のID（APIキーの名前、JWTのsub）または"group:"に続けたグループ名
+	Subject string `json:"subject" example:"group:teama"`
+	Role    string `json:"role" example:"uploader"`
+	// 対象のバケットキーの接頭辞。空の場合はすべてのバケット
+	BucketPrefix string    `json:"bucketPrefix" example:"teama-"`
+	CreatedBy    string    `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// GrantRequest は権限の付与のリクエスト
+type GrantRequest struct {
+	Subject      string `json:"subject" example:"group:teama"`
+	Role         string `json:"role" example:"uploader"`
+	BucketPrefix string `json:"bucketPrefix" example:"teama-"`
+}
+
+// Matches は付与が呼び出し元とバケットに当てはまるか判定します
+// bucketKeyが空（バケットを特定しない操作）の場合は、すべてのバケットへの付与のみ当てはまります
+func (g *Grant) Matches(principal *Principal, bucketKey string) bool {
+	if g.BucketPrefix != "" && (bucketKey == "" || !strings.HasPrefix(bucketKey, g.BucketPrefix)) {
+		return false
+	}
+	if group, ok := strings.CutPrefix(g.Subject, GroupSubjectPrefix); ok {
+		for _, g := range principal.Groups {
+			if g == group {
+				return true
+			}
+		}
+		return false
+	}
+	return g.Subject == principal.ID
+}
+
+// GrantRepository は権限の付与を保存するリポジトリインターフェース
+type GrantRepository interface {
+	List() ([]Grant, error)
+	Save(grant *Grant) error
+	Delete(id string) error
+}
+
+// AccessUseCase はロールと権限の付与による認可のユースケースインターフェース
+type AccessUseCase interface {
+	// Authorize はコンテキストの呼び出し元がバケットに対して操作できるか確認し、できない場合はErrAccessDeniedを返します
+	Authorize(ctx context.Context, action Action, bucketKey string) error
+	ListGrants(ctx context.Context) ([]Grant, error)
+	CreateGrant(ctx context.Context, req *GrantRequest) (*Grant, error)
+	DeleteGrant(ctx context.Context, id string) error
+}
+
+// BucketKeyOfURN はBase64エンコードされたオブジェクトのURNからバケットキーを取り出します
+func BucketKeyOfURN(urn string) (string, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(urn, "="))
+	if err != nil {
+		return "", false
+	}
+	return BucketKeyOfObjectID(string(decoded))
+}
+
+// ObjectIDPrefix はOSSのオブジェクトIDの接頭辞
+const ObjectIDPrefix = "urn:adsk.objects:os.object:"
+
+// BucketKeyOfObjectID はオブジェクトID（urn:adsk.objects:os.object:バケットキー/オブジェクトキー）からバケットキーを取り出します
+func BucketKeyOfObjectID(objectID string) (string, bool) {
+	rest, ok := strings.CutPrefix(objectID, ObjectIDPrefix)
+	if !ok {
+		return "", false
+	}
+	bucketKey, _, ok := strings.Cut(rest, "/")
+	return bucketKey, ok && bucketKey != ""
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type APSBucket struct {
     BucketKey    string `json:"bucketKey"`
     CreatedDate  int64  `json:"createdDate"`
@@ -27,8 +29,8 @@ type APSBucketDetail struct {
 
 // APSBucketRepository インターフェースに詳細取得メソッドを追加
 type APSBucketRepository interface {
-    CreateBucket(token string) (*APSBucket, error)
-    GetBuckets(token string) (*BucketsResponse, error)
-    GetBucketDetail(token string, bucketKey string) (*APSBucketDetail, error)
-    DeleteBucket(token string, bucketKey string) error // 追加
+    CreateBucket(ctx context.Context, token string) (*APSBucket, error)
+    GetBuckets(ctx context.Context, token string) (*BucketsResponse, error)
+    GetBucketDetail(ctx context.Context, token string, bucketKey string) (*APSBucketDetail, error)
+    DeleteBucket(ctx context.Context, token string, bucketKey string) error // 追加
 }
\ No newline at end of file
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrPropertiesNotReady はModel Derivativeが要素のプロパティをまだ準備している場合（202）のエラー
+var ErrPropertiesNotReady = errors.New("properties are not ready")
+
+// ErrBundleNotSupported はオフライン閲覧用バンドルを作成できない派生ファイルの場合のエラー
+var ErrBundleNotSupported = errors.New("bundle is not supported")
+
+// ErrInvalidGLBRequest はGLB変換のリクエストが不正な場合のエラー
+var ErrInvalidGLBRequest = errors.New("invalid glb request")
+
+// DerivativeDownload は派生ファイルをダウンロードするための署名付きCookie情報
+type DerivativeDownload struct {
+	URL         string `json:"url"`
+	Cookie      string `json:"-"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Expiration  int64  `json:"expiration"`
+}
+
+// DerivativeBundle はオフライン閲覧用にダウンロードした派生ファイル一式を表す構造体
+type DerivativeBundle struct {
+	URN       string           `json:"urn"`
+	Dir       string           `json:"-"`
+	Viewables []BundleViewable `json:"viewables"`
+	Files     []BundleFile     `json:"files"`
+}
+
+// BundleViewable はバンドル内でViewerのローカルモードから読み込めるビュー
+type BundleViewable struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+	Mime string `json:"mime"`
+	Path string `json:"path"`
+}
+
+// BundleFile はバンドルに含まれる派生ファイル
+type BundleFile struct {
+	DerivativeURN string `json:"derivativeUrn"`
+	Path          string `json:"path"`
+	Size          int64  `json:"size"`
+}
+
+// MetadataView はModel Derivativeのメタデータのビュー
+type MetadataView struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+	GUID string `json:"guid"`
+}
+
+// PropertyObject はビューの要素とプロパティ
+// Propertiesは分類（例: Constraints）ごとのプロパティ名と値で、値は文字列・数値・配列のいずれかです
+type PropertyObject struct {
+	ObjectID   int            `json:"objectid"`
+	Name       string         `json:"name"`
+	ExternalID string         `json:"externalId"`
+	Properties map[string]any `json:"properties"`
+}
+
+// DerivativeOutputFormat はModel Derivativeのジョブで要求する出力形式
+type DerivativeOutputFormat struct {
+	Type     string         `json:"type"`
+	Views    []string       `json:"views,omitempty"`
+	Advanced map[string]any `json:"advanced,omitempty"`
+}
+
+// GLBConversion はOBJ派生ファイルからGLBへの変換状況
+// StatusはOBJのジョブが進行中の間はpending/inprogress、GLBが用意できるとsuccessになります
+type GLBConversion struct {
+	URN       string `json:"urn"`
+	ModelGUID string `json:"modelGuid"`
+	ObjectIDs []int  `json:"objectIds,omitempty"`
+	Status    string `json:"status"`
+	Progress  string `json:"progress,omitempty"`
+	Key       string `json:"key"`
+	Size      int64  `json:"size,omitempty"`
+}
+
+// APSDerivativeRepository はModel Derivativeの派生ファイルを扱うリポジトリインターフェース
+type APSDerivativeRepository interface {
+	GetDerivativeDownload(ctx context.Context, urn string, derivativeURN string) (*DerivativeDownload, error)
+	// offsetが0より大きい場合はRangeリクエストで続きから取得し、再開できたかどうかを返す
+	DownloadDerivative(ctx context.Context, download *DerivativeDownload, offset int64) (io.ReadCloser, bool, error)
+	// ViewerのリクエストをサーバーのトークンでAPSへ転送します
+	OpenDerivativeResource(ctx context.Context, method string, path string, rawQuery string, header http.Header) (*DerivativeResource, error)
+	// 既存の派生ファイルを残したまま、指定した形式の派生ファイルを追加で作成するジョブを送信します
+	SubmitDerivativeJob(ctx context.Context, urn string, formats []DerivativeOutputFormat) (*TranslateJobResponse, error)
+	// GetMetadata は翻訳したモデルのビューの一覧を返します
+	GetMetadata(ctx context.Context, urn string) ([]MetadataView, error)
+	// GetProperties はビューのすべての要素のプロパティを返します。準備中の場合はErrPropertiesNotReadyを返します
+	// レスポンスがmaxBytesを超える場合はエラーを返します
+	GetProperties(ctx context.Context, urn string, guid string, maxBytes int64) ([]PropertyObject, error)
+	// DeleteManifest はマニフェストとすべての派生ファイルを削除します。既に削除されている場合もnilを返します
+	DeleteManifest(ctx context.Context, urn string) error
+}
+
+// APSDerivativeUseCase はModel Derivativeの派生ファイルを扱うユースケースインターフェース
+type APSDerivativeUseCase interface {
+	// PrepareBundle が返したバンドルは、書き出し終えるまで削除されないよう必ずWriteBundleZipに渡してください
+	PrepareBundle(ctx context.Context, urn string) (*DerivativeBundle, error)
+	WriteBundleZip(bundle *DerivativeBundle, w io.Writer) error
+	ProxyDerivative(ctx context.Context, req *DerivativeProxyRequest) (*DerivativeProxyResponse, error)
+	ConvertToGLB(ctx context.Context, urn string, modelGUID string, objectIDs []int) (*GLBConversion, error)
+	OpenGLB(conversion *GLBConversion) (io.ReadSeekCloser, error)
+}
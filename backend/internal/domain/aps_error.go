@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APSError はAPS（およびS3の署名付きURL）がエラーのステータスを返した場合のエラー
+// ハンドラではStatusCodeをもとにクライアントへ返すステータスを決めます
+type APSError struct {
+	// 失敗した操作（例: "get buckets"）
+	Operation string
+	// APSが返したHTTPステータス
+	StatusCode int
+	// OSSのreasonやModel Derivativeのdiagnosticなど、エラーの説明
+	Reason string
+	// AUTH-001などのエラーコード
+	ErrorCode string
+	// 問い合わせ時に必要になるリクエストID
+	RequestID string
+	// 429や503の場合にAPSが返したRetry-Afterヘッダー
+	RetryAfter string
+}
+
+func (e *APSError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "aps %s failed: status=%d", e.Operation, e.StatusCode)
+	if e.ErrorCode != "" {
+		fmt.Fprintf(&b, ", code=%s", e.ErrorCode)
+	}
+	if e.Reason != "" {
+		fmt.Fprintf(&b, ", reason=%s", e.Reason)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", requestId=%s", e.RequestID)
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrExportNotFound はエクスポートが見つからない場合のエラー
+	ErrExportNotFound = errors.New("export not found")
+	// ErrExportNotReady はエクスポートのファイルがまだ用意できていない場合のエラー
+	ErrExportNotReady = errors.New("export is not ready")
+	// ErrInvalidExportRequest はエクスポートのリクエストが不正な場合のエラー
+	ErrInvalidExportRequest = errors.New("invalid export request")
+)
+
+// ExportRecord は選択した要素のエクスポート履歴
+type ExportRecord struct {
+	ID          string     `json:"id"`
+	URN         string     `json:"urn"`
+	ModelGUID   string     `json:"modelGuid"`
+	ObjectIDs   []int      `json:"objectIds"`
+	Format      string     `json:"format"`             // obj または stl
+	Encoding    string     `json:"encoding,omitempty"` // stlの場合のみ ascii または binary
+	Status      string     `json:"status"`             // pending, inprogress, success, failed
+	Progress    string     `json:"progress,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	FileName    string     `json:"fileName,omitempty"`
+	Size        int64      `json:"size,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// ExportRequest はエクスポートの作成リクエスト
+type ExportRequest struct {
+	ModelGUID string `json:"modelGuid"`
+	ObjectIDs []int  `json:"objectIds"`
+	Format    string `json:"format"`
+	Encoding  string `json:"encoding,omitempty"`
+}
+
+// ExportRepository はエクスポート履歴と出力ファイルを保存するリポジトリインターフェース
+type ExportRepository interface {
+	Save(record *ExportRecord) error
+	Get(urn string, id string) (*ExportRecord, error)
+	ListByURN(urn string) ([]ExportRecord, error)
+	// writeで書き出した内容をエクスポートのファイルとして保存し、サイズを返します
+	SaveFile(record *ExportRecord, write func(w io.Writer) error) (int64, error)
+	OpenFile(record *ExportRecord) (io.ReadSeekCloser, error)
+}
+
+// APSExportUseCase は選択した要素のエクスポートのユースケースインターフェース
+type APSExportUseCase interface {
+	CreateExport(ctx context.Context, urn string, req *ExportRequest) (*ExportRecord, error)
+	ListExports(ctx context.Context, urn string) ([]ExportRecord, error)
+	GetExport(ctx context.Context, urn string, id string) (*ExportRecord, error)
+	OpenExportFile(ctx context.Context, urn string, id string) (*ExportRecord, io.ReadSeekCloser, error)
+}
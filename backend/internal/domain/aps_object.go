@@ -1,5 +1,13 @@
 package domain
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrSignedURLNotAllowed は許可されていないホストの署名付きURLが指定された場合のエラー
+var ErrSignedURLNotAllowed = errors.New("signed url host is not allowed")
+
 // APSObject はAutodesk Platform Servicesのオブジェクトを表す構造体
 type APSObject struct {
 	BucketKey       string   `json:"bucketKey"`
@@ -16,26 +24,28 @@ type APSObject struct {
 
 // APSObjectRepository はAPSオブジェクトのリポジトリインターフェース
 type APSObjectRepository interface {
-	GetS3SignedURLs(bucketKey string, objectKey string, parts int) (*APSObject, error)
-	PutS3SignedURLs(signedURL string, fileContent []byte) error
-	CreateObject(bucketKey, objectKey, uploadKey string) (*APSObject, error)  // 追加
+	GetS3SignedURLs(ctx context.Context, bucketKey string, objectKey string, parts int) (*APSObject, error)
+	PutS3SignedURLs(ctx context.Context, signedURL string, fileContent []byte) error
+	CreateObject(ctx context.Context, bucketKey, objectKey, uploadKey string) (*APSObject, error)  // 追加
 	GenerateBase64EncodedURN(objectId string) (string, error)
 	// 新規追加
-	TranslateObject(base64URN string, objectKey string) (*TranslateJobResponse, error)
+	TranslateObject(ctx context.Context, base64URN string, objectKey string) (*TranslateJobResponse, error)
 	// 追加
-	TrackTranslationJobStatus(urn string) (*TranslationStatus, error)
+	TrackTranslationJobStatus(ctx context.Context, urn string) (*TranslationStatus, error)
+	// DeleteObject はOSSのオブジェクトを削除します。既に削除されている場合もnilを返します
+	DeleteObject(ctx context.Context, bucketKey string, objectKey string) error
 }
 
 // APSObjectUseCase はAPSオブジェクトのユースケースインターフェース
 type APSObjectUseCase interface {
-	GetS3SignedURLs(bucketKey string, objectKey string, parts int) (*APSObject, error)
-	PutS3SignedURLs(signedURL string, fileContent []byte) error
-	CreateObject(bucketKey, objectKey, uploadKey string) (*APSObject, error)  // 追加
+	GetS3SignedURLs(ctx context.Context, bucketKey string, objectKey string, parts int) (*APSObject, error)
+	PutS3SignedURLs(ctx context.Context, signedURL string, fileContent []byte) error
+	CreateObject(ctx context.Context, bucketKey, objectKey, uploadKey string) (*APSObject, error)  // 追加
 	GenerateBase64EncodedURN(objectId string) (string, error)
 	// 新規追加
-	TranslateObject(base64URN string, objectKey string) (*TranslateJobResponse, error)
+	TranslateObject(ctx context.Context, base64URN string, objectKey string) (*TranslateJobResponse, error)
 	// 追加
-	TrackTranslationJobStatus(urn string) (*TranslationStatus, error)
+	TrackTranslationJobStatus(ctx context.Context, urn string) (*TranslationStatus, error)
 }
 
 type TranslateJobResponse struct {
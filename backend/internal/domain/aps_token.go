@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 // @Description APSトークンレスポンス
 type APSToken struct {
     AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..." description:"APSアクセストークン"`
@@ -9,5 +11,8 @@ type APSToken struct {
 }
 
 type APSTokenRepository interface {
-    GetToken() (*APSToken, error)
+    // 有効期限内であればキャッシュしたトークンを返す
+    GetToken(ctx context.Context) (*APSToken, error)
+    // キャッシュを使わずにトークンを取り直す（APSが401を返した場合に使用）
+    RefreshToken(ctx context.Context) (*APSToken, error)
 }
\ No newline at end of file
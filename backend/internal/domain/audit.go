@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// 監査記録の結果
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	// ロールが足りず拒否した
+	AuditOutcomeDenied = "denied"
+)
+
+// 監査記録のみに使う操作
+const (
+	ActionGrantCreate Action = "grant:create"
+	ActionGrantDelete Action = "grant:delete"
+)
+
+// 監査記録の対象を表す文字列
+const (
+	// APSのトークンの発行
+	AuditTargetAPSToken = "aps_token"
+)
+
+// BucketTarget はバケットを表す監査記録の対象を返します
+func BucketTarget(bucketKey string) string {
+	return "bucket:" + bucketKey
+}
+
+// ObjectTarget はオブジェクトを表す監査記録の対象を返します
+func ObjectTarget(bucketKey string, objectKey string) string {
+	return "object:" + bucketKey + "/" + objectKey
+}
+
+// GrantTarget は権限の付与を表す監査記録の対象を返します
+func GrantTarget(id string) string {
+	return "grant:" + id
+}
+
+// AuditEntry は監査ログの1件の記録
+// 各記録は直前の記録のハッシュ（PrevHash）を含めてハッシュ（Hash）を計算し、改ざんや削除を検出できるようにします
+type AuditEntry struct {
+	// 1から始まる通し番号
+	Seq  int64     `json:"seq"`
+	Time time.Time `json:"time"`
+	// 呼び出し元のIDと認証方法。バックグラウンドの処理では空
+	Principal       string `json:"principal,omitempty"`
+	PrincipalMethod string `json:"principalMethod,omitempty"`
+	Action          Action `json:"action" example:"bucket:delete"`
+	// 操作の対象（例: bucket:my-bucket, object:my-bucket/model.rvt, grant:<ID>）
+	Target  string `json:"target" example:"bucket:my-bucket"`
+	Outcome string `json:"outcome" example:"success"`
+	// 失敗や拒否の理由
+	Detail    string `json:"detail,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+	PrevHash  string `json:"prevHash"`
+	Hash      string `json:"hash"`
+}
+
+// AuditFilter は監査ログの検索条件。空の項目は条件にしません
+type AuditFilter struct {
+	Principal string
+	Action    Action
+	// 対象の前方一致（例: bucket:teama-）
+	TargetPrefix string
+	Outcome      string
+	Since        time.Time
+	Until        time.Time
+	// 返す件数の上限。新しい順に返します
+	Limit int
+}
+
+// AuditVerification は監査ログのハッシュチェーンの検証結果
+type AuditVerification struct {
+	OK      bool  `json:"ok"`
+	Entries int64 `json:"entries"`
+	// 最初に不整合が見つかった記録の通し番号
+	BrokenAt int64  `json:"brokenAt,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	// 書き込みの途中で中断した記録の数。プロセスの異常終了などで最後の行が途中までしかない場合に数えます
+	Incomplete int `json:"incomplete,omitempty"`
+}
+
+// AuditRepository は監査ログを追記のみで保存するリポジトリインターフェース
+type AuditRepository interface {
+	// Append は通し番号とハッシュを設定して記録を追記します
+	Append(entry *AuditEntry) error
+	Query(filter AuditFilter) ([]AuditEntry, error)
+	Verify() (*AuditVerification, error)
+}
+
+// AuditLogger はユースケースから監査ログに記録するインターフェース
+type AuditLogger interface {
+	// Record はコンテキストの呼び出し元とリクエストIDを付けて操作の結果を記録します
+	// errがnilなら成功、ErrAccessDeniedなら拒否、それ以外は失敗として記録します
+	Record(ctx context.Context, action Action, target string, err error)
+}
+
+// AuditUseCase は監査ログの検索と検証のユースケースインターフェース
+type AuditUseCase interface {
+	AuditLogger
+	Query(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+	Verify(ctx context.Context) (*AuditVerification, error)
+}
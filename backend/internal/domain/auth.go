@@ -0,0 +1,51 @@
+package domain
+
+import "context"
+
+// 認証方法
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+	// 共有リンクと引き換えに発行したViewer用トークン
+	AuthMethodShare = "share"
+	// 認証を通っていない呼び出し元
+	AuthMethodAnonymous = "anonymous"
+	// 開発用に認証を無効にした場合の呼び出し元
+	AuthMethodDisabled = "disabled"
+)
+
+// Principal は認証された呼び出し元
+type Principal struct {
+	// 呼び出し元の識別子（APIキーの名前、JWTのsub）
+	ID string `json:"id"`
+	// 認証方法（api_key, jwt, share, anonymous, disabled）
+	Method string `json:"method"`
+	// JWTの発行者（iss）
+	Issuer string `json:"issuer,omitempty"`
+	// すべてのバケットに対するロール（viewer, uploader, admin）
+	Roles []string `json:"roles"`
+	// 所属するグループ。グループへの権限の付与に使います
+	Groups []string `json:"groups,omitempty"`
+	// 共有リンクで発行したトークンの場合、閲覧できるモデルのURN。このURNの参照以外は許可しません
+	URN string `json:"urn,omitempty"`
+}
+
+// AnonymousPrincipal は認証を通っていない呼び出し元。ロールを持たないため、権限が必要な操作はすべて拒否します
+var AnonymousPrincipal = &Principal{ID: "anonymous", Method: AuthMethodAnonymous, Roles: []string{}}
+
+// DevelopmentPrincipal は開発用に認証を無効にした（auth.disabled）場合の呼び出し元。すべての操作を許可します
+var DevelopmentPrincipal = &Principal{ID: "development", Method: AuthMethodDisabled, Roles: []string{RoleAdmin}}
+
+type principalKey struct{}
+
+// WithPrincipal はコンテキストに認証された呼び出し元を設定します
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext はコンテキストの呼び出し元を返します
+// 認証ミドルウェアを通っていない場合（管理用エンドポイントやバックグラウンド処理）はfalseを返します
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrDerivativePathNotAllowed はプロキシが転送を許可していないパスを要求された場合のエラー
+var ErrDerivativePathNotAllowed = errors.New("derivative path is not allowed")
+
+// DerivativeProxyRequest はViewerから受け取った派生ファイルへのリクエスト
+type DerivativeProxyRequest struct {
+	Method   string
+	Path     string // エスケープ済みのパス（先頭の/なし）
+	RawQuery string
+	Header   http.Header
+}
+
+// ParseDerivativePath はプロキシが許可するパスか確認し、対象のURNとマニフェスト本体の取得かどうかを返します
+// 許可するのはViewerが読み込みに使うModel Derivativeのマニフェスト・派生ファイル・サムネイルの取得のみです
+func ParseDerivativePath(escapedPath string) (string, bool, error) {
+	decoded, err := url.PathUnescape(escapedPath)
+	if err != nil {
+		return "", false, ErrDerivativePathNotAllowed
+	}
+	for _, segment := range strings.Split(decoded, "/") {
+		if segment == "." || segment == ".." {
+			return "", false, ErrDerivativePathNotAllowed
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(decoded, "modelderivative/v2/"); ok {
+		if strings.HasPrefix(rest, "regions/") {
+			parts := strings.SplitN(rest, "/", 3)
+			if len(parts) < 3 {
+				return "", false, ErrDerivativePathNotAllowed
+			}
+			rest = parts[2]
+		}
+		rest, ok = strings.CutPrefix(rest, "designdata/")
+		if !ok {
+			return "", false, ErrDerivativePathNotAllowed
+		}
+		parts := strings.SplitN(rest, "/", 3)
+		if len(parts) < 2 || parts[0] == "" {
+			return "", false, ErrDerivativePathNotAllowed
+		}
+		switch parts[1] {
+		case "manifest":
+			return parts[0], len(parts) == 2, nil
+		case "thumbnail":
+			return parts[0], false, nil
+		}
+		return "", false, ErrDerivativePathNotAllowed
+	}
+
+	if rest, ok := strings.CutPrefix(decoded, "derivativeservice/v2/"); ok {
+		kind, target, _ := strings.Cut(rest, "/")
+		switch kind {
+		case "manifest":
+			if target == "" || strings.Contains(target, "/") {
+				return "", false, ErrDerivativePathNotAllowed
+			}
+			return target, true, nil
+		case "thumbnails":
+			if target == "" || strings.Contains(target, "/") {
+				return "", false, ErrDerivativePathNotAllowed
+			}
+			return target, false, nil
+		case "derivatives":
+			derivative, ok := strings.CutPrefix(target, "urn:adsk.viewing:fs.file:")
+			if !ok {
+				return "", false, ErrDerivativePathNotAllowed
+			}
+			urn, _, _ := strings.Cut(derivative, "/")
+			if urn == "" {
+				return "", false, ErrDerivativePathNotAllowed
+			}
+			return urn, false, nil
+		}
+	}
+
+	return "", false, ErrDerivativePathNotAllowed
+}
+
+// DerivativeProxyResponse はプロキシのレスポンス
+// キャッシュにヒットした場合はContentが、そうでない場合はBodyが設定されます
+type DerivativeProxyResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       io.ReadCloser
+	Content    io.ReadSeekCloser
+	ModTime    time.Time
+}
+
+// DerivativeResource はAPSから取得した派生ファイルのレスポンス
+type DerivativeResource struct {
+	StatusCode int
+	Header     http.Header
+	Body       io.ReadCloser
+}
+
+// DerivativeCacheEntry はディスクキャッシュのエントリ
+type DerivativeCacheEntry struct {
+	Key             string            `json:"key"`
+	URN             string            `json:"urn"`
+	ManifestVersion string            `json:"manifestVersion"`
+	Blob            string            `json:"blob"`
+	Size            int64             `json:"size"`
+	Header          map[string]string `json:"header"`
+	StoredAt        time.Time         `json:"storedAt"`
+}
+
+// DerivativeCacheWriter はキャッシュへの書き込み。Commitで確定し、Abortで破棄します
+type DerivativeCacheWriter interface {
+	io.Writer
+	Commit() error
+	Abort()
+}
+
+// DerivativeCacheRepository は派生ファイルのコンテンツアドレス型キャッシュのインターフェース
+type DerivativeCacheRepository interface {
+	// キャッシュにない場合はnilを返します
+	Get(key string) (*DerivativeCacheEntry, io.ReadSeekCloser, error)
+	NewWriter(entry *DerivativeCacheEntry) (DerivativeCacheWriter, error)
+	ManifestVersion(urn string) string
+	// バージョンが変わった場合はそのURNのエントリを無効化します
+	SetManifestVersion(urn string, version string) error
+}
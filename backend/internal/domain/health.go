@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// 確認結果の状態
+const (
+	HealthStatusOK    = "ok"
+	HealthStatusError = "error"
+)
+
+// HealthCheck は準備状態の確認項目
+type HealthCheck struct {
+	Name string
+	// 結果を再利用する時間。0の場合は毎回確認します
+	// APSの確認はプローブのたびにクォータを消費しないよう、結果をキャッシュします
+	CacheTTL time.Duration
+	Check    func(ctx context.Context) error
+}
+
+// @Description 確認項目ごとの結果
+type HealthCheckResult struct {
+	Name      string  `json:"name" example:"aps_token"`
+	Status    string  `json:"status" example:"ok"`
+	LatencyMs float64 `json:"latency_ms" example:"123.4"`
+	Error     string  `json:"error,omitempty"`
+	// キャッシュした結果を返した場合はtrue
+	Cached    bool      `json:"cached"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// @Description 生存確認・準備状態の確認の結果
+type HealthReport struct {
+	Status string              `json:"status" example:"ok"`
+	Checks []HealthCheckResult `json:"checks,omitempty"`
+}
+
+// OK はすべての確認項目が成功したか判定します
+func (r *HealthReport) OK() bool {
+	return r.Status == HealthStatusOK
+}
+
+// HealthUseCase は生存確認・準備状態の確認のユースケースインターフェース
+type HealthUseCase interface {
+	// プロセスが動作していることを返します
+	Live() *HealthReport
+	// APSの認証情報・OSSへの接続・ローカルのストアへの書き込みを確認します
+	Ready(ctx context.Context) *HealthReport
+}
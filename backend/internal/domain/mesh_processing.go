@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrInvalidMeshRequest はメッシュ処理のリクエストが不正な場合のエラー
+	ErrInvalidMeshRequest = errors.New("invalid mesh request")
+	// ErrMeshLimitExceeded はメッシュの頂点数や三角形数が上限を超えた場合のエラー
+	ErrMeshLimitExceeded = errors.New("mesh limit exceeded")
+)
+
+// MeshGeometryInput はViewerから抽出したジオメトリ（フロントエンドのExtractedMeshData.geometryDataと同じ形）
+type MeshGeometryInput struct {
+	Vertices []float32 `json:"vertices"`
+	Normals  []float32 `json:"normals,omitempty"`
+	Indices  []uint32  `json:"indices,omitempty"`
+}
+
+// MeshMaterialInput は抽出したメッシュのマテリアル。Colorは0〜1のRGBまたはRGBA
+type MeshMaterialInput struct {
+	Name    string    `json:"name,omitempty"`
+	Color   []float32 `json:"color,omitempty"`
+	Opacity *float32  `json:"opacity,omitempty"`
+}
+
+// MeshInput はViewerから抽出した1フラグメント分のメッシュ
+// MatrixWorldはthree.jsのMatrix4.elementsと同じ列優先の16要素
+type MeshInput struct {
+	DbID         int                `json:"dbId"`
+	FragID       int                `json:"fragId"`
+	GeometryData MeshGeometryInput  `json:"geometryData"`
+	MatrixWorld  []float64          `json:"matrixWorld,omitempty"`
+	Material     *MeshMaterialInput `json:"material,omitempty"`
+}
+
+// MeshProcessOptions はメッシュの後処理の設定
+// 省略した項目はサーバーの既定値を使います。TargetTrianglesはサーバーの上限を超えられません
+type MeshProcessOptions struct {
+	WeldTolerance   *float64 `json:"weldTolerance,omitempty"`
+	MergeByMaterial *bool    `json:"mergeByMaterial,omitempty"`
+	TargetTriangles int      `json:"targetTriangles,omitempty"`
+}
+
+// MeshProcessRequest はメッシュの後処理のリクエスト
+type MeshProcessRequest struct {
+	Meshes  []MeshInput        `json:"meshes"`
+	Options MeshProcessOptions `json:"options"`
+}
+
+// MeshBounds は処理後のメッシュ全体のバウンディングボックス
+type MeshBounds struct {
+	Min    [3]float64 `json:"min"`
+	Max    [3]float64 `json:"max"`
+	Center [3]float64 `json:"center"`
+	Size   [3]float64 `json:"size"`
+}
+
+// MeshProcessResult はメッシュの後処理の結果
+type MeshProcessResult struct {
+	InputVertices   int `json:"inputVertices"`
+	InputTriangles  int `json:"inputTriangles"`
+	OutputVertices  int `json:"outputVertices"`
+	OutputTriangles int `json:"outputTriangles"`
+	TargetTriangles int `json:"targetTriangles"`
+	// 目標の三角形数まで間引けず、間引く前の形状のまま残した要素のdbId
+	UnmetDbIDs []int       `json:"unmetDbIds,omitempty"`
+	Bounds     *MeshBounds `json:"bounds,omitempty"`
+}
+
+// MeshLimits はメッシュ処理で受け付けるデータ量の上限と既定値
+type MeshLimits struct {
+	MaxRequestBytes        int64
+	MaxVertices            int
+	MaxTriangles           int
+	DefaultTargetTriangles int
+	DefaultWeldTolerance   float64
+}
+
+// MeshProcessingUseCase はViewerから抽出したメッシュを後処理するユースケースインターフェース
+type MeshProcessingUseCase interface {
+	Limits() MeshLimits
+	// 溶接・マテリアルごとの結合・間引きを行い、GLBをwに書き出します
+	ProcessToGLB(ctx context.Context, req *MeshProcessRequest, w io.Writer) (*MeshProcessResult, error)
+}
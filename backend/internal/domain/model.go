@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrModelNotFound はカタログにモデルが見つからない場合のエラー
+	ErrModelNotFound = errors.New("model not found")
+	// ErrInvalidModelRequest はモデルの検索や更新のリクエストが不正な場合のエラー
+	ErrInvalidModelRequest = errors.New("invalid model request")
+)
+
+// カタログのモデルの状態。翻訳を開始した後はマニフェストの状態（pending, inprogress, success, failed, timeout）になります
+const (
+	// アップロードのみで翻訳していない
+	ModelStatusUploaded = "uploaded"
+	// 翻訳を開始し、まだマニフェストで確認していない
+	ModelStatusPending = "pending"
+	// 翻訳が完了した
+	ModelStatusSuccess = "success"
+)
+
+// カタログの変更を監査ログに記録する操作
+const (
+	ActionModelUpdate Action = "model:update"
+	ActionModelDelete Action = "model:delete"
+)
+
+// ModelTarget はモデルを表す監査記録の対象を返します
+func ModelTarget(id string) string {
+	return "model:" + id
+}
+
+// Model はアップロードしたファイルのカタログの項目
+type Model struct {
+	ID string `json:"id"`
+	// 表示名。既定は元のファイル名
+	Name        string `json:"name" example:"office.rvt"`
+	Description string `json:"description,omitempty"`
+	// 元のファイル名と、小文字にした拡張子（ドットなし）
+	FileName  string `json:"fileName" example:"office.rvt"`
+	FileType  string `json:"fileType" example:"rvt"`
+	Size      int64  `json:"size"`
+	BucketKey string `json:"bucketKey"`
+	ObjectKey string `json:"objectKey"`
+	ObjectID  string `json:"objectId"`
+	// Base64エンコードしたオブジェクトのURN
+	URN string `json:"urn"`
+	// 翻訳の出力形式とビュー（例: svf:2d,3d）。翻訳していない場合は空
+	TranslationProfile string   `json:"translationProfile,omitempty" example:"svf:2d,3d"`
+	Status             string   `json:"status" example:"success"`
+	Progress           string   `json:"progress,omitempty" example:"complete"`
+	HasThumbnail       bool     `json:"hasThumbnail"`
+	Tags               []string `json:"tags"`
+	// 整理先のプロジェクトとフォルダ。プロジェクト直下の場合はFolderIDが空
+	ProjectID string `json:"projectId,omitempty"`
+	FolderID  string `json:"folderId,omitempty"`
+	// アップロードした呼び出し元のID
+	UploadedBy string    `json:"uploadedBy,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// ModelFilter はカタログの検索条件。空の項目は条件にしません
+type ModelFilter struct {
+	BucketKey  string
+	Status     string
+	FileType   string
+	UploadedBy string
+	Tag        string
+	ProjectID  string
+	// フォルダのID。RootFolderIDの場合はプロジェクト直下
+	FolderID string
+	// 名前またはファイル名の部分一致
+	Query string
+	// 並び順（createdAt, name, size）。先頭に-を付けると降順。既定は-createdAt
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// ModelList はカタログの検索結果の1ページ
+type ModelList struct {
+	Items []Model `json:"items"`
+	// 条件に一致したすべての件数
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// ModelUpdate はモデルの更新のリクエスト。指定した項目のみ更新します
+type ModelUpdate struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+}
+
+// ModelRepository はカタログを保存するリポジトリインターフェース
+type ModelRepository interface {
+	// Save はモデルを追加または更新します
+	Save(ctx context.Context, model *Model) error
+	Get(ctx context.Context, id string) (*Model, error)
+	// GetByObjectID はオブジェクトのモデルを返します。ない場合はErrModelNotFoundを返します
+	GetByObjectID(ctx context.Context, objectID string) (*Model, error)
+	GetByURN(ctx context.Context, urn string) (*Model, error)
+	List(ctx context.Context, filter ModelFilter) (*ModelList, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ModelCatalog はアップロードと翻訳の流れからカタログを更新するインターフェース
+// カタログの更新に失敗してもアップロードや翻訳は止めず、エラーはログに記録します
+type ModelCatalog interface {
+	// ObjectUploaded はアップロードを完了したオブジェクトを登録します。同じオブジェクトを上書きした場合は項目を更新します
+	ObjectUploaded(ctx context.Context, object *APSObject, urn string)
+	// TranslationSubmitted は翻訳ジョブの出力形式を記録します
+	TranslationSubmitted(ctx context.Context, urn string, objectKey string, job *TranslateJobResponse)
+	// TranslationChecked はマニフェストで確認した翻訳の状態を記録します
+	TranslationChecked(ctx context.Context, urn string, status *TranslationStatus)
+}
+
+// ModelUseCase はカタログのユースケースインターフェース
+type ModelUseCase interface {
+	ModelCatalog
+	ListModels(ctx context.Context, filter ModelFilter) (*ModelList, error)
+	GetModel(ctx context.Context, id string) (*Model, error)
+	UpdateModel(ctx context.Context, id string, update *ModelUpdate) (*Model, error)
+	// DeleteModel はカタログから項目を削除します。OSSのオブジェクトと派生ファイルは削除しません
+	DeleteModel(ctx context.Context, id string) error
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrProjectNotFound はプロジェクトが見つからない場合のエラー
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrFolderNotFound はフォルダが見つからない場合のエラー
+	ErrFolderNotFound = errors.New("folder not found")
+	// ErrInvalidProjectRequest はプロジェクトやフォルダのリクエストが不正な場合のエラー
+	ErrInvalidProjectRequest = errors.New("invalid project request")
+	// ErrFolderConflict は同じ親に同じ名前のフォルダがある場合や、空でないフォルダを削除しようとした場合のエラー
+	ErrFolderConflict = errors.New("folder conflict")
+)
+
+// RootFolderID はプロジェクト直下（どのフォルダにも入っていない）を表すフォルダID
+const RootFolderID = "root"
+
+// プロジェクトとフォルダの変更を監査ログに記録する操作
+const (
+	ActionProjectCreate Action = "project:create"
+	ActionProjectUpdate Action = "project:update"
+	ActionProjectDelete Action = "project:delete"
+	ActionFolderCreate  Action = "folder:create"
+	ActionFolderUpdate  Action = "folder:update"
+	ActionFolderDelete  Action = "folder:delete"
+	ActionModelMove     Action = "model:move"
+	// プロジェクトの削除に伴うOSSのオブジェクトとマニフェストの削除
+	ActionObjectDelete   Action = "object:delete"
+	ActionManifestDelete Action = "manifest:delete"
+)
+
+// ProjectTarget はプロジェクトを表す監査記録の対象を返します
+func ProjectTarget(id string) string {
+	return "project:" + id
+}
+
+// FolderTarget はフォルダを表す監査記録の対象を返します
+func FolderTarget(id string) string {
+	return "folder:" + id
+}
+
+// ManifestTarget は翻訳の派生ファイル（マニフェスト）を表す監査記録の対象を返します
+func ManifestTarget(urn string) string {
+	return "manifest:" + urn
+}
+
+// Project はモデルを整理するための論理的なまとまり。OSSのバケットとは独立していて、複数のバケットのモデルを含められます
+type Project struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name" example:"Office Building"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"createdBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ProjectRequest はプロジェクトの作成のリクエスト
+type ProjectRequest struct {
+	Name        string `json:"name" example:"Office Building"`
+	Description string `json:"description,omitempty"`
+}
+
+// ProjectUpdate はプロジェクトの更新のリクエスト。指定した項目のみ更新します
+type ProjectUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// Folder はプロジェクト内のフォルダ。入れ子にできます
+type Folder struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	// 親フォルダのID。プロジェクト直下の場合は空
+	ParentID  string    `json:"parentId,omitempty"`
+	Name      string    `json:"name" example:"Structure"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// FolderRequest はフォルダの作成のリクエスト
+type FolderRequest struct {
+	Name string `json:"name" example:"Structure"`
+	// 親フォルダのID。空の場合はプロジェクト直下
+	ParentID string `json:"parentId,omitempty"`
+}
+
+// FolderUpdate はフォルダの名前の変更と移動のリクエスト。指定した項目のみ更新します
+type FolderUpdate struct {
+	Name *string `json:"name,omitempty"`
+	// 移動先の親フォルダのID。空文字列の場合はプロジェクト直下へ移動します
+	ParentID *string `json:"parentId,omitempty"`
+}
+
+// ModelMove はモデルの移動のリクエスト
+// FolderIDを指定した場合はそのフォルダのプロジェクトへ、ProjectIDのみの場合はプロジェクト直下へ移動します
+// どちらも空の場合はプロジェクトから外します
+type ModelMove struct {
+	ProjectID string `json:"projectId,omitempty"`
+	FolderID  string `json:"folderId,omitempty"`
+}
+
+// FolderItem はフォルダの一覧に表示するモデル
+type FolderItem struct {
+	Model
+	// サムネイルを取得するURL。サムネイルがない場合は空
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+}
+
+// FolderContents はプロジェクト直下またはフォルダの中身
+type FolderContents struct {
+	Project Project `json:"project"`
+	// 表示しているフォルダ。プロジェクト直下の場合はnull
+	Folder *Folder `json:"folder"`
+	// プロジェクト直下から表示しているフォルダまでのフォルダ
+	Path    []Folder     `json:"path"`
+	Folders []Folder     `json:"folders"`
+	Models  []FolderItem `json:"models"`
+	// フォルダ直下のモデルのすべての件数
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// ProjectDeleteOptions はプロジェクトの削除の指定
+type ProjectDeleteOptions struct {
+	// プロジェクトのモデルについて、OSSのオブジェクトと翻訳の派生ファイルも削除します
+	Purge bool
+	// 削除せずに、削除する内容のみを返します
+	DryRun bool
+}
+
+// ProjectDeletion はプロジェクトの削除の内容。DryRunの場合は削除する予定の内容です
+type ProjectDeletion struct {
+	Project Project `json:"project"`
+	DryRun  bool    `json:"dryRun"`
+	Purge   bool    `json:"purge"`
+	Folders int     `json:"folders"`
+	// プロジェクトのモデル。Purgeでない場合はプロジェクトから外すのみで、カタログには残ります
+	Models []DeletedModel `json:"models"`
+}
+
+// DeletedModel はプロジェクトの削除の対象になったモデル
+type DeletedModel struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	BucketKey string `json:"bucketKey"`
+	ObjectKey string `json:"objectKey"`
+	URN       string `json:"urn"`
+}
+
+// ProjectRepository はプロジェクトとフォルダを保存するリポジトリインターフェース
+type ProjectRepository interface {
+	SaveProject(ctx context.Context, project *Project) error
+	GetProject(ctx context.Context, id string) (*Project, error)
+	ListProjects(ctx context.Context) ([]Project, error)
+	// DeleteProject はプロジェクトとフォルダを削除し、モデルをプロジェクトから外します
+	DeleteProject(ctx context.Context, id string) error
+	// SaveFolder はフォルダを追加または更新します。同じ親に同じ名前のフォルダがある場合はErrFolderConflictを返します
+	SaveFolder(ctx context.Context, folder *Folder) error
+	GetFolder(ctx context.Context, id string) (*Folder, error)
+	// ListFolders はプロジェクトのすべてのフォルダを返します
+	ListFolders(ctx context.Context, projectID string) ([]Folder, error)
+	DeleteFolder(ctx context.Context, id string) error
+}
+
+// ProjectUseCase はプロジェクトとフォルダのユースケースインターフェース
+type ProjectUseCase interface {
+	ListProjects(ctx context.Context) ([]Project, error)
+	GetProject(ctx context.Context, id string) (*Project, error)
+	CreateProject(ctx context.Context, req *ProjectRequest) (*Project, error)
+	UpdateProject(ctx context.Context, id string, update *ProjectUpdate) (*Project, error)
+	DeleteProject(ctx context.Context, id string, opts ProjectDeleteOptions) (*ProjectDeletion, error)
+	ListFolders(ctx context.Context, projectID string) ([]Folder, error)
+	CreateFolder(ctx context.Context, projectID string, req *FolderRequest) (*Folder, error)
+	UpdateFolder(ctx context.Context, projectID string, id string, update *FolderUpdate) (*Folder, error)
+	// DeleteFolder は空のフォルダを削除します。サブフォルダやモデルがある場合はErrFolderConflictを返します
+	DeleteFolder(ctx context.Context, projectID string, id string) error
+	// GetFolderContents はフォルダ（RootFolderIDの場合はプロジェクト直下）のサブフォルダとモデルを返します
+	GetFolderContents(ctx context.Context, projectID string, folderID string, limit int, offset int) (*FolderContents, error)
+	MoveModel(ctx context.Context, modelID string, move *ModelMove) (*Model, error)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidSearchRequest は検索のリクエストが不正な場合のエラー
+var ErrInvalidSearchRequest = errors.New("invalid search request")
+
+// SearchQuery は全文検索の条件
+type SearchQuery struct {
+	// 検索語。空白区切りの語をすべて含むモデルを探します。"Level 3"のように引用符で囲むと語句として、fire*のように末尾に*を付けると前方一致で探します
+	Query string
+	// 絞り込み。空の項目は条件にしません
+	FileType string
+	// プロジェクトのID。RootFolderIDの場合はプロジェクトに入っていないモデル
+	ProjectID string
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+// SearchHit は検索に一致したモデル
+type SearchHit struct {
+	Model
+	// 一致した箇所の抜粋。一致した語は[]で囲みます
+	Snippet string `json:"snippet" example:"... [Fire] [Damper] Schedule ..."`
+	// 一致の度合い。大きいほどよく一致しています
+	Score float64 `json:"score"`
+}
+
+// SearchFacet は絞り込みの値ごとの件数
+type SearchFacet struct {
+	Value string `json:"value" example:"rvt"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets は検索結果の絞り込みの候補。各項目は、その項目以外の絞り込みを適用した件数です
+type SearchFacets struct {
+	FileType []SearchFacet `json:"fileType"`
+	// プロジェクトに入っていないモデルは値が空
+	ProjectID []SearchFacet `json:"projectId"`
+	Status    []SearchFacet `json:"status"`
+}
+
+// SearchResult は検索結果の1ページ
+type SearchResult struct {
+	Items  []SearchHit  `json:"items"`
+	Total  int          `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+	Facets SearchFacets `json:"facets"`
+}
+
+// SearchIndex はモデルの全文検索の索引インターフェース
+// 名前・説明・ファイル名・タグはカタログの変更に合わせて索引に反映されます
+type SearchIndex interface {
+	// Search は条件に一致するモデルを一致の度合いの順に返します
+	Search(ctx context.Context, query SearchQuery) (*SearchResult, error)
+	// SetProperties はモデルの要素の名前とプロパティの値を索引に登録します
+	SetProperties(ctx context.Context, modelID string, text string) error
+}
+
+// ModelIndexer はカタログの変更を検索の索引に反映するインターフェース
+type ModelIndexer interface {
+	// TranslationCompleted は翻訳が完了したモデルの要素のプロパティを索引に登録します。登録は非同期に行います
+	TranslationCompleted(ctx context.Context, model *Model)
+}
+
+// SearchUseCase は全文検索のユースケースインターフェース
+type SearchUseCase interface {
+	ModelIndexer
+	Search(ctx context.Context, query SearchQuery) (*SearchResult, error)
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrShareNotFound は共有リンクが見つからない場合のエラー
+	ErrShareNotFound = errors.New("share not found")
+	// ErrInvalidShareRequest は共有リンクの作成や引き換えのリクエストが不正な場合のエラー
+	ErrInvalidShareRequest = errors.New("invalid share request")
+	// ErrShareUnavailable は共有リンクの有効期限が切れたか、取り消されたか、閲覧できる回数の上限に達した場合のエラー
+	ErrShareUnavailable = errors.New("share is no longer available")
+	// ErrInvalidSharePassword はパスワードを設定した共有リンクのパスワードがないか、一致しない場合のエラー
+	ErrInvalidSharePassword = errors.New("invalid share password")
+	// ErrInvalidShareToken はViewer用トークンの更新で、トークンが不正または期限切れの場合のエラー
+	ErrInvalidShareToken = errors.New("invalid share viewer token")
+)
+
+// 共有リンクの操作を監査ログに記録する操作
+const (
+	ActionShareCreate Action = "share:create"
+	ActionShareRevoke Action = "share:revoke"
+	// 共有リンクとViewer用トークンの引き換え
+	ActionShareView Action = "share:view"
+)
+
+// ShareEmbedPath は共有リンクの埋め込みページのパスの接頭辞。共有リンクのURLはこれにトークンを続けたものです
+const ShareEmbedPath = "/embed/"
+
+// ShareThumbnailSize は埋め込みで公開するサムネイルの幅と高さ（Model Derivativeが対応する100・200・400のいずれか）
+const ShareThumbnailSize = 400
+
+// ShareTarget は共有リンクを表す監査記録の対象を返します
+func ShareTarget(id string) string {
+	return "share:" + id
+}
+
+// Share はアカウントのない相手にモデルを見せるための共有リンク
+// トークンは作成時にのみ返し、ハッシュのみを保存します
+type Share struct {
+	ID      string `json:"id"`
+	ModelID string `json:"modelId"`
+	URN     string `json:"urn"`
+	// パスワードを設定しているか
+	HasPassword bool `json:"hasPassword"`
+	// 閲覧できる回数の上限。0の場合は制限しません
+	MaxViews int `json:"maxViews"`
+	// Viewer用トークンと引き換えた回数
+	ViewCount    int        `json:"viewCount"`
+	LastViewedAt *time.Time `json:"lastViewedAt,omitempty"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	RevokedAt    *time.Time `json:"revokedAt,omitempty"`
+	CreatedBy    string     `json:"createdBy,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+
+	TokenHash    string `json:"-"`
+	PasswordHash string `json:"-"`
+}
+
+// Active は共有リンクが取り消されておらず、有効期限内か確認します
+// 閲覧できる回数の上限は引き換えのときに確認します
+func (s *Share) Active(now time.Time) error {
+	if s.RevokedAt != nil {
+		return fmt.Errorf("%w: revoked", ErrShareUnavailable)
+	}
+	if !now.Before(s.ExpiresAt) {
+		return fmt.Errorf("%w: expired", ErrShareUnavailable)
+	}
+	return nil
+}
+
+// SharePolicy は共有リンクの有効期間の既定値と上限
+type SharePolicy struct {
+	DefaultTTL     time.Duration
+	MaxTTL         time.Duration
+	ViewerTokenTTL time.Duration
+	// 共有リンクのURLのベース。空の場合は作成のレスポンスにURLを含めません
+	PublicURL string
+}
+
+// ShareRequest は共有リンクの作成のリクエスト
+type ShareRequest struct {
+	// 有効期間（秒）。省略した場合はサーバーの既定値
+	ExpiresIn int `json:"expiresIn,omitempty" example:"604800"`
+	// 設定した場合は引き換えにパスワードが必要です
+	Password string `json:"password,omitempty"`
+	// 閲覧できる回数の上限。0の場合は制限しません
+	MaxViews int `json:"maxViews,omitempty" example:"10"`
+}
+
+// CreatedShare は作成した共有リンクとトークン。トークンは再取得できません
+type CreatedShare struct {
+	Share
+	Token string `json:"token"`
+	// 埋め込みページのURL。oEmbedにもこのURLを渡します
+	URL string `json:"url,omitempty" example:"https://viewer.example.com/embed/3q2-7w..."`
+}
+
+// SharedModel は有効な共有リンクと、共有しているモデル
+type SharedModel struct {
+	Share *Share
+	Model *Model
+}
+
+// OEmbed はoEmbedのレスポンス（type=rich）
+type OEmbed struct {
+	Type         string `json:"type" example:"rich"`
+	Version      string `json:"version" example:"1.0"`
+	Title        string `json:"title,omitempty"`
+	ProviderName string `json:"provider_name,omitempty"`
+	ProviderURL  string `json:"provider_url,omitempty"`
+	// 埋め込みページを表示するiframe
+	HTML   string `json:"html"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	// パスワードを設定した共有リンクでは返しません
+	ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+	// 共有リンクの有効期限までの秒数
+	CacheAge int `json:"cache_age,omitempty"`
+}
+
+// ShareExchangeRequest は共有リンクとViewer用トークンの引き換えのリクエスト
+type ShareExchangeRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password,omitempty"`
+}
+
+// ShareRefreshRequest はViewer用トークンの更新のリクエスト
+type ShareRefreshRequest struct {
+	// 有効期限内のViewer用トークン
+	AccessToken string `json:"access_token"`
+}
+
+// ShareViewerToken は共有リンクと引き換えに発行したViewer用トークン
+// 派生ファイルのプロキシでのみ、共有したモデルのURNに限って使えます
+type ShareViewerToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresIn   int    `json:"expires_in" example:"900"`
+	URN         string `json:"urn"`
+	ModelName   string `json:"modelName"`
+}
+
+// ShareViewerClaims はViewer用トークンに含める内容
+type ShareViewerClaims struct {
+	ShareID   string
+	URN       string
+	ExpiresAt time.Time
+}
+
+// ShareTokenIssuer は共有リンクのViewer用トークンを発行するインターフェース
+type ShareTokenIssuer interface {
+	IssueViewerToken(claims ShareViewerClaims) (string, error)
+	// ParseViewerToken はViewer用トークンの署名と有効期限を検証し、内容を返します
+	ParseViewerToken(token string) (*ShareViewerClaims, error)
+}
+
+// ShareRepository は共有リンクを保存するリポジトリインターフェース
+type ShareRepository interface {
+	SaveShare(ctx context.Context, share *Share) error
+	GetShare(ctx context.Context, id string) (*Share, error)
+	GetShareByTokenHash(ctx context.Context, tokenHash string) (*Share, error)
+	// ListShares はモデルの共有リンクを新しい順に返します
+	ListShares(ctx context.Context, modelID string) ([]Share, error)
+	// RecordShareView は閲覧の回数を1増やします。上限に達している場合はErrShareUnavailableを返します
+	RecordShareView(ctx context.Context, id string, at time.Time) error
+}
+
+// ShareUseCase は共有リンクのユースケースインターフェース
+type ShareUseCase interface {
+	CreateShare(ctx context.Context, modelID string, req *ShareRequest) (*CreatedShare, error)
+	ListShares(ctx context.Context, modelID string) ([]Share, error)
+	RevokeShare(ctx context.Context, modelID string, id string) error
+	// ExchangeShare は共有リンクのトークンとパスワードを確認し、閲覧を1回と数えてViewer用トークンを発行します
+	ExchangeShare(ctx context.Context, req *ShareExchangeRequest) (*ShareViewerToken, error)
+	// RefreshShareToken は有効期限内のViewer用トークンを新しいものと交換します。閲覧の回数には数えません
+	RefreshShareToken(ctx context.Context, req *ShareRefreshRequest) (*ShareViewerToken, error)
+	// GetSharedModel はトークンの共有リンクが有効か確認し、共有しているモデルを返します。閲覧の回数には数えません
+	GetSharedModel(ctx context.Context, token string) (*SharedModel, error)
+	// GetShareThumbnail は共有しているモデルのサムネイルを返します。パスワードを設定した共有リンクはErrInvalidSharePasswordを返します
+	GetShareThumbnail(ctx context.Context, token string) (*DerivativeProxyResponse, error)
+}
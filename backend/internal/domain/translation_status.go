@@ -1,5 +1,19 @@
 package domain
 
+import (
+    "path"
+    "slices"
+    "strings"
+)
+
+// TranslationMetrics は翻訳ジョブの所要時間を記録するインターフェース
+type TranslationMetrics interface {
+    // 翻訳ジョブを送信したことを記録します。fileNameは翻訳元のファイル名
+    JobSubmitted(urn string, fileName string)
+    // マニフェストで確認した翻訳の状態（pending, inprogress, success, failed, timeout）を記録します
+    JobStatus(urn string, status string)
+}
+
 type TranslationStatus struct {
     Type         string       `json:"type"`
     HasThumbnail string      `json:"hasThumbnail"`
@@ -28,6 +42,10 @@ type Children struct {
     Status       string     `json:"status"`
     Progress     string     `json:"progress"`
     HasThumbnail string     `json:"hasThumbnail"`
+    URN          string     `json:"urn,omitempty"`
+    Mime         string     `json:"mime,omitempty"`
+    ModelGUID    string     `json:"modelGuid,omitempty"`
+    ObjectIDs    []int      `json:"objectIds,omitempty"`
     Children     []Resource `json:"children,omitempty"`
     Messages     []Message  `json:"messages,omitempty"`
 }
@@ -46,3 +64,44 @@ type Message struct {
     Code    string   `json:"code"`
     Message []string `json:"message,omitempty"`
 }
+
+// FindOBJResources はマニフェストから指定したモデルビュー・objectIdsのOBJとMTLの派生ファイルを探します
+// objectIdsは並び順によらず比較します。見つからない場合はnilを返します
+func (s *TranslationStatus) FindOBJResources(modelGUID string, objectIDs []int) (*Children, *Children) {
+    wanted := slices.Clone(objectIDs)
+    slices.Sort(wanted)
+
+    for _, derivative := range s.Derivatives {
+        if derivative.OutputType != "obj" {
+            continue
+        }
+
+        var objFile, mtlFile *Children
+        for i := range derivative.Children {
+            child := &derivative.Children[i]
+            if child.ModelGUID != modelGUID {
+                continue
+            }
+            ids := slices.Clone(child.ObjectIDs)
+            slices.Sort(ids)
+            if !slices.Equal(ids, wanted) {
+                continue
+            }
+
+            switch strings.ToLower(path.Ext(child.URN)) {
+            case ".obj":
+                objFile = child
+            case ".mtl":
+                mtlFile = child
+            }
+        }
+        if objFile != nil {
+            if objFile.Status == "" {
+                objFile.Status = derivative.Status
+                objFile.Progress = derivative.Progress
+            }
+            return objFile, mtlFile
+        }
+    }
+    return nil, nil
+}
@@ -2,16 +2,21 @@ package aps_bucket
 
 import (
     "net/http"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
 type APSBucketRepository struct {
-    client  *http.Client
-    baseURL string
+    client    *http.Client
+    endpoints aps_endpoint.Endpoints
+    timeouts  aps_timeout.Timeouts
 }
 
-func NewAPSBucketRepository(client *http.Client) *APSBucketRepository {
+func NewAPSBucketRepository(client *http.Client, endpoints aps_endpoint.Endpoints, timeouts aps_timeout.Timeouts) *APSBucketRepository {
     return &APSBucketRepository{
-        client:  client,
-        baseURL: "https://developer.api.autodesk.com/oss/v2",
+        client:    client,
+        endpoints: endpoints,
+        timeouts:  timeouts,
     }
 }
\ No newline at end of file
@@ -2,15 +2,21 @@ package aps_bucket
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "io"
     "net/http"
     "github.com/google/uuid"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
-func (r *APSBucketRepository) CreateBucket(token string) (*domain.APSBucket, error) {
+func (r *APSBucketRepository) CreateBucket(ctx context.Context, token string) (*domain.APSBucket, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
     bucketKey := fmt.Sprintf("my-aps-bucket-%s", uuid.New().String())
     
     requestBody := struct {
@@ -26,8 +32,8 @@ func (r *APSBucketRepository) CreateBucket(token string) (*domain.APSBucket, err
         return nil, err
     }
 
-    req, err := http.NewRequest("POST", 
-        "https://developer.api.autodesk.com/oss/v2/buckets",
+    req, err := http.NewRequestWithContext(ctx, "POST", 
+        r.endpoints.OSS+"/buckets",
         bytes.NewBuffer(jsonData))
     if err != nil {
         return nil, err
@@ -42,9 +48,8 @@ func (r *APSBucketRepository) CreateBucket(token string) (*domain.APSBucket, err
     }
     defer resp.Body.Close()
 
-    if resp.StatusCode != http.StatusOK {
-        bodyBytes, _ := io.ReadAll(resp.Body)
-        return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
+    if err := aps_error.Check("create bucket", resp); err != nil {
+        return nil, err
     }
 
     bodyBytes, err := io.ReadAll(resp.Body)
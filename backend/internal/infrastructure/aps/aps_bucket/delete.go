@@ -1,14 +1,21 @@
 package aps_bucket
 
 import (
+    "context"
     "fmt"
     "net/http"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
-func (r *APSBucketRepository) DeleteBucket(token string, bucketKey string) error {
-    url := fmt.Sprintf("%s/buckets/%s", r.baseURL, bucketKey)
+func (r *APSBucketRepository) DeleteBucket(ctx context.Context, token string, bucketKey string) error {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
+    url := fmt.Sprintf("%s/buckets/%s", r.endpoints.OSS, bucketKey)
     
-    req, err := http.NewRequest("DELETE", url, nil)
+    req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
     if err != nil {
         return err
     }
@@ -21,8 +28,8 @@ func (r *APSBucketRepository) DeleteBucket(token string, bucketKey string) error
     }
     defer resp.Body.Close()
 
-    if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("failed to delete bucket: %d", resp.StatusCode)
+    if err := aps_error.Check("delete bucket", resp); err != nil {
+        return err
     }
 
     return nil
@@ -1,11 +1,14 @@
 package aps_bucket
 
 import (
+    "context"
     "encoding/json"
     "fmt"
     "net/http"
     
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
 type BucketsResponse struct {
@@ -13,10 +16,13 @@ type BucketsResponse struct {
     Next  string            `json:"next"`
 }
 
-func (r *APSBucketRepository) GetBuckets(token string) (*domain.BucketsResponse, error) {
-    url := "https://developer.api.autodesk.com/oss/v2/buckets"
+func (r *APSBucketRepository) GetBuckets(ctx context.Context, token string) (*domain.BucketsResponse, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
+    url := r.endpoints.OSS + "/buckets"
     
-    req, err := http.NewRequest("GET", url, nil)
+    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
     if err != nil {
         return nil, fmt.Errorf("failed to create request: %w", err)
     }
@@ -29,8 +35,9 @@ func (r *APSBucketRepository) GetBuckets(token string) (*domain.BucketsResponse,
     }
     defer resp.Body.Close()
 
-    if resp.StatusCode == http.StatusForbidden {
-        return nil, fmt.Errorf("access denied: check if token has bucket:read scope")
+    // 403の場合はトークンにbucket:readスコープがあるか確認してください
+    if err := aps_error.Check("get buckets", resp); err != nil {
+        return nil, err
     }
 
     var bucketsResp domain.BucketsResponse
@@ -1,17 +1,23 @@
 package aps_bucket
 
 import (
+    "context"
     "encoding/json"
     "fmt"
     "net/http"
 
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
-func (r *APSBucketRepository) GetBucketDetail(token string, bucketKey string) (*domain.APSBucketDetail, error) {
-    url := fmt.Sprintf("https://developer.api.autodesk.com/oss/v2/buckets/%s/details", bucketKey)
+func (r *APSBucketRepository) GetBucketDetail(ctx context.Context, token string, bucketKey string) (*domain.APSBucketDetail, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
+    url := fmt.Sprintf("%s/buckets/%s/details", r.endpoints.OSS, bucketKey)
     
-    req, err := http.NewRequest("GET", url, nil)
+    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
     if err != nil {
         return nil, err
     }
@@ -25,8 +31,8 @@ func (r *APSBucketRepository) GetBucketDetail(token string, bucketKey string) (*
     }
     defer resp.Body.Close()
 
-    if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+    if err := aps_error.Check("get bucket detail", resp); err != nil {
+        return nil, err
     }
 
     var detail domain.APSBucketDetail
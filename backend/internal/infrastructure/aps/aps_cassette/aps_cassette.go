@@ -0,0 +1,81 @@
+package aps_cassette
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// Mode はAPS呼び出しの記録・再生のモード
+type Mode string
+
+const (
+	// ModeOff は記録も再生もせずにAPSへ送信します
+	ModeOff Mode = ""
+	// ModeRecord はAPSへ送信したリクエストとレスポンスをカセットに記録します
+	ModeRecord Mode = "record"
+	// ModeReplay はAPSへ送信せず、カセットに記録したレスポンスを返します
+	ModeReplay Mode = "replay"
+)
+
+// Interaction はカセットに記録する1回のリクエストとレスポンス
+// カセットはInteractionを1行ずつJSONで書いたファイル（JSON Lines）です
+type Interaction struct {
+	Request  Request   `json:"request"`
+	Response *Response `json:"response,omitempty"`
+	// 通信エラーになった場合のエラーメッセージ。再生時は同じメッセージのエラーを返します
+	Error      string    `json:"error,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Request は記録したリクエスト。トークンや署名は伏せ字にしています
+type Request struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// Response は記録したレスポンス。トークンや署名は伏せ字にしています
+type Response struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// New はモードに応じてAPS呼び出しに使うhttp.RoundTripperを返します
+// pathはカセットのファイルパスで、記録・再生する場合に使います
+func New(mode Mode, path string) (http.RoundTripper, error) {
+	switch mode {
+	case ModeOff, "off":
+		return http.DefaultTransport, nil
+	case ModeRecord:
+		return NewRecorder(path, http.DefaultTransport)
+	case ModeReplay:
+		return NewReplayer(path)
+	}
+	return nil, fmt.Errorf("invalid cassette mode: %q", mode)
+}
+
+// encodeBody は本文がテキストであればそのまま、バイナリであればBase64で返します
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// decodeBody はencodeBodyで記録した本文を元に戻します
+func decodeBody(body string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return nil, fmt.Errorf("unsupported body encoding: %s", encoding)
+}
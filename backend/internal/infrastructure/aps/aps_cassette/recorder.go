@@ -0,0 +1,131 @@
+package aps_cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Recorder はリクエストを送信し、リクエストとレスポンスをカセットに追記するhttp.RoundTripper
+// リトライの下で使うため、リトライやトークンの取り直しによる再送も1回ずつ記録します
+// レスポンスの本文は記録のためにすべて読み込んでから返します
+type Recorder struct {
+	base http.RoundTripper
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder はpathのカセットを新しく作成して記録を始めます。既存のカセットは上書きします
+func NewRecorder(path string, base http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{base: base, file: file}, nil
+}
+
+// RoundTrip はリクエストを送信して結果を記録します
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    redactURL(req.URL),
+			Header: redactHeader(req.Header),
+		},
+		RecordedAt: time.Now().UTC(),
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(reqBody)
+	if interaction.Request.BodyEncoding == "" {
+		interaction.Request.Body = redactBody(interaction.Request.Body)
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		interaction.Error = err.Error()
+		r.append(interaction)
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		interaction.Error = err.Error()
+		r.append(interaction)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction.Response = &Response{
+		StatusCode: resp.StatusCode,
+		Header:     redactHeader(resp.Header),
+	}
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(respBody)
+	if interaction.Response.BodyEncoding == "" {
+		interaction.Response.Body = redactBody(interaction.Response.Body)
+	}
+	r.append(interaction)
+
+	return resp, nil
+}
+
+// Close はカセットを閉じます
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// append はカセットに1行追記します。記録に失敗してもAPS呼び出しは失敗させません
+func (r *Recorder) append(interaction Interaction) {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		slog.Error("failed to encode APS cassette interaction", "error", err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		slog.Error("failed to write APS cassette", "error", err)
+	}
+}
+
+// readRequestBody はリクエストの本文を読み込みます
+// RoundTripperは元のリクエストを変更できないため、GetBodyがない場合は本文を読み直せるリクエストのコピーを返します
+func readRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer rc.Close()
+		body, err := io.ReadAll(rc)
+		return req, body, err
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := req.Clone(req.Context())
+	c.Body = io.NopCloser(bytes.NewReader(body))
+	return c, body, nil
+}
@@ -0,0 +1,76 @@
+package aps_cassette
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// 伏せ字にした値
+const redacted = "REDACTED"
+
+// 署名付きURL（S3・CloudFront）のうち値を伏せ字にするクエリパラメータ
+var signedParams = []string{"X-Amz-Signature", "X-Amz-Credential", "X-Amz-Security-Token", "Signature", "Policy", "Key-Pair-Id"}
+
+var (
+	// 本文中の署名付きURLのクエリパラメータ。JSONでは&が\u0026にエスケープされる場合がある
+	signedParamPattern = regexp.MustCompile(`(?i)((?:[?&]|\\u0026)(?:` + strings.Join(signedParams, "|") + `)=)[^&"\s\\<]+`)
+	// JSONのトークンやシークレットの値
+	secretJSONPattern = regexp.MustCompile(`("(?:access_token|refresh_token|client_secret)"\s*:\s*")[^"]+"`)
+	// フォームのトークンやシークレットの値
+	secretFormPattern = regexp.MustCompile(`((?:^|&)(?:client_secret|client_id|access_token|refresh_token)=)[^&]+`)
+)
+
+// redactHeader はヘッダーの認証情報を伏せ字にしたコピーを返します
+func redactHeader(header http.Header) http.Header {
+	h := header.Clone()
+	if v := h.Get("Authorization"); v != "" {
+		scheme, _, _ := strings.Cut(v, " ")
+		h.Set("Authorization", scheme+" "+redacted)
+	}
+	if h.Get("X-Amz-Security-Token") != "" {
+		h.Set("X-Amz-Security-Token", redacted)
+	}
+	// Cookieは名前を残して値を伏せ字にする（例: CloudFront-Policy=REDACTED）
+	for i, v := range h["Cookie"] {
+		var pairs []string
+		for _, pair := range strings.Split(v, ";") {
+			name, _, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			pairs = append(pairs, name+"="+redacted)
+		}
+		h["Cookie"][i] = strings.Join(pairs, "; ")
+	}
+	// Set-Cookieは名前と属性を残して値を伏せ字にする
+	for i, v := range h["Set-Cookie"] {
+		pair, attrs, _ := strings.Cut(v, ";")
+		name, _, _ := strings.Cut(pair, "=")
+		h["Set-Cookie"][i] = name + "=" + redacted
+		if attrs != "" {
+			h["Set-Cookie"][i] += ";" + attrs
+		}
+	}
+	return h
+}
+
+// redactURL は署名付きURLの署名を伏せ字にします。クエリパラメータは名前順に並べ替えます
+func redactURL(u *url.URL) string {
+	c := *u
+	query := c.Query()
+	for name := range query {
+		for _, p := range signedParams {
+			if strings.EqualFold(name, p) {
+				query.Set(name, redacted)
+			}
+		}
+	}
+	c.RawQuery = query.Encode()
+	return c.String()
+}
+
+// redactBody は本文中のトークン・シークレット・署名を伏せ字にします
+func redactBody(body string) string {
+	body = secretJSONPattern.ReplaceAllString(body, "${1}"+redacted+`"`)
+	body = secretFormPattern.ReplaceAllString(body, "${1}"+redacted)
+	return signedParamPattern.ReplaceAllString(body, "${1}"+redacted)
+}
@@ -0,0 +1,116 @@
+package aps_cassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Replayer はAPSへ送信せず、カセットに記録したレスポンスを返すhttp.RoundTripper
+// リクエストはメソッドとURLのパス・クエリ（署名は伏せ字にしたもの）で照合し、同じリクエストには記録した順に返します
+// ホストは照合に使わないため、記録時と異なるベースURLでも再生できます
+// 記録した回数より多く呼ばれた場合は最後のレスポンスを繰り返します（マニフェストのポーリングなど）
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+}
+
+// NewReplayer はpathのカセットを読み込みます
+func NewReplayer(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &Replayer{interactions: map[string][]Interaction{}}
+	scanner := bufio.NewScanner(file)
+	// 派生ファイルの本文も1行に記録されるため、行の長さは制限しない
+	scanner.Buffer(nil, int(^uint(0)>>1))
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s:%d: %w", path, line, err)
+		}
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cassette %s:%d: %w", path, line, err)
+		}
+		key := interactionKey(interaction.Request.Method, u)
+		r.interactions[key] = append(r.interactions[key], interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RoundTrip は記録したレスポンスを返します。記録がない場合はエラーを返します
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := interactionKey(req.Method, req.URL)
+	r.mu.Lock()
+	queue := r.interactions[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no recorded APS interaction for %s", key)
+	}
+	interaction := queue[0]
+	if len(queue) > 1 {
+		r.interactions[key] = queue[1:]
+	}
+	r.mu.Unlock()
+
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+	if interaction.Response == nil {
+		return nil, fmt.Errorf("recorded APS interaction for %s %s has no response", req.Method, interaction.Request.URL)
+	}
+
+	body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// 記録した本文は伏せ字にしているため、長さは実際の本文に合わせる
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// interactionKey はリクエストを照合するキー（メソッドと署名を伏せ字にしたパス・クエリ）を返します
+func interactionKey(method string, u *url.URL) string {
+	redactedURL, err := url.Parse(redactURL(u))
+	if err != nil {
+		return method + " " + u.RequestURI()
+	}
+	return method + " " + redactedURL.RequestURI()
+}
@@ -0,0 +1,54 @@
+package aps_client
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// RetryPolicy はAPS呼び出しのリトライの設定
+type RetryPolicy struct {
+	// 1回の呼び出しで行う最大のリトライ回数。0の場合はリトライしません
+	MaxRetries int `yaml:"maxRetries"`
+	// 指数バックオフの初回の待ち時間
+	BaseDelay time.Duration `yaml:"baseDelay"`
+	// 1回あたりの待ち時間の上限。Retry-Afterがこれを超える場合はリトライせずにエラーを返します
+	MaxDelay time.Duration `yaml:"maxDelay"`
+}
+
+// DefaultRetryPolicy は既定のリトライの設定を返します
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// TokenRefresher は401を受け取った場合にトークンを取り直すためのインターフェース
+type TokenRefresher interface {
+	RefreshToken(ctx context.Context) (*domain.APSToken, error)
+}
+
+// retryStats はリトライの回数。/debug/varsのaps_clientで確認できます
+// キーはリトライの理由（status_429, status_503, network, token_refreshなど）
+var retryStats = expvar.NewMap("aps_client")
+
+// NewClient はAPS呼び出し用のHTTPクライアントを作成します
+// baseは実際に送信するRoundTripperで、nilの場合はhttp.DefaultTransportを使います
+// refresherを指定した場合、Authorizationヘッダー付きのリクエストが401になるとトークンを1度だけ取り直して再送します
+func NewClient(base http.RoundTripper, policy RetryPolicy, refresher TokenRefresher) *http.Client {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &Transport{
+			Base:      base,
+			Policy:    policy,
+			Refresher: refresher,
+		},
+	}
+}
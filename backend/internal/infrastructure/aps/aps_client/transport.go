@@ -0,0 +1,258 @@
+package aps_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/metrics"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+// Transport はAPS呼び出しをリトライするhttp.RoundTripper
+// 冪等なメソッド（GET, HEAD, PUT, DELETE, OPTIONS）は429・5xx・通信エラーで、それ以外は429の場合のみ再送します
+// S3の署名付きURLへのパートのPUTも冪等なため再送の対象です
+type Transport struct {
+	Base      http.RoundTripper
+	Policy    RetryPolicy
+	Refresher TokenRefresher
+}
+
+type retryableKey struct{}
+
+// WithRetryable はPOSTでも5xxや通信エラーで再送してよいリクエストであることをコンテキストに設定します
+// 同じ内容で何度送っても結果が変わらないAPI（トークンの取得や翻訳ジョブの送信など）に使います
+func WithRetryable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableKey{}, true)
+}
+
+// RoundTrip はリクエストを送信し、必要に応じてバックオフしながら再送します
+// 再送を含む呼び出し全体を1つのクライアントスパンとして記録します
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	api := metrics.APIName(req)
+	ctx, span := tracing.Tracer().Start(req.Context(), "APS "+api+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			tracing.API.String(api),
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+		))
+	// 署名付きURLのパスにはアップロードキーなどが含まれるため、APSのAPIのみパスを記録する
+	if api != "s3" {
+		span.SetAttributes(semconv.URLPath(req.URL.Path))
+	}
+	retries := 0
+	resp, err := t.roundTrip(req.WithContext(ctx), api, &retries)
+	span.SetAttributes(tracing.RetryCount.Int(retries))
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	tracing.End(span, err)
+	return resp, err
+}
+
+// roundTrip はRoundTripの本体。再送した回数をretriesに設定します
+func (t *Transport) roundTrip(req *http.Request, api string, retries *int) (*http.Response, error) {
+	ctx := req.Context()
+	span := trace.SpanFromContext(ctx)
+	idempotent := isIdempotent(req)
+	refreshed := false
+	sent := false
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewind(req, sent)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := t.base().RoundTrip(attemptReq)
+		sent = true
+		elapsed := time.Since(start)
+		metrics.APSRequestDuration.WithLabelValues(api, req.Method, metrics.StatusLabel(resp, err)).Observe(elapsed.Seconds())
+		logAttempt(ctx, req, api, attempt, resp, err, elapsed)
+
+		// 401はトークンの期限切れの可能性があるため、1度だけトークンを取り直す（リトライ回数には数えない）
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && t.Refresher != nil && !refreshed && req.Header.Get("Authorization") != "" {
+			token, rerr := t.Refresher.RefreshToken(ctx)
+			if rerr == nil {
+				drain(resp)
+				refreshed = true
+				retryStats.Add("token_refresh", 1)
+				span.AddEvent("token_refresh")
+				req = req.Clone(ctx)
+				req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+				attempt--
+				continue
+			}
+		}
+
+		reason, retry := shouldRetry(resp, err, idempotent)
+		if !retry || attempt >= t.Policy.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > t.Policy.MaxDelay {
+					// 待ち時間が長すぎる場合は呼び出し側にRetry-Afterごと返す
+					return resp, nil
+				}
+				delay = after
+			}
+			drain(resp)
+		}
+
+		retryStats.Add(reason, 1)
+		metrics.APSRetries.WithLabelValues(api, reason).Inc()
+		*retries++
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.String("reason", reason),
+			attribute.Int("attempt", attempt+1),
+			attribute.String("delay", delay.String()),
+		))
+		if api == "s3" && req.Method == http.MethodPut {
+			metrics.UploadPartRetries.Inc()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// logAttempt は1回の送信の結果をログに出力します。失敗した送信は再送する場合も警告として出力します
+func logAttempt(ctx context.Context, req *http.Request, api string, attempt int, resp *http.Response, err error, elapsed time.Duration) {
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("api", api),
+		slog.String("method", req.Method),
+		slog.String("host", req.URL.Host),
+		slog.Int("attempt", attempt+1),
+		slog.Int64("duration_ms", elapsed.Milliseconds()),
+	}
+	// 署名付きURLのパスにはアップロードキーなどが含まれるため、APSのAPIのみパスを出力する
+	if api != "s3" {
+		attrs = append(attrs, slog.String("path", req.URL.Path))
+	}
+	switch {
+	case err != nil:
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.Any("error", err))
+	default:
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if id := aps_error.RequestIDOf(resp); id != "" {
+			attrs = append(attrs, slog.String("aps_request_id", id))
+		}
+		if resp.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+	}
+	slog.LogAttrs(ctx, level, "aps request", attrs...)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// backoff はフルジッター付きの指数バックオフの待ち時間を返します
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.Policy.BaseDelay << attempt
+	if d <= 0 || d > t.Policy.MaxDelay {
+		d = t.Policy.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(d)) + 1)
+}
+
+// shouldRetry は再送するかどうかと、その理由を返します
+func shouldRetry(resp *http.Response, err error, idempotent bool) (string, bool) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return "", false
+		}
+		return "network", idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// 429は処理されていないため、メソッドによらず再送できる
+		return "status_429", true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return "status_" + strconv.Itoa(resp.StatusCode), idempotent
+	default:
+		return "", false
+	}
+}
+
+// isIdempotent はリクエストを再送しても安全か判定します
+func isIdempotent(req *http.Request) bool {
+	if retryable, _ := req.Context().Value(retryableKey{}).(bool); retryable {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewind は2回目以降の送信のためにリクエストボディを読み直せるようにします
+func rewind(req *http.Request, sent bool) (*http.Request, error) {
+	if !sent || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("aps client: request body cannot be rewound for retry")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+// retryAfter はRetry-Afterヘッダー（秒数またはHTTP日付）を待ち時間に変換します
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// drain は再送する前にレスポンスボディを読み捨てて接続を再利用できるようにします
+func drain(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
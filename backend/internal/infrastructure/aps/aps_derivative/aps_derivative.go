@@ -0,0 +1,30 @@
+package aps_derivative
+
+import (
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// APSDerivativeRepository はModel Derivativeの派生ファイルリポジトリ実装
+type APSDerivativeRepository struct {
+	client    *http.Client
+	tokenRepo domain.APSTokenRepository
+	endpoints aps_endpoint.Endpoints
+	timeouts  aps_timeout.Timeouts
+}
+
+// NewAPSDerivativeRepository は新しいAPSDerivativeRepositoryを作成します
+func NewAPSDerivativeRepository(client *http.Client, tokenRepo domain.APSTokenRepository, endpoints aps_endpoint.Endpoints, timeouts aps_timeout.Timeouts) *APSDerivativeRepository {
+	return &APSDerivativeRepository{
+		client:    client,
+		tokenRepo: tokenRepo,
+		endpoints: endpoints,
+		timeouts:  timeouts,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.APSDerivativeRepository = (*APSDerivativeRepository)(nil)
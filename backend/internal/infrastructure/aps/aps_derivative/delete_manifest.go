@@ -0,0 +1,38 @@
+package aps_derivative
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_client"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// DeleteManifest はマニフェストとすべての派生ファイルを削除します。翻訳していない場合（404）も成功とみなします
+func (r *APSDerivativeRepository) DeleteManifest(ctx context.Context, urn string) error {
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+	defer cancel()
+
+	token, err := r.tokenRepo.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	// 削除は何度送っても結果が同じため、5xxでも再送してよい
+	req, err := http.NewRequestWithContext(aps_client.WithRetryable(ctx), "DELETE",
+		fmt.Sprintf("%s/designdata/%s/manifest", r.endpoints.ModelDerivative, urn), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return aps_error.Check("delete manifest", resp, http.StatusOK, http.StatusNotFound)
+}
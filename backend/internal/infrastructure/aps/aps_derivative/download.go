@@ -0,0 +1,53 @@
+package aps_derivative
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// DownloadDerivative は署名付きCookieを使用して派生ファイルをダウンロードします
+// タイムアウトは本文の読み込みにも適用されるため、返した本文を閉じるまでキャンセルしません
+func (r *APSDerivativeRepository) DownloadDerivative(ctx context.Context, download *domain.DerivativeDownload, offset int64) (io.ReadCloser, bool, error) {
+	if err := r.endpoints.CheckSignedURL(download.URL); err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.Download)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", download.URL, nil)
+	if err != nil {
+		cancel()
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Cookie", download.Cookie)
+	// 保存されているバイト列をそのまま受け取るため、透過的なgzip展開を無効にする
+	req.Header.Set("Accept-Encoding", "identity")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, false, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return aps_timeout.CancelOnClose(resp.Body, cancel), false, nil
+	case http.StatusPartialContent:
+		return aps_timeout.CancelOnClose(resp.Body, cancel), true, nil
+	default:
+		apsErr := aps_error.FromResponse("download derivative", resp)
+		resp.Body.Close()
+		cancel()
+		return nil, false, apsErr
+	}
+}
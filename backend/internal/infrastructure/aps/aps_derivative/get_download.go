@@ -0,0 +1,70 @@
+package aps_derivative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// GetDerivativeDownload は派生ファイルをダウンロードするための署名付きCookieを取得します
+func (r *APSDerivativeRepository) GetDerivativeDownload(ctx context.Context, urn string, derivativeURN string) (*domain.DerivativeDownload, error) {
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+	defer cancel()
+
+	token, err := r.tokenRepo.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	// 派生URNはパスに含めるためエンコードする
+	endpoint := fmt.Sprintf("%s/designdata/%s/manifest/%s/signedcookies",
+		r.endpoints.ModelDerivative, urn, url.PathEscape(derivativeURN))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := aps_error.Check("get derivative signed cookies", resp); err != nil {
+		return nil, err
+	}
+
+	var apiResponse struct {
+		URL         string `json:"url"`
+		ContentType string `json:"content-type"`
+		Size        int64  `json:"size"`
+		Expiration  int64  `json:"expiration"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// CloudFrontの署名付きCookieをダウンロード時に送るCookieヘッダーへまとめる
+	var cookies []string
+	for _, c := range resp.Cookies() {
+		cookies = append(cookies, c.Name+"="+c.Value)
+	}
+
+	return &domain.DerivativeDownload{
+		URL:         apiResponse.URL,
+		Cookie:      strings.Join(cookies, "; "),
+		ContentType: apiResponse.ContentType,
+		Size:        apiResponse.Size,
+		Expiration:  apiResponse.Expiration,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package aps_derivative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// GetMetadata は翻訳したモデルのビュー（2D・3D）の一覧を取得します
+func (r *APSDerivativeRepository) GetMetadata(ctx context.Context, urn string) ([]domain.MetadataView, error) {
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+	defer cancel()
+
+	resp, err := r.get(ctx, fmt.Sprintf("%s/designdata/%s/metadata", r.endpoints.ModelDerivative, urn))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := aps_error.Check("get metadata", resp); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Metadata []domain.MetadataView `json:"metadata"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return response.Data.Metadata, nil
+}
+
+// GetProperties はビューのすべての要素のプロパティを取得します
+// 大きなモデルでは数百MBになるため、maxBytesを超えるレスポンスは読み込まずにエラーを返します
+func (r *APSDerivativeRepository) GetProperties(ctx context.Context, urn string, guid string, maxBytes int64) ([]domain.PropertyObject, error) {
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.Download)
+	defer cancel()
+
+	resp, err := r.get(ctx, fmt.Sprintf("%s/designdata/%s/metadata/%s/properties", r.endpoints.ModelDerivative, urn, url.PathEscape(guid)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := aps_error.Check("get properties", resp, http.StatusOK, http.StatusAccepted); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return nil, domain.ErrPropertiesNotReady
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, fmt.Errorf("properties of %s are %d bytes, exceeding the limit of %d bytes", guid, resp.ContentLength, maxBytes)
+	}
+
+	var response struct {
+		Data struct {
+			Collection []domain.PropertyObject `json:"collection"`
+		} `json:"data"`
+	}
+	body := &io.LimitedReader{R: resp.Body, N: maxBytes + 1}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		if body.N <= 0 {
+			return nil, fmt.Errorf("properties of %s exceed the limit of %d bytes", guid, maxBytes)
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return response.Data.Collection, nil
+}
+
+// get はサーバーのトークンでGETリクエストを送信します
+func (r *APSDerivativeRepository) get(ctx context.Context, endpoint string) (*http.Response, error) {
+	token, err := r.tokenRepo.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,52 @@
+package aps_derivative
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// OpenDerivativeResource はサーバーのトークンを付与してViewerのリクエストをAPSへ転送します
+// レスポンスボディはストリームのまま返すため、呼び出し側でCloseする必要があります
+// タイムアウトは本文の読み込みにも適用されるため、本文を閉じるまでキャンセルしません
+func (r *APSDerivativeRepository) OpenDerivativeResource(ctx context.Context, method string, path string, rawQuery string, header http.Header) (*domain.DerivativeResource, error) {
+	token, err := r.tokenRepo.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.Download)
+
+	endpoint := r.endpoints.Viewer + "/" + path
+	if rawQuery != "" {
+		endpoint += "?" + rawQuery
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return &domain.DerivativeResource{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       aps_timeout.CancelOnClose(resp.Body, cancel),
+	}, nil
+}
@@ -0,0 +1,68 @@
+package aps_derivative
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_client"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+)
+
+// SubmitDerivativeJob は指定した出力形式の派生ファイルを作成するジョブを送信します
+// x-ads-forceを付けないため、作成済みのSVFなどの派生ファイルはそのまま残ります
+func (r *APSDerivativeRepository) SubmitDerivativeJob(ctx context.Context, urn string, formats []domain.DerivativeOutputFormat) (*domain.TranslateJobResponse, error) {
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+	defer cancel()
+
+	token, err := r.tokenRepo.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	requestBody := map[string]any{
+		"input": map[string]any{
+			"urn": urn,
+		},
+		"output": map[string]any{
+			"formats": formats,
+		},
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// x-ads-forceを付けない場合は再送しても重複して作成されないため、5xxでも再送してよい
+	req, err := http.NewRequestWithContext(aps_client.WithRetryable(ctx), "POST",
+		r.endpoints.ModelDerivative+"/designdata/job",
+		bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := aps_error.Check("submit derivative job", resp, http.StatusOK, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	var response domain.TranslateJobResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,64 @@
+package aps_endpoint
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// DefaultHost はAPSのAPIのホスト
+const DefaultHost = "https://developer.api.autodesk.com"
+
+// Endpoints はAPSの各APIのベースURL。末尾のスラッシュは含みません
+type Endpoints struct {
+	// 認証API（例: https://developer.api.autodesk.com/authentication/v2）
+	Auth string
+	// OSS API（例: https://developer.api.autodesk.com/oss/v2）
+	OSS string
+	// Model Derivative API（例: https://developer.api.autodesk.com/modelderivative/v2）
+	ModelDerivative string
+	// Viewerの派生ファイルリクエストの転送先。modelderivative/v2やderivativeservice/v2のパスを付けて呼び出します
+	Viewer string
+	// 署名付きURLのアップロード・ダウンロードを許可するホスト。サブドメインも許可します
+	SignedHosts []string
+}
+
+// Default は本番のAPSのベースURLを返します
+func Default() Endpoints {
+	return ForHost(DefaultHost, "amazonaws.com", "autodesk.com")
+}
+
+// ForHost はすべてのAPIを同じホストで提供する場合のベースURLを返します
+// 署名付きURLはhostと指定したホストを許可します
+func ForHost(host string, signedHosts ...string) Endpoints {
+	host = strings.TrimRight(host, "/")
+	allowed := append([]string{}, signedHosts...)
+	if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+		allowed = append(allowed, u.Hostname())
+	}
+	return Endpoints{
+		Auth:            host + "/authentication/v2",
+		OSS:             host + "/oss/v2",
+		ModelDerivative: host + "/modelderivative/v2",
+		Viewer:          host,
+		SignedHosts:     allowed,
+	}
+}
+
+// CheckSignedURL は署名付きURLのホストが許可されているか確認します
+// アップロード先のURLはクライアントから受け取るため、任意のホストへリクエストを送らないよう制限します
+func (e Endpoints) CheckSignedURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("%w: %s", domain.ErrSignedURLNotAllowed, raw)
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range e.SignedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", domain.ErrSignedURLNotAllowed, u.Host)
+}
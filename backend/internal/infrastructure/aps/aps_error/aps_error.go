@@ -0,0 +1,122 @@
+package aps_error
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// エラーレスポンスの本文を読み込む上限
+const maxErrorBodyBytes = 64 << 10
+
+// リクエストIDが含まれるヘッダー。APSとS3で名前が異なるため順に探します
+var requestIDHeaders = []string{"X-Ads-Request-Id", "X-Request-Id", "X-Amz-Request-Id"}
+
+// Check はレスポンスのステータスがokStatusesのいずれかであればnilを、そうでなければ*domain.APSErrorを返します
+// okStatusesを省略した場合は200のみを成功とみなします。エラーの場合は本文を読み込みます
+func Check(operation string, resp *http.Response, okStatuses ...int) error {
+	if len(okStatuses) == 0 {
+		okStatuses = []int{http.StatusOK}
+	}
+	if slices.Contains(okStatuses, resp.StatusCode) {
+		return nil
+	}
+	return FromResponse(operation, resp)
+}
+
+// FromResponse はエラーのレスポンスから*domain.APSErrorを作成します
+// APSのJSON（reason, developerMessage, diagnosticなど）とS3のXMLのどちらの形式にも対応します
+func FromResponse(operation string, resp *http.Response) *domain.APSError {
+	apsErr := &domain.APSError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		RetryAfter: resp.Header.Get("Retry-After"),
+	}
+	apsErr.RequestID = RequestIDOf(resp)
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	trimmed := strings.TrimSpace(string(body))
+	switch {
+	case strings.HasPrefix(trimmed, "{"):
+		parseJSON(apsErr, []byte(trimmed))
+	case strings.HasPrefix(trimmed, "<"):
+		parseXML(apsErr, []byte(trimmed))
+	case trimmed != "":
+		apsErr.Reason = trimmed
+	}
+	return apsErr
+}
+
+// RequestIDOf はレスポンスのAPS（x-ads-request-id）またはS3のリクエストIDを返します
+func RequestIDOf(resp *http.Response) string {
+	for _, name := range requestIDHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseJSON はAPSのJSON形式のエラー本文を読み取ります
+func parseJSON(apsErr *domain.APSError, body []byte) {
+	var payload struct {
+		Reason           string `json:"reason"`
+		DeveloperMessage string `json:"developerMessage"`
+		Diagnostic       string `json:"diagnostic"`
+		Detail           string `json:"detail"`
+		Title            string `json:"title"`
+		Message          string `json:"message"`
+		ErrorCode        string `json:"errorCode"`
+		Code             string `json:"code"`
+		Errors           []struct {
+			Code   string `json:"code"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		apsErr.Reason = string(body)
+		return
+	}
+
+	for _, reason := range []string{payload.Reason, payload.DeveloperMessage, payload.Diagnostic, payload.Detail, payload.Message, payload.Title} {
+		if reason != "" {
+			apsErr.Reason = reason
+			break
+		}
+	}
+	apsErr.ErrorCode = payload.ErrorCode
+	if apsErr.ErrorCode == "" {
+		apsErr.ErrorCode = payload.Code
+	}
+	if len(payload.Errors) > 0 {
+		if apsErr.Reason == "" {
+			apsErr.Reason = payload.Errors[0].Detail
+		}
+		if apsErr.ErrorCode == "" {
+			apsErr.ErrorCode = payload.Errors[0].Code
+		}
+	}
+}
+
+// parseXML はS3のXML形式のエラー本文を読み取ります
+func parseXML(apsErr *domain.APSError, body []byte) {
+	var payload struct {
+		Code      string `xml:"Code"`
+		Message   string `xml:"Message"`
+		RequestID string `xml:"RequestId"`
+	}
+	if err := xml.Unmarshal(body, &payload); err != nil {
+		apsErr.Reason = string(body)
+		return
+	}
+	apsErr.ErrorCode = payload.Code
+	apsErr.Reason = payload.Message
+	if apsErr.RequestID == "" {
+		apsErr.RequestID = payload.RequestID
+	}
+}
@@ -0,0 +1,153 @@
+package aps_fake
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stage は翻訳ジョブの進捗の1段階。ジョブの送信からAfterが経過するとこの状態になります
+type Stage struct {
+	After    time.Duration
+	Status   string
+	Progress string
+}
+
+// Options は偽のAPSサーバーの設定
+type Options struct {
+	// 翻訳ジョブの進捗の台本。空の場合はDefaultScriptを使います
+	Script []Stage
+	// 発行するトークンの有効期間。0の場合は1時間
+	TokenTTL time.Duration
+	// 署名付きURLに使うURL。空の場合はリクエストのホストを使います
+	PublicURL string
+}
+
+// DefaultScript はdの間に25%ずつ進んで完了する翻訳の台本を返します
+func DefaultScript(d time.Duration) []Stage {
+	return []Stage{
+		{After: 0, Status: "pending", Progress: "0% complete"},
+		{After: d / 4, Status: "inprogress", Progress: "25% complete"},
+		{After: d / 2, Status: "inprogress", Progress: "50% complete"},
+		{After: d * 3 / 4, Status: "inprogress", Progress: "75% complete"},
+		{After: d, Status: "success", Progress: "complete"},
+	}
+}
+
+// Server はAPSの認証・OSS・署名付きS3・Model Derivativeのマニフェストをメモリ上で再現するサーバー
+// Autodeskのアカウントなしでバックエンドとフロントエンドを動かすための開発用で、データは保存しません
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu      sync.Mutex
+	tokens  map[string]time.Time
+	buckets map[string]*bucket
+	uploads map[string]*upload
+	jobs    map[string]*job
+}
+
+// NewServer は新しいServerを作成します
+func NewServer(opts Options) *Server {
+	if len(opts.Script) == 0 {
+		opts.Script = DefaultScript(10 * time.Second)
+	}
+	if opts.TokenTTL <= 0 {
+		opts.TokenTTL = time.Hour
+	}
+
+	s := &Server{
+		opts:    opts,
+		mux:     http.NewServeMux(),
+		tokens:  map[string]time.Time{},
+		buckets: map[string]*bucket{},
+		uploads: map[string]*upload{},
+		jobs:    map[string]*job{},
+	}
+
+	s.mux.HandleFunc("POST /authentication/v2/token", s.issueToken)
+
+	s.mux.HandleFunc("GET /oss/v2/buckets", s.authorized(s.listBuckets))
+	s.mux.HandleFunc("POST /oss/v2/buckets", s.authorized(s.createBucket))
+	s.mux.HandleFunc("GET /oss/v2/buckets/{bucketKey}/details", s.authorized(s.getBucketDetail))
+	s.mux.HandleFunc("DELETE /oss/v2/buckets/{bucketKey}", s.authorized(s.deleteBucket))
+	s.mux.HandleFunc("DELETE /oss/v2/buckets/{bucketKey}/objects/{objectKey}", s.authorized(s.deleteObject))
+	s.mux.HandleFunc("GET /oss/v2/buckets/{bucketKey}/objects/{objectKey}/signeds3upload", s.authorized(s.startUpload))
+	s.mux.HandleFunc("POST /oss/v2/buckets/{bucketKey}/objects/{objectKey}/signeds3upload", s.authorized(s.completeUpload))
+	s.mux.HandleFunc("PUT /s3/{uploadKey}/{part}", s.putPart)
+
+	s.mux.HandleFunc("POST /modelderivative/v2/designdata/job", s.authorized(s.submitJob))
+	s.mux.HandleFunc("GET /modelderivative/v2/designdata/{urn}/manifest", s.authorized(s.getManifest))
+	s.mux.HandleFunc("DELETE /modelderivative/v2/designdata/{urn}/manifest", s.authorized(s.deleteManifest))
+	s.mux.HandleFunc("GET /modelderivative/v2/designdata/{urn}/thumbnail", s.authorized(s.getThumbnail))
+	s.mux.HandleFunc("GET /modelderivative/v2/designdata/{urn}/metadata", s.authorized(s.getMetadata))
+	s.mux.HandleFunc("GET /modelderivative/v2/designdata/{urn}/metadata/{guid}/properties", s.authorized(s.getProperties))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Running は起動中の偽のAPSサーバー
+type Running struct {
+	// サーバーのURL（例: http://127.0.0.1:54321）
+	URL    string
+	server *http.Server
+}
+
+// Start はループバックアドレスの空いているポートでサーバーを起動します
+func Start(opts Options) (*Running, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: NewServer(opts)}
+	go server.Serve(ln)
+	return &Running{URL: "http://" + ln.Addr().String(), server: server}, nil
+}
+
+// Close はサーバーを停止します
+func (r *Running) Close() error {
+	return r.server.Shutdown(context.Background())
+}
+
+// authorized は有効なトークンを持つリクエストだけを処理します
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		expiresAt, found := s.tokens[token]
+		s.mu.Unlock()
+		if !ok || !found || time.Now().After(expiresAt) {
+			writeError(w, http.StatusUnauthorized, "The token is invalid or expired")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// publicURL は署名付きURLに使うサーバーのURLを返します
+func (s *Server) publicURL(r *http.Request) string {
+	if s.opts.PublicURL != "" {
+		return strings.TrimRight(s.opts.PublicURL, "/")
+	}
+	return "http://" + r.Host
+}
+
+// writeJSON はJSONのレスポンスを返します
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError はAPSと同じ形式のエラーレスポンスを返します
+func writeError(w http.ResponseWriter, status int, reason string) {
+	writeJSON(w, status, map[string]string{"reason": reason})
+}
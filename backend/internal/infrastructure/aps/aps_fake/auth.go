@@ -0,0 +1,32 @@
+package aps_fake
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// issueToken はclient_credentialsのトークンを発行します。クライアントIDとシークレットの値は確認しません
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := r.BasicAuth(); !ok {
+		writeError(w, http.StatusUnauthorized, "The client_id specified does not have access to the api product")
+		return
+	}
+	if r.PostFormValue("grant_type") != "client_credentials" {
+		writeError(w, http.StatusBadRequest, "Unsupported grant_type")
+		return
+	}
+
+	token := "fake-" + uuid.New().String()
+	s.mu.Lock()
+	s.tokens[token] = time.Now().Add(s.opts.TokenTTL)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, domain.APSToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.opts.TokenTTL.Seconds()),
+	})
+}
@@ -0,0 +1,273 @@
+package aps_fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+type job struct {
+	urn          string
+	rootFilename string
+	submittedAt  time.Time
+	// ファイル名に"fail"を含む場合は台本の最後の段階で失敗させる
+	fail bool
+}
+
+// submitJob は翻訳ジョブを受け付けます。進捗は受け付けた時刻からの経過時間と台本で決まります
+func (s *Server) submitJob(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Input struct {
+			URN          string `json:"urn"`
+			RootFilename string `json:"rootFilename"`
+		} `json:"input"`
+		Output struct {
+			Formats []struct {
+				Type  string   `json:"type"`
+				Views []string `json:"views"`
+			} `json:"formats"`
+		} `json:"output"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Input.URN == "" || len(req.Output.Formats) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	bucketKey, objectKey, ok := parseURN(req.Input.URN)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "The urn is invalid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[bucketKey]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bucket not found")
+		return
+	}
+	if _, ok := b.objects[objectKey]; !ok {
+		writeError(w, http.StatusNotFound, "Object not found")
+		return
+	}
+
+	var response domain.TranslateJobResponse
+	response.URN = req.Input.URN
+	response.AcceptedJobs.Output.Formats = req.Output.Formats
+
+	// 作成済みのジョブは作り直さない
+	if _, ok := s.jobs[req.Input.URN]; ok {
+		response.Result = "success"
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+
+	rootFilename := req.Input.RootFilename
+	if rootFilename == "" {
+		rootFilename = objectKey
+	}
+	s.jobs[req.Input.URN] = &job{
+		urn:          req.Input.URN,
+		rootFilename: rootFilename,
+		submittedAt:  time.Now(),
+		fail:         strings.Contains(strings.ToLower(rootFilename), "fail"),
+	}
+	response.Result = "created"
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// getManifest は台本に沿って翻訳の進捗を返します
+// 完了するとSVFの3Dビューを1つ持つマニフェストを返しますが、派生ファイルの本体はサムネイルのみ提供します
+func (s *Server) getManifest(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	j, ok := s.jobs[r.PathValue("urn")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Requested manifest not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, j.manifest(s.stageOf(j)))
+}
+
+// deleteManifest はジョブとマニフェストを削除します
+func (s *Server) deleteManifest(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	urn := r.PathValue("urn")
+	if _, ok := s.jobs[urn]; !ok {
+		writeError(w, http.StatusNotFound, "Requested manifest not found")
+		return
+	}
+	delete(s.jobs, urn)
+	writeJSON(w, http.StatusOK, map[string]string{"result": "success"})
+}
+
+// getThumbnail は翻訳が完了したモデルのサムネイルとして、1ピクセルのPNGを返します
+func (s *Server) getThumbnail(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	j, ok := s.jobs[r.PathValue("urn")]
+	s.mu.Unlock()
+	if !ok || s.stageOf(j).Status != "success" {
+		writeError(w, http.StatusNotFound, "Thumbnail not found")
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(thumbnailPNG)
+}
+
+// getMetadata は翻訳が完了したモデルの3Dビューを返します。完了前は空の一覧を返します
+func (s *Server) getMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	j, ok := s.jobs[r.PathValue("urn")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Requested manifest not found")
+		return
+	}
+
+	views := []domain.MetadataView{}
+	if s.stageOf(j).Status == "success" {
+		views = append(views, domain.MetadataView{Name: "{3D}", Role: "3d", GUID: j.viewGUID()})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{"type": "metadata", "metadata": views},
+	})
+}
+
+// getProperties は3Dビューの要素として、ファイル名と3つのレベル、それぞれのレベルの壁を返します
+// 翻訳の完了前は、プロパティを準備中であることを表す202を返します
+func (s *Server) getProperties(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	j, ok := s.jobs[r.PathValue("urn")]
+	s.mu.Unlock()
+	if !ok || r.PathValue("guid") != j.viewGUID() {
+		writeError(w, http.StatusNotFound, "Requested view not found")
+		return
+	}
+	if s.stageOf(j).Status != "success" {
+		writeJSON(w, http.StatusAccepted, map[string]string{"result": "success"})
+		return
+	}
+
+	objects := []domain.PropertyObject{{ObjectID: 1, Name: j.rootFilename, Properties: map[string]any{}}}
+	for i := 1; i <= 3; i++ {
+		level := fmt.Sprintf("Level %d", i)
+		objects = append(objects,
+			domain.PropertyObject{
+				ObjectID:   i * 10,
+				Name:       level,
+				ExternalID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(j.urn+"/"+level)).String(),
+				Properties: map[string]any{
+					"Constraints": map[string]any{"Elevation": fmt.Sprintf("%d mm", (i-1)*4000)},
+				},
+			},
+			domain.PropertyObject{
+				ObjectID:   i*10 + 1,
+				Name:       fmt.Sprintf("Basic Wall [%d]", 1000+i),
+				ExternalID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(j.urn+"/wall/"+level)).String(),
+				Properties: map[string]any{
+					"Constraints":   map[string]any{"Base Constraint": level, "Unconnected Height": 4000},
+					"Identity Data": map[string]any{"Type Name": "Generic - 200mm"},
+				},
+			})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{"type": "properties", "collection": objects},
+	})
+}
+
+// stageOf はジョブの送信からの経過時間に応じた台本の段階を返します
+func (s *Server) stageOf(j *job) Stage {
+	stage := s.opts.Script[0]
+	elapsed := time.Since(j.submittedAt)
+	for i, st := range s.opts.Script {
+		if st.After > elapsed {
+			break
+		}
+		stage = st
+		if j.fail && i == len(s.opts.Script)-1 {
+			stage.Status = "failed"
+		}
+	}
+	return stage
+}
+
+// 1x1ピクセルの灰色のPNG
+var thumbnailPNG, _ = base64.StdEncoding.DecodeString(
+	"iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAAAAAA6fptVAAAACklEQVR4nGNoAAAAggCBd81ytgAAAABJRU5ErkJggg==")
+
+// manifest は指定した段階のマニフェストを作成します
+func (j *job) manifest(stage Stage) domain.TranslationStatus {
+	derivative := domain.Derivative{
+		Name:         j.rootFilename,
+		HasThumbnail: "false",
+		Status:       stage.Status,
+		Progress:     stage.Progress,
+		OutputType:   "svf",
+	}
+
+	hasThumbnail := "false"
+	switch stage.Status {
+	case "success":
+		hasThumbnail = "true"
+		derivative.HasThumbnail = hasThumbnail
+		derivative.Children = []domain.Children{{
+			GUID:         j.viewGUID(),
+			Type:         "geometry",
+			Role:         "3d",
+			Name:         "{3D}",
+			Status:       "success",
+			Progress:     "complete",
+			HasThumbnail: hasThumbnail,
+			Children: []domain.Resource{{
+				GUID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(j.urn+"/3d/svf")).String(),
+				Type: "resource",
+				URN:  "urn:adsk.viewing:fs.file:" + j.urn + "/output/1/0.svf",
+				Role: "graphics",
+				Mime: "application/autodesk-svf",
+			}},
+		}}
+	case "failed":
+		derivative.Messages = []domain.Message{{
+			Type:    "error",
+			Code:    "TranslationWorker-InternalFailure",
+			Message: []string{"Unrecoverable exit code from extractor: -1073741831"},
+		}}
+	}
+
+	return domain.TranslationStatus{
+		Type:         "manifest",
+		HasThumbnail: hasThumbnail,
+		Status:       stage.Status,
+		Progress:     stage.Progress,
+		Region:       "US",
+		URN:          j.urn,
+		Derivatives:  []domain.Derivative{derivative},
+	}
+}
+
+// viewGUID は翻訳が完了したモデルの3DビューのGUIDを返します
+func (j *job) viewGUID() string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(j.urn+"/3d")).String()
+}
+
+// parseURN はBase64エンコードされたオブジェクトのURNからバケットキーとオブジェクトキーを取り出します
+func parseURN(urn string) (string, string, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(urn, "="))
+	if err != nil {
+		return "", "", false
+	}
+	rest, ok := strings.CutPrefix(string(decoded), "urn:adsk.objects:os.object:")
+	if !ok {
+		return "", "", false
+	}
+	bucketKey, objectKey, ok := strings.Cut(rest, "/")
+	return bucketKey, objectKey, ok && bucketKey != "" && objectKey != ""
+}
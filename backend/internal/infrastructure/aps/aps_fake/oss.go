@@ -0,0 +1,252 @@
+package aps_fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 1回の署名付きURLの発行で要求できるパート数の上限
+const maxUploadParts = 25
+
+// OSSのバケットキーとして使える文字列
+var bucketKeyPattern = regexp.MustCompile(`^[-_.a-z0-9]{3,128}$`)
+
+type bucket struct {
+	key       string
+	policyKey string
+	createdAt time.Time
+	objects   map[string][]byte
+}
+
+type upload struct {
+	bucketKey string
+	objectKey string
+	parts     [][]byte
+	expiresAt time.Time
+}
+
+func (b *bucket) detail() domain.APSBucketDetail {
+	return domain.APSBucketDetail{
+		BucketKey:   b.key,
+		BucketOwner: "fake",
+		CreatedDate: b.createdAt.UnixMilli(),
+		Permissions: []domain.Permission{{AuthId: "fake", Access: "full"}},
+		PolicyKey:   b.policyKey,
+	}
+}
+
+func (s *Server) listBuckets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	items := []domain.APSBucket{}
+	for _, b := range s.buckets {
+		items = append(items, domain.APSBucket{BucketKey: b.key, CreatedDate: b.createdAt.UnixMilli(), PolicyKey: b.policyKey})
+	}
+	s.mu.Unlock()
+
+	sort.Slice(items, func(i, j int) bool { return items[i].CreatedDate < items[j].CreatedDate })
+	writeJSON(w, http.StatusOK, domain.BucketsResponse{Items: items})
+}
+
+func (s *Server) createBucket(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		BucketKey string `json:"bucketKey"`
+		PolicyKey string `json:"policyKey"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !bucketKeyPattern.MatchString(req.BucketKey) {
+		writeError(w, http.StatusBadRequest, "Bucket key is invalid")
+		return
+	}
+	switch req.PolicyKey {
+	case "transient", "temporary", "persistent":
+	default:
+		writeError(w, http.StatusBadRequest, "Policy key is invalid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.buckets[req.BucketKey]; ok {
+		writeError(w, http.StatusConflict, "Bucket already exists")
+		return
+	}
+	b := &bucket{key: req.BucketKey, policyKey: req.PolicyKey, createdAt: time.Now(), objects: map[string][]byte{}}
+	s.buckets[b.key] = b
+	writeJSON(w, http.StatusOK, b.detail())
+}
+
+func (s *Server) getBucketDetail(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	b, ok := s.buckets[r.PathValue("bucketKey")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bucket not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, b.detail())
+}
+
+func (s *Server) deleteBucket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.PathValue("bucketKey")
+	if _, ok := s.buckets[key]; !ok {
+		writeError(w, http.StatusNotFound, "Bucket not found")
+		return
+	}
+	delete(s.buckets, key)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteObject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[r.PathValue("bucketKey")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bucket not found")
+		return
+	}
+	objectKey := r.PathValue("objectKey")
+	if _, ok := b.objects[objectKey]; !ok {
+		writeError(w, http.StatusNotFound, "Object not found")
+		return
+	}
+	delete(b.objects, objectKey)
+	w.WriteHeader(http.StatusOK)
+}
+
+// startUpload はアップロードキーとパートごとの署名付きURLを発行します
+// 署名付きURLはこのサーバーの/s3/を指します
+func (s *Server) startUpload(w http.ResponseWriter, r *http.Request) {
+	parts := 1
+	if v := r.URL.Query().Get("parts"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxUploadParts {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("parts must be between 1 and %d", maxUploadParts))
+			return
+		}
+		parts = n
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucketKey := r.PathValue("bucketKey")
+	if _, ok := s.buckets[bucketKey]; !ok {
+		writeError(w, http.StatusNotFound, "Bucket not found")
+		return
+	}
+
+	uploadKey := uuid.New().String()
+	expiresAt := time.Now().Add(time.Hour)
+	s.uploads[uploadKey] = &upload{
+		bucketKey: bucketKey,
+		objectKey: r.PathValue("objectKey"),
+		parts:     make([][]byte, parts),
+		expiresAt: expiresAt,
+	}
+
+	urls := make([]string, parts)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/s3/%s/%d", s.publicURL(r), uploadKey, i+1)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"uploadKey":        uploadKey,
+		"uploadExpiration": expiresAt.UTC().Format(time.RFC3339),
+		"urlExpiration":    expiresAt.UTC().Format(time.RFC3339),
+		"urls":             urls,
+	})
+}
+
+// putPart は署名付きURLへのパートのアップロードを受け付けます。S3と同じくトークンは不要です
+func (s *Server) putPart(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.uploads[r.PathValue("uploadKey")]
+	part, err := strconv.Atoi(r.PathValue("part"))
+	if !ok || time.Now().After(u.expiresAt) {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied", "Request has expired")
+		return
+	}
+	if err != nil || part < 1 || part > len(u.parts) {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Part number is invalid")
+		return
+	}
+	u.parts[part-1] = data
+	w.Header().Set("ETag", fmt.Sprintf("%q", uuid.NewSHA1(uuid.NameSpaceOID, data).String()))
+	w.WriteHeader(http.StatusOK)
+}
+
+// completeUpload はアップロード済みのパートを結合してオブジェクトを作成します
+func (s *Server) completeUpload(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UploadKey string `json:"uploadKey"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucketKey, objectKey := r.PathValue("bucketKey"), r.PathValue("objectKey")
+	u, ok := s.uploads[req.UploadKey]
+	if !ok || u.bucketKey != bucketKey || u.objectKey != objectKey {
+		writeError(w, http.StatusNotFound, "Upload key not found")
+		return
+	}
+	b, ok := s.buckets[bucketKey]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bucket not found")
+		return
+	}
+	for _, part := range u.parts {
+		if part == nil {
+			writeError(w, http.StatusBadRequest, "Not all parts have been uploaded")
+			return
+		}
+	}
+
+	data := bytes.Join(u.parts, nil)
+	b.objects[objectKey] = data
+	delete(s.uploads, req.UploadKey)
+
+	writeJSON(w, http.StatusOK, domain.APSObject{
+		BucketKey:   bucketKey,
+		ObjectId:    objectID(bucketKey, objectKey),
+		ObjectKey:   objectKey,
+		Size:        int64(len(data)),
+		ContentType: "application/octet-stream",
+		Location:    fmt.Sprintf("%s/oss/v2/buckets/%s/objects/%s", s.publicURL(r), bucketKey, objectKey),
+	})
+}
+
+// objectID はOSSのオブジェクトIDを返します
+func objectID(bucketKey string, objectKey string) string {
+	return fmt.Sprintf("urn:adsk.objects:os.object:%s/%s", bucketKey, objectKey)
+}
+
+// writeS3Error はS3と同じXML形式のエラーレスポンスを返します
+func writeS3Error(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
@@ -5,19 +5,25 @@ import (
 	"fmt"
 	"net/http"
 	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
 // APSObjectRepository はAPSオブジェクトのリポジトリ実装
 type APSObjectRepository struct {
 	client    *http.Client
 	tokenRepo domain.APSTokenRepository
+	endpoints aps_endpoint.Endpoints
+	timeouts  aps_timeout.Timeouts
 }
 
 // NewAPSObjectRepository は新しいAPSObjectRepositoryを作成します
-func NewAPSObjectRepository(client *http.Client, tokenRepo domain.APSTokenRepository) *APSObjectRepository {
+func NewAPSObjectRepository(client *http.Client, tokenRepo domain.APSTokenRepository, endpoints aps_endpoint.Endpoints, timeouts aps_timeout.Timeouts) *APSObjectRepository {
 	return &APSObjectRepository{
 		client:    client,
 		tokenRepo: tokenRepo,
+		endpoints: endpoints,
+		timeouts:  timeouts,
 	}
 }
 
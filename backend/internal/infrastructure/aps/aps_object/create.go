@@ -2,19 +2,25 @@ package aps_object
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "net/http"
     
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
-func (r *APSObjectRepository) CreateObject(bucketKey string, objectKey string, uploadKey string) (*domain.APSObject, error) {
+func (r *APSObjectRepository) CreateObject(ctx context.Context, bucketKey string, objectKey string, uploadKey string) (*domain.APSObject, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
     // Generate objectId in correct format
     objectId := fmt.Sprintf("%s/%s", bucketKey, objectKey)
     
-    url := fmt.Sprintf("https://developer.api.autodesk.com/oss/v2/buckets/%s/objects/%s/signeds3upload", 
-        bucketKey, objectKey)
+    url := fmt.Sprintf("%s/buckets/%s/objects/%s/signeds3upload", 
+        r.endpoints.OSS, bucketKey, objectKey)
 
     reqBody := map[string]string{
         "uploadKey": uploadKey,
@@ -24,12 +30,12 @@ func (r *APSObjectRepository) CreateObject(bucketKey string, objectKey string, u
         return nil, err
     }
 
-    req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+    req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
     if err != nil {
         return nil, err
     }
 
-    token, err := r.tokenRepo.GetToken()
+    token, err := r.tokenRepo.GetToken(ctx)
     if err != nil {
         return nil, err
     }
@@ -44,6 +50,10 @@ func (r *APSObjectRepository) CreateObject(bucketKey string, objectKey string, u
     }
     defer resp.Body.Close()
 
+    if err := aps_error.Check("complete s3 upload", resp); err != nil {
+        return nil, err
+    }
+
     var apsObject domain.APSObject
     if err := json.NewDecoder(resp.Body).Decode(&apsObject); err != nil {
         return nil, err
@@ -54,8 +64,9 @@ func (r *APSObjectRepository) CreateObject(bucketKey string, objectKey string, u
         ObjectId: objectId,
         BucketKey: bucketKey,
         ObjectKey: objectKey,
-        Location: "",  // Set appropriate value if needed
-        Size: 0,      // Set appropriate value if needed
+        Location: apsObject.Location,
+        Size: apsObject.Size,
+        ContentType: apsObject.ContentType,
     }
     
     return &apsObject, nil
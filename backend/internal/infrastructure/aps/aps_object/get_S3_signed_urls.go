@@ -1,27 +1,33 @@
 package aps_object
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
 // GetS3SignedURLs はS3署名付きURLを取得します
-func (r *APSObjectRepository) GetS3SignedURLs(bucketKey string, objectKey string, parts int) (*domain.APSObject, error) {
+func (r *APSObjectRepository) GetS3SignedURLs(ctx context.Context, bucketKey string, objectKey string, parts int) (*domain.APSObject, error) {
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+	defer cancel()
+
 	// アクセストークンを取得
-	token, err := r.tokenRepo.GetToken()
+	token, err := r.tokenRepo.GetToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// APIエンドポイントを構築
-	url := fmt.Sprintf("https://developer.api.autodesk.com/oss/v2/buckets/%s/objects/%s/signeds3upload?parts=%d", 
-		bucketKey, objectKey, parts)
+	url := fmt.Sprintf("%s/buckets/%s/objects/%s/signeds3upload?parts=%d", 
+		r.endpoints.OSS, bucketKey, objectKey, parts)
 
 	// リクエストを作成
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +43,10 @@ func (r *APSObjectRepository) GetS3SignedURLs(bucketKey string, objectKey string
 	}
 	defer resp.Body.Close()
 
+	if err := aps_error.Check("get s3 signed upload urls", resp); err != nil {
+		return nil, err
+	}
+
 	// レスポンスを解析
 	var apiResponse struct {
 		URLs             []string `json:"urls"`
@@ -54,7 +64,7 @@ func (r *APSObjectRepository) GetS3SignedURLs(bucketKey string, objectKey string
 		ObjectId:         fmt.Sprintf("urn:adsk.objects:os.object:%s/%s", bucketKey, objectKey),
 		ObjectKey:        objectKey,
 		ContentType:     "application/octet-stream",
-		Location:        fmt.Sprintf("https://developer.api.autodesk.com/oss/v2/buckets/%s/objects/%s", bucketKey, objectKey),
+		Location:        fmt.Sprintf("%s/buckets/%s/objects/%s", r.endpoints.OSS, bucketKey, objectKey),
 		URLs:            apiResponse.URLs,
 		UploadKey:       apiResponse.UploadKey,
 		UploadExpiration: apiResponse.UploadExpiration,
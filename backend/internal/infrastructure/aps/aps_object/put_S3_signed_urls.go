@@ -2,15 +2,26 @@ package aps_object
 
 import (
 	"bytes"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/metrics"
 )
 
 // PutS3SignedURLs はS3署名付きURLを使用してオブジェクトをアップロードします
-func (r *APSObjectRepository) PutS3SignedURLs(signedURL string, fileContent []byte) error {
+func (r *APSObjectRepository) PutS3SignedURLs(ctx context.Context, signedURL string, fileContent []byte) error {
+	if err := r.endpoints.CheckSignedURL(signedURL); err != nil {
+		return err
+	}
+
+	ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.Upload)
+	defer cancel()
+
 	// リクエストを作成
-	req, err := http.NewRequest("PUT", signedURL, bytes.NewReader(fileContent))
+	req, err := http.NewRequestWithContext(ctx, "PUT", signedURL, bytes.NewReader(fileContent))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -19,23 +30,17 @@ func (r *APSObjectRepository) PutS3SignedURLs(signedURL string, fileContent []by
 	req.Header.Set("Content-Type", "application/octet-stream")
 
 	// リクエストを送信
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := r.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// レスポンスを読み取り
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// ステータスコードをチェック
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API returned non-OK status: %d, body: %s", resp.StatusCode, string(body))
+	// ステータスコードをチェック（S3のエラーはXMLで返る）
+	if err := aps_error.Check("upload part to s3", resp); err != nil {
+		return err
 	}
 
+	metrics.UploadBytes.Add(float64(len(fileContent)))
 	return nil
 }
@@ -1,34 +1,42 @@
 package aps_object
 
 import (
+    "context"
     "encoding/json"
     "fmt"
     "net/http"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
-func (r *APSObjectRepository) TrackTranslationJobStatus(urn string) (*domain.TranslationStatus, error) {
-    token, err := r.tokenRepo.GetToken()
+func (r *APSObjectRepository) TrackTranslationJobStatus(ctx context.Context, urn string) (*domain.TranslationStatus, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
+    token, err := r.tokenRepo.GetToken(ctx)
     if err != nil {
         return nil, fmt.Errorf("failed to get access token: %w", err)
     }
 
-    req, err := http.NewRequest("GET", 
-        fmt.Sprintf("https://developer.api.autodesk.com/modelderivative/v2/designdata/%s/manifest", urn),
+    req, err := http.NewRequestWithContext(ctx, "GET", 
+        fmt.Sprintf("%s/designdata/%s/manifest", r.endpoints.ModelDerivative, urn),
         nil)
     if err != nil {
         return nil, fmt.Errorf("failed to create request: %w", err)
     }
 
     req.Header.Set("Authorization", "Bearer "+token.AccessToken)
-
-    client := &http.Client{}
-    resp, err := client.Do(req)
+    resp, err := r.client.Do(req)
     if err != nil {
         return nil, fmt.Errorf("failed to send request: %w", err)
     }
     defer resp.Body.Close()
 
+    if err := aps_error.Check("get manifest", resp); err != nil {
+        return nil, err
+    }
+
     var status domain.TranslationStatus
     if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
         return nil, fmt.Errorf("failed to decode response: %w", err)
@@ -2,15 +2,22 @@ package aps_object
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "net/http"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_client"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
-func (r *APSObjectRepository) TranslateObject(base64URN string, objectKey string) (*domain.TranslateJobResponse, error) {
+func (r *APSObjectRepository) TranslateObject(ctx context.Context, base64URN string, objectKey string) (*domain.TranslateJobResponse, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.API)
+    defer cancel()
+
     // アクセストークンを取得
-    token, err := r.tokenRepo.GetToken()
+    token, err := r.tokenRepo.GetToken(ctx)
     if err != nil {
         return nil, fmt.Errorf("failed to get access token: %w", err)
     }
@@ -38,8 +45,9 @@ func (r *APSObjectRepository) TranslateObject(base64URN string, objectKey string
     }
 
     // APIリクエストを作成
-    req, err := http.NewRequest("POST", 
-        "https://developer.api.autodesk.com/modelderivative/v2/designdata/job",
+    // 同じURNの翻訳ジョブは再送しても重複して作成されないため、5xxでも再送してよい
+    req, err := http.NewRequestWithContext(aps_client.WithRetryable(ctx), "POST", 
+        r.endpoints.ModelDerivative+"/designdata/job",
         bytes.NewBuffer(jsonBody))
     if err != nil {
         return nil, fmt.Errorf("failed to create request: %w", err)
@@ -49,13 +57,16 @@ func (r *APSObjectRepository) TranslateObject(base64URN string, objectKey string
     req.Header.Set("Authorization", "Bearer "+token.AccessToken)
 
     // リクエストを送信
-    client := &http.Client{}
-    resp, err := client.Do(req)
+    resp, err := r.client.Do(req)
     if err != nil {
         return nil, fmt.Errorf("failed to send request: %w", err)
     }
     defer resp.Body.Close()
 
+    if err := aps_error.Check("submit translation job", resp, http.StatusOK, http.StatusCreated); err != nil {
+        return nil, err
+    }
+
     // レスポンスを解析
     var response domain.TranslateJobResponse
     if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
@@ -0,0 +1,54 @@
+package aps_timeout
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Timeouts はAPS呼び出しの種類ごとのタイムアウト。0の場合はタイムアウトしません
+type Timeouts struct {
+	// トークンの取得
+	Auth time.Duration `yaml:"auth"`
+	// OSS・Model DerivativeのAPI呼び出し
+	API time.Duration `yaml:"api"`
+	// 署名付きURLでのS3へのアップロード（1パートごと）
+	Upload time.Duration `yaml:"upload"`
+	// 派生ファイルのダウンロード（本文の読み込みを含む）
+	Download time.Duration `yaml:"download"`
+}
+
+// Default は既定のタイムアウトを返します
+func Default() Timeouts {
+	return Timeouts{
+		Auth:     30 * time.Second,
+		API:      time.Minute,
+		Upload:   10 * time.Minute,
+		Download: 30 * time.Minute,
+	}
+}
+
+// WithTimeout はdが0より大きい場合にタイムアウト付きのコンテキストを返します
+func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// CancelOnClose はレスポンスの本文を閉じたときにコンテキストをキャンセルするようにします
+// 本文を呼び出し元に返す場合、リクエストを送った時点ではキャンセルできないため使います
+func CancelOnClose(body io.ReadCloser, cancel context.CancelFunc) io.ReadCloser {
+	return &cancelOnCloseBody{ReadCloser: body, cancel: cancel}
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
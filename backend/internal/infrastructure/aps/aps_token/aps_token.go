@@ -2,14 +2,53 @@ package aps_token
 
 import (
     "net/http"
+    "sync"
+    "time"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
 )
 
+// 有効期限のこの時間前になったらトークンを取り直す
+const tokenRefreshMargin = time.Minute
+
+const (
+    // InternalScope はサーバー内でOSSとModel Derivativeを操作するトークンのスコープ
+    InternalScope = "data:read data:write data:create bucket:read bucket:create bucket:delete"
+    // ViewerScope はブラウザのViewerに渡すトークンのスコープ。派生ファイルの閲覧のみを許可します
+    ViewerScope = "viewables:read"
+)
+
+// Credentials はclient_credentialsでトークンを取得するためのアプリの認証情報
+type Credentials struct {
+    ClientID     string
+    ClientSecret string
+}
+
 type APSTokenRepository struct {
-    client *http.Client
+    client      *http.Client
+    credentials Credentials
+    endpoints   aps_endpoint.Endpoints
+    timeouts    aps_timeout.Timeouts
+    scope       string
+
+    mu        sync.Mutex
+    token     *domain.APSToken
+    expiresAt time.Time
 }
 
-func NewAPSTokenRepository() *APSTokenRepository {
+// NewAPSTokenRepository はscopeのトークンを取得してキャッシュするリポジトリを作成します
+// スコープごとにキャッシュを分けるため、スコープごとに作成してください
+func NewAPSTokenRepository(client *http.Client, credentials Credentials, endpoints aps_endpoint.Endpoints, timeouts aps_timeout.Timeouts, scope string) *APSTokenRepository {
     return &APSTokenRepository{
-        client: &http.Client{},
+        client:      client,
+        credentials: credentials,
+        endpoints:   endpoints,
+        timeouts:    timeouts,
+        scope:       scope,
     }
-}
\ No newline at end of file
+}
+
+// インターフェースの実装を確認
+var _ domain.APSTokenRepository = (*APSTokenRepository)(nil)
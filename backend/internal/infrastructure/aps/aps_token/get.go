@@ -1,30 +1,65 @@
 package aps_token
 
 import (
+    "context"
     "encoding/json"
     "net/http"
     "net/url"
-    "os"
     "strings"
+    "time"
 
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_client"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_error"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/metrics"
 )
 
-func (r *APSTokenRepository) GetToken() (*domain.APSToken, error) {
-    clientID := os.Getenv("APS_CLIENT_ID")
-    clientSecret := os.Getenv("APS_CLIENT_SECRET")
-    
+// GetToken は有効期限内のキャッシュがあればそれを返し、なければトークンを取得します
+func (r *APSTokenRepository) GetToken(ctx context.Context) (*domain.APSToken, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if r.token != nil && time.Until(r.expiresAt) > tokenRefreshMargin {
+        return r.cached(), nil
+    }
+    metrics.TokenRefreshes.WithLabelValues("expiry").Inc()
+    return r.fetchLocked(ctx)
+}
+
+// RefreshToken はキャッシュを使わずにトークンを取り直します
+func (r *APSTokenRepository) RefreshToken(ctx context.Context) (*domain.APSToken, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    metrics.TokenRefreshes.WithLabelValues("unauthorized").Inc()
+    return r.fetchLocked(ctx)
+}
+
+// cached はキャッシュしたトークンを残りの有効期限に合わせて返します
+func (r *APSTokenRepository) cached() *domain.APSToken {
+    token := *r.token
+    token.ExpiresIn = int(time.Until(r.expiresAt).Seconds())
+    return &token
+}
+
+// fetchLocked はAPSからトークンを取得してキャッシュします。r.muを保持して呼び出してください
+func (r *APSTokenRepository) fetchLocked(ctx context.Context) (*domain.APSToken, error) {
+    ctx, cancel := aps_timeout.WithTimeout(ctx, r.timeouts.Auth)
+    defer cancel()
+
     data := url.Values{}
     data.Set("grant_type", "client_credentials")
-    data.Set("scope", "data:read data:write data:create bucket:read bucket:create bucket:delete") 
+    data.Set("scope", r.scope)
     
-    req, err := http.NewRequest("POST", "https://developer.api.autodesk.com/authentication/v2/token", 
+    // client_credentialsでのトークン取得は何度送っても同じ結果になるため再送してよい
+    req, err := http.NewRequestWithContext(aps_client.WithRetryable(ctx), "POST", r.endpoints.Auth+"/token", 
         strings.NewReader(data.Encode()))
     if err != nil {
         return nil, err
     }
     
-    req.SetBasicAuth(clientID, clientSecret)
+    req.SetBasicAuth(r.credentials.ClientID, r.credentials.ClientSecret)
     req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
     
     resp, err := r.client.Do(req)
@@ -32,11 +67,17 @@ func (r *APSTokenRepository) GetToken() (*domain.APSToken, error) {
         return nil, err
     }
     defer resp.Body.Close()
+
+    if err := aps_error.Check("get token", resp); err != nil {
+        return nil, err
+    }
     
     var token domain.APSToken
     if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
         return nil, err
     }
-    
-    return &token, nil
+
+    r.token = &token
+    r.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+    return r.cached(), nil
 }
\ No newline at end of file
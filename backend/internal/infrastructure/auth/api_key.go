@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// APIKeyHeader はAPIキーを送るヘッダー
+const APIKeyHeader = "X-API-Key"
+
+// ハッシュで指定したAPIキーの接頭辞
+const sha256Prefix = "sha256:"
+
+// APIKeyAuthenticator はX-API-Keyヘッダーの静的なAPIキーを検証するAuthenticator
+type APIKeyAuthenticator struct {
+	keys []apiKeyHash
+}
+
+type apiKeyHash struct {
+	key  APIKey
+	hash []byte
+}
+
+// NewAPIKeyAuthenticator はAPIキーのAuthenticatorを作成します
+// キーはSHA-256のハッシュで保持し、比較は一定時間で行います
+func NewAPIKeyAuthenticator(keys []APIKey) (*APIKeyAuthenticator, error) {
+	a := &APIKeyAuthenticator{}
+	for _, key := range keys {
+		hash, err := HashAPIKey(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("api key %q: %w", key.Name, err)
+		}
+		a.keys = append(a.keys, apiKeyHash{key: key, hash: hash})
+	}
+	return a, nil
+}
+
+// HashAPIKey は設定したAPIキー（平文または"sha256:<16進数>"）のSHA-256のハッシュを返します
+func HashAPIKey(key string) ([]byte, error) {
+	if hexHash, ok := strings.CutPrefix(key, sha256Prefix); ok {
+		hash, err := hex.DecodeString(hexHash)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, errors.New("sha256 hash must be 64 hex characters")
+		}
+		return hash, nil
+	}
+	if key == "" {
+		return nil, errors.New("key is empty")
+	}
+	hash := sha256.Sum256([]byte(key))
+	return hash[:], nil
+}
+
+// Authenticate はX-API-Keyヘッダーのキーを検証します
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+	hash := sha256.Sum256([]byte(key))
+	// どのキーと一致したかで処理時間が変わらないよう、すべてのキーと比較する
+	var matched *apiKeyHash
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], a.keys[i].hash) == 1 {
+			matched = &a.keys[i]
+		}
+	}
+	if matched == nil {
+		return nil, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+	}
+	return &domain.Principal{
+		ID:     matched.key.Name,
+		Method: domain.AuthMethodAPIKey,
+		Roles:  matched.key.Roles,
+		Groups: matched.key.Groups,
+	}, nil
+}
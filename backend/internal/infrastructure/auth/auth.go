@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+var (
+	// ErrNoCredentials はリクエストに認証情報がないことを表します
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials は認証情報が不正であることを表します
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator はリクエストの認証情報を検証し、呼び出し元を返します
+// 扱う種類の認証情報がリクエストにない場合はErrNoCredentialsを返します
+type Authenticator interface {
+	Authenticate(r *http.Request) (*domain.Principal, error)
+}
+
+// Options は認証の設定。APIキーもJWTも設定しない場合は認証を行いません
+type Options struct {
+	// 開発用に認証を無効にし、すべてのリクエストをadminロールの呼び出し元として扱います
+	// APIキーもJWTも設定せずにこれを指定しない場合、サーバーは起動しません
+	Disabled bool `yaml:"disabled"`
+	// サービス向けの静的なAPIキー（X-API-Keyヘッダー）
+	APIKeys []APIKey `yaml:"apiKeys"`
+	// ユーザー向けのJWT（Authorization: Bearer）
+	JWT JWTOptions `yaml:"jwt"`
+}
+
+// APIKey はサービスに発行したAPIキー
+type APIKey struct {
+	// 呼び出し元の識別子としてログや監査に記録する名前
+	Name string `yaml:"name"`
+	// キーの値。平文の代わりに"sha256:"に続けてSHA-256の16進数を指定できます
+	Key string `yaml:"key"`
+	// すべてのバケットに対するロール（viewer, uploader, admin）と所属するグループ
+	Roles  []string `yaml:"roles"`
+	Groups []string `yaml:"groups"`
+}
+
+// JWTOptions はJWTの検証の設定
+type JWTOptions struct {
+	// RS256・ES256・EdDSAなどの署名を検証する公開鍵のJWKSファイル
+	JWKSFile string `yaml:"jwksFile"`
+	// HS256・HS384・HS512の署名を検証する共有鍵
+	HMACSecret string `yaml:"hmacSecret"`
+	// 指定した場合はissとaudを検証します
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// exp・nbf・iatの検証で許容する時計のずれ
+	Leeway time.Duration `yaml:"leeway"`
+	// ロールとグループを読み取るクレームの名前。値は文字列の配列またはスペース区切りの文字列
+	RolesClaim  string `yaml:"rolesClaim"`
+	GroupsClaim string `yaml:"groupsClaim"`
+}
+
+// Enabled はJWTの検証を設定しているか判定します
+func (o JWTOptions) Enabled() bool {
+	return o.JWKSFile != "" || o.HMACSecret != ""
+}
+
+// DefaultOptions は既定の認証の設定を返します
+func DefaultOptions() Options {
+	return Options{
+		JWT: JWTOptions{
+			Leeway:      30 * time.Second,
+			RolesClaim:  "roles",
+			GroupsClaim: "groups",
+		},
+	}
+}
+
+// Enabled は認証を行うか判定します
+func (o Options) Enabled() bool {
+	return len(o.APIKeys) > 0 || o.JWT.Enabled()
+}
+
+// New は設定した認証方法を順に試すAuthenticatorを返します。認証を設定していない場合はnilを返します
+func New(opts Options) (Authenticator, error) {
+	var chain Chain
+	if len(opts.APIKeys) > 0 {
+		a, err := NewAPIKeyAuthenticator(opts.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, a)
+	}
+	if opts.JWT.Enabled() {
+		a, err := NewJWTAuthenticator(opts.JWT)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, a)
+	}
+	if len(chain) == 0 {
+		return nil, nil
+	}
+	return chain, nil
+}
+
+// Chain は複数の認証方法を順に試すAuthenticator
+// 認証情報を扱った最初の認証方法の結果を返します
+type Chain []Authenticator
+
+// Authenticate はいずれかの認証方法でリクエストを認証します
+func (c Chain) Authenticate(r *http.Request) (*domain.Principal, error) {
+	for _, a := range c {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+	return nil, ErrNoCredentials
+}
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+const testHMACSecret = "0123456789abcdef0123456789abcdef"
+
+// writeJWKS はEd25519の公開鍵1つを含むJWKSファイルを作成し、秘密鍵を返します
+func writeJWKS(t *testing.T) (string, ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"k1","use":"sig","x":%q}]}`, base64.RawURLEncoding.EncodeToString(public))
+	if err := os.WriteFile(path, []byte(jwks), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path, public, private
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func bearer(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/models", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	jwksPath, public, private := writeJWKS(t)
+	a, err := NewJWTAuthenticator(JWTOptions{
+		JWKSFile:   jwksPath,
+		HMACSecret: testHMACSecret,
+		Issuer:     "https://idp.example.com",
+		Audience:   "aps-viewer",
+		RolesClaim: "roles",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 公開鍵のみを設定した場合は、公開鍵を共有鍵として使ったHS256を受け付けない
+	publicOnly, err := NewJWTAuthenticator(JWTOptions{JWKSFile: jwksPath, Issuer: "https://idp.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "alice",
+			"iss":   "https://idp.example.com",
+			"aud":   "aps-viewer",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{domain.RoleUploader},
+		}
+	}
+	with := func(key string, value any) jwt.MapClaims {
+		c := valid()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		auth    *JWTAuthenticator
+		token   string
+		wantErr bool
+	}{
+		{name: "hmac", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), valid(), "")},
+		{name: "ed25519 from jwks", auth: a, token: sign(t, jwt.SigningMethodEdDSA, private, valid(), "k1")},
+		{name: "wrong issuer", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), with("iss", "https://evil.example.com"), ""), wantErr: true},
+		{name: "wrong audience", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), with("aud", "other"), ""), wantErr: true},
+		{name: "expired", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), with("exp", now.Add(-time.Hour).Unix()), ""), wantErr: true},
+		{name: "no expiry", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), with("exp", nil), ""), wantErr: true},
+		{name: "issued in the future", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), with("iat", now.Add(time.Hour).Unix()), ""), wantErr: true},
+		{name: "no subject", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), with("sub", nil), ""), wantErr: true},
+		{name: "wrong secret", auth: a, token: sign(t, jwt.SigningMethodHS256, []byte("another secret of sufficient size"), valid(), ""), wantErr: true},
+		{name: "unknown kid", auth: a, token: sign(t, jwt.SigningMethodEdDSA, private, valid(), "k2"), wantErr: true},
+		{name: "alg none", auth: a, token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid(), ""), wantErr: true},
+		{name: "hmac signed with the public key", auth: publicOnly, token: sign(t, jwt.SigningMethodHS256, []byte(public), valid(), "k1"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := tt.auth.Authenticate(bearer(tt.token))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if principal.ID != "alice" || principal.Method != domain.AuthMethodJWT || !slices.Equal(principal.Roles, []string{domain.RoleUploader}) {
+				t.Errorf("principal = %+v", principal)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	authenticator, err := New(Options{
+		APIKeys: []APIKey{{Name: "ci", Key: "ci-key-0123456789", Roles: []string{domain.RoleViewer}}},
+		JWT:     JWTOptions{HMACSecret: testHMACSecret},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := sign(t, jwt.SigningMethodHS256, []byte(testHMACSecret), jwt.MapClaims{
+		"sub": "alice",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}, "")
+
+	tests := []struct {
+		name    string
+		apiKey  string
+		token   string
+		wantID  string
+		wantErr error
+	}{
+		{name: "no credentials", wantErr: ErrNoCredentials},
+		{name: "api key", apiKey: "ci-key-0123456789", wantID: "ci"},
+		{name: "jwt", token: token, wantID: "alice"},
+		{name: "unknown api key", apiKey: "unknown", wantErr: ErrInvalidCredentials},
+		// 不正なAPIキーは、有効なJWTがあっても他の認証方法で救済しない
+		{name: "unknown api key with a valid jwt", apiKey: "unknown", token: token, wantErr: ErrInvalidCredentials},
+		{name: "invalid jwt", token: "not-a-jwt", wantErr: ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/models", nil)
+			if tt.apiKey != "" {
+				r.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			principal, err := authenticator.Authenticate(r)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if principal.ID != tt.wantID {
+				t.Errorf("principal.ID = %q, want %q", principal.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestNewWithoutAuthentication(t *testing.T) {
+	authenticator, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authenticator != nil {
+		t.Errorf("New returned %T, want nil when no authentication is configured", authenticator)
+	}
+}
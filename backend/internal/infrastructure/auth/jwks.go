@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 未知のkidを受け取ったときにJWKSファイルを読み直す最短の間隔
+const jwksReloadInterval = time.Minute
+
+// jwksFile はJWKSファイルの公開鍵。鍵の入れ替えに追従するため、未知のkidを受け取ると読み直します
+type jwksFile struct {
+	path string
+
+	mu         sync.Mutex
+	keys       []jwk
+	reloadedAt time.Time
+}
+
+// jwk は公開鍵とその属性
+type jwk struct {
+	kid string
+	alg string
+	key any
+}
+
+// loadJWKSFile はJWKSファイルを読み込みます
+func loadJWKSFile(path string) (*jwksFile, error) {
+	keys, err := readJWKS(path)
+	if err != nil {
+		return nil, err
+	}
+	return &jwksFile{path: path, keys: keys, reloadedAt: time.Now()}, nil
+}
+
+// key はkidと署名アルゴリズムに対応する公開鍵を返します
+// kidがないトークンは、鍵が1つだけの場合のみその鍵で検証します
+func (f *jwksFile) key(kid string, alg string) (any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if key, ok := findJWK(f.keys, kid, alg); ok {
+		return key, nil
+	}
+	if kid != "" && time.Since(f.reloadedAt) >= jwksReloadInterval {
+		f.reloadedAt = time.Now()
+		keys, err := readJWKS(f.path)
+		if err != nil {
+			return nil, err
+		}
+		f.keys = keys
+		if key, ok := findJWK(f.keys, kid, alg); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no key found for kid %q", kid)
+}
+
+func findJWK(keys []jwk, kid string, alg string) (any, bool) {
+	if kid == "" {
+		if len(keys) == 1 && compatible(keys[0], alg) {
+			return keys[0].key, true
+		}
+		return nil, false
+	}
+	for _, k := range keys {
+		if k.kid == kid && compatible(k, alg) {
+			return k.key, true
+		}
+	}
+	return nil, false
+}
+
+// compatible は鍵の種類と署名アルゴリズムが合っているか判定します
+func compatible(k jwk, alg string) bool {
+	if k.alg != "" && k.alg != alg {
+		return false
+	}
+	switch k.key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		return strings.HasPrefix(alg, "ES")
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	default:
+		return false
+	}
+}
+
+// readJWKS はJWKSファイルの署名用の公開鍵（RSA、EC、Ed25519）を読み込みます
+func readJWKS(path string) ([]jwk, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file: %w", err)
+	}
+
+	var keys []jwk
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var key any
+		switch k.Kty {
+		case "RSA":
+			key, err = rsaKey(k.N, k.E)
+		case "EC":
+			key, err = ecKey(k.Crv, k.X, k.Y)
+		case "OKP":
+			key, err = ed25519Key(k.Crv, k.X)
+		default:
+			err = fmt.Errorf("unsupported kty %q", k.Kty)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %d (kid %q): %w", i, k.Kid, err)
+		}
+		keys = append(keys, jwk{kid: k.Kid, alg: k.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks file has no signing keys")
+	}
+	return keys, nil
+}
+
+func rsaKey(n string, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("invalid n: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("invalid e: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(eBytes)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid e")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nBytes), E: int(exponent.Int64())}, nil
+}
+
+func ecKey(crv string, x string, y string) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported crv %q", crv)
+	}
+	xBytes, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x: %w", err)
+	}
+	yBytes, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y: %w", err)
+	}
+	key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(xBytes), Y: new(big.Int).SetBytes(yBytes)}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("point is not on the curve")
+	}
+	return key, nil
+}
+
+func ed25519Key(crv string, x string) (ed25519.PublicKey, error) {
+	if crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported crv %q", crv)
+	}
+	key, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid x")
+	}
+	return ed25519.PublicKey(key), nil
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 共有鍵で検証する署名アルゴリズム
+var hmacMethods = []string{"HS256", "HS384", "HS512"}
+
+// 公開鍵で検証する署名アルゴリズム
+var publicKeyMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// JWTAuthenticator はAuthorization: BearerのJWTを検証するAuthenticator
+type JWTAuthenticator struct {
+	hmacSecret  []byte
+	jwks        *jwksFile
+	parser      *jwt.Parser
+	rolesClaim  string
+	groupsClaim string
+}
+
+// NewJWTAuthenticator はJWTのAuthenticatorを作成します。JWKSファイルは作成時に読み込みます
+func NewJWTAuthenticator(opts JWTOptions) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{rolesClaim: opts.RolesClaim, groupsClaim: opts.GroupsClaim}
+	var methods []string
+	if opts.HMACSecret != "" {
+		a.hmacSecret = []byte(opts.HMACSecret)
+		methods = append(methods, hmacMethods...)
+	}
+	if opts.JWKSFile != "" {
+		jwks, err := loadJWKSFile(opts.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwks = jwks
+		methods = append(methods, publicKeyMethods...)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	a.parser = jwt.NewParser(parserOpts...)
+	return a, nil
+}
+
+// Authenticate はAuthorizationヘッダーのJWTの署名と有効期限などを検証します
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrNoCredentials
+	}
+
+	claims := &claims{}
+	if _, err := a.parser.ParseWithClaims(strings.TrimSpace(token), claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no sub claim", ErrInvalidCredentials)
+	}
+	return &domain.Principal{
+		ID:     claims.Subject,
+		Method: domain.AuthMethodJWT,
+		Issuer: claims.Issuer,
+		Roles:  claims.strings(a.rolesClaim),
+		Groups: claims.strings(a.groupsClaim),
+	}, nil
+}
+
+// claims は登録済みのクレームと、ロールなどを読み取るためのすべてのクレーム
+type claims struct {
+	jwt.RegisteredClaims
+	all map[string]any
+}
+
+func (c *claims) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.RegisteredClaims); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.all)
+}
+
+// strings は文字列の配列またはスペース区切りの文字列のクレームを返します
+func (c *claims) strings(name string) []string {
+	if name == "" {
+		return nil
+	}
+	switch v := c.all[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// key は署名アルゴリズムとkidに対応する検証用の鍵を返します
+func (a *JWTAuthenticator) key(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if a.hmacSecret == nil {
+			return nil, errors.New("hmac signed tokens are not accepted")
+		}
+		return a.hmacSecret, nil
+	}
+	if a.jwks == nil {
+		return nil, errors.New("public key signed tokens are not accepted")
+	}
+	kid, _ := token.Header["kid"].(string)
+	return a.jwks.key(kid, token.Method.Alg())
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 共有リンクのViewer用トークンの発行者（iss）。他のJWTと区別するために使います
+const shareTokenIssuer = "aps-viewer-share"
+
+// ShareTokens は共有リンクと引き換えに発行するViewer用トークン（HS256のJWT）の発行と検証
+// 検証したトークンは共有したモデルのURNのみを参照できる呼び出し元として扱います
+type ShareTokens struct {
+	secret []byte
+	shares domain.ShareRepository
+	parser *jwt.Parser
+	now    func() time.Time
+}
+
+// NewShareTokens は新しいShareTokensを作成します
+// secretが空の場合は起動ごとに署名鍵を生成するため、再起動やレプリカ間で発行済みのトークンが無効になります
+func NewShareTokens(secret string, shares domain.ShareRepository) (*ShareTokens, error) {
+	key := []byte(secret)
+	if secret == "" {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate share token secret: %w", err)
+		}
+		slog.Warn("share.tokenSecret is not set; share viewer tokens are invalidated on restart")
+	}
+	return &ShareTokens{
+		secret: key,
+		shares: shares,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{"HS256"}),
+			jwt.WithIssuer(shareTokenIssuer),
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+		),
+		now: time.Now,
+	}, nil
+}
+
+// インターフェースの実装を確認
+var _ domain.ShareTokenIssuer = (*ShareTokens)(nil)
+
+// shareClaims はViewer用トークンのクレーム。subに共有リンクのIDを入れます
+type shareClaims struct {
+	jwt.RegisteredClaims
+	URN string `json:"urn"`
+}
+
+// IssueViewerToken は共有リンクのViewer用トークンを発行します
+func (t *ShareTokens) IssueViewerToken(claims domain.ShareViewerClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, shareClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    shareTokenIssuer,
+			Subject:   claims.ShareID,
+			IssuedAt:  jwt.NewNumericDate(t.now()),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+		URN: claims.URN,
+	})
+	signed, err := token.SignedString(t.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign share viewer token: %w", err)
+	}
+	return signed, nil
+}
+
+// ParseViewerToken はViewer用トークンの署名・発行者・有効期限を検証し、内容を返します
+// 共有リンクが有効かどうかは確認しません
+func (t *ShareTokens) ParseViewerToken(token string) (*domain.ShareViewerClaims, error) {
+	claims := &shareClaims{}
+	if _, err := t.parser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return t.secret, nil }); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" || claims.URN == "" {
+		return nil, errors.New("share token has no sub or urn claim")
+	}
+	return &domain.ShareViewerClaims{
+		ShareID:   claims.Subject,
+		URN:       claims.URN,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// Authenticate はAuthorizationヘッダーのViewer用トークンを検証します
+// 他の発行者のトークンはErrNoCredentialsを返し、後に続く認証方法に任せます
+// 共有リンクが取り消されたか有効期限が切れた場合は、トークンの有効期限内でも拒否します
+func (t *ShareTokens) Authenticate(r *http.Request) (*domain.Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrNoCredentials
+	}
+	token = strings.TrimSpace(token)
+
+	unverified := &shareClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, unverified); err != nil || unverified.Issuer != shareTokenIssuer {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := t.ParseViewerToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	share, err := t.shares.GetShare(r.Context(), claims.ShareID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	if err := share.Active(t.now()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	return &domain.Principal{
+		ID:     domain.ShareTarget(share.ID),
+		Method: domain.AuthMethodShare,
+		Roles:  []string{domain.RoleViewer},
+		URN:    claims.URN,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package derivative_cache
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// CheckWritable はキャッシュの作業ディレクトリに書き込めるか確認します
+func (c *DerivativeCache) CheckWritable() error {
+	f, err := os.CreateTemp(filepath.Join(c.dir, "tmp"), ".probe-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write([]byte("ok")); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,124 @@
+package derivative_cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// キャッシュ上限の既定値（5GiB）
+const defaultMaxBytes int64 = 5 << 30
+
+// DerivativeCache は派生ファイルのコンテンツアドレス型ディスクキャッシュ
+// 本体はSHA-256ハッシュ名のblobとして保存し、同じ内容のレスポンスは1つのblobを共有します
+// 合計サイズが上限を超えると、最も長く使われていないエントリから削除します
+type DerivativeCache struct {
+	dir      string
+	maxBytes int64
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // 先頭が最も最近使われたエントリ
+	blobRefs   map[string]int
+	totalBytes int64
+	manifests  map[string]string
+}
+
+// NewDerivativeCache は新しいDerivativeCacheを作成し、ディスク上のインデックスを読み込みます
+func NewDerivativeCache(dir string, maxBytes int64) (*DerivativeCache, error) {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "aps-derivative-cache")
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	for _, sub := range []string{"blobs", "index", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create cache directory: %w", err)
+		}
+	}
+
+	c := &DerivativeCache{
+		dir:       dir,
+		maxBytes:  maxBytes,
+		entries:   map[string]*list.Element{},
+		lru:       list.New(),
+		blobRefs:  map[string]int{},
+		manifests: map[string]string{},
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// load はディスク上のインデックスとマニフェストのバージョンを読み込みます
+// 最終アクセス日時は保存していないため、起動直後は保存日時の順にLRUを組み立てます
+func (c *DerivativeCache) load() error {
+	if data, err := os.ReadFile(filepath.Join(c.dir, "manifests.json")); err == nil {
+		if err := json.Unmarshal(data, &c.manifests); err != nil {
+			return fmt.Errorf("failed to read manifest versions: %w", err)
+		}
+	}
+
+	files, err := os.ReadDir(filepath.Join(c.dir, "index"))
+	if err != nil {
+		return fmt.Errorf("failed to read cache index: %w", err)
+	}
+
+	var loaded []*domain.DerivativeCacheEntry
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(c.dir, "index", f.Name()))
+		if err != nil {
+			continue
+		}
+		var entry domain.DerivativeCacheEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		if _, err := os.Stat(c.blobPath(entry.Blob)); err != nil {
+			os.Remove(filepath.Join(c.dir, "index", f.Name()))
+			continue
+		}
+		loaded = append(loaded, &entry)
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].StoredAt.After(loaded[j].StoredAt)
+	})
+	for _, entry := range loaded {
+		c.entries[entry.Key] = c.lru.PushBack(entry)
+		c.addBlobRef(entry)
+	}
+
+	// 作業途中のまま残った一時ファイルを掃除する
+	if tmps, err := os.ReadDir(filepath.Join(c.dir, "tmp")); err == nil {
+		for _, f := range tmps {
+			os.Remove(filepath.Join(c.dir, "tmp", f.Name()))
+		}
+	}
+
+	c.evictLocked()
+	return nil
+}
+
+// blobPath はblobの保存先パスを返します
+func (c *DerivativeCache) blobPath(blob string) string {
+	return filepath.Join(c.dir, "blobs", blob[:2], blob)
+}
+
+// indexPath はエントリのインデックスファイルのパスを返します
+func (c *DerivativeCache) indexPath(key string) string {
+	return filepath.Join(c.dir, "index", key+".json")
+}
+
+// インターフェースの実装を確認
+var _ domain.DerivativeCacheRepository = (*DerivativeCache)(nil)
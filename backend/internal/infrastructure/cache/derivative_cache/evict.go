@@ -0,0 +1,42 @@
+package derivative_cache
+
+import (
+	"container/list"
+	"os"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// addBlobRef はblobの参照数を増やし、初めて参照されたblobのサイズを合計に加えます
+func (c *DerivativeCache) addBlobRef(entry *domain.DerivativeCacheEntry) {
+	c.blobRefs[entry.Blob]++
+	if c.blobRefs[entry.Blob] == 1 {
+		c.totalBytes += entry.Size
+	}
+}
+
+// removeLocked はエントリとそのインデックスファイルを削除します
+func (c *DerivativeCache) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*domain.DerivativeCacheEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, entry.Key)
+	os.Remove(c.indexPath(entry.Key))
+	c.releaseBlobRef(entry)
+}
+
+// releaseBlobRef はblobの参照数を減らし、参照されなくなったblobを削除します
+func (c *DerivativeCache) releaseBlobRef(entry *domain.DerivativeCacheEntry) {
+	c.blobRefs[entry.Blob]--
+	if c.blobRefs[entry.Blob] <= 0 {
+		delete(c.blobRefs, entry.Blob)
+		os.Remove(c.blobPath(entry.Blob))
+		c.totalBytes -= entry.Size
+	}
+}
+
+// evictLocked は合計サイズが上限以下になるまで最も古いエントリから削除します
+func (c *DerivativeCache) evictLocked() {
+	for c.totalBytes > c.maxBytes && c.lru.Len() > 0 {
+		c.removeLocked(c.lru.Back())
+	}
+}
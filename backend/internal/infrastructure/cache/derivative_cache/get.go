@@ -0,0 +1,38 @@
+package derivative_cache
+
+import (
+	"io"
+	"os"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Get はキャッシュからエントリを取得します
+// マニフェストが更新された後のエントリは無効として削除し、ミスとして扱います
+func (c *DerivativeCache) Get(key string) (*domain.DerivativeCacheEntry, io.ReadSeekCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, nil, nil
+	}
+
+	entry := elem.Value.(*domain.DerivativeCacheEntry)
+	if entry.ManifestVersion != c.manifests[entry.URN] {
+		c.removeLocked(elem)
+		return nil, nil, nil
+	}
+
+	f, err := os.Open(c.blobPath(entry.Blob))
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.removeLocked(elem)
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry, f, nil
+}
@@ -0,0 +1,44 @@
+package derivative_cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ManifestVersion はURNについて最後に確認したマニフェストのバージョンを返します
+func (c *DerivativeCache) ManifestVersion(urn string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.manifests[urn]
+}
+
+// SetManifestVersion はマニフェストのバージョンを更新します
+// バージョンが変わった場合は、そのURNの古いエントリをすべて無効化します
+func (c *DerivativeCache) SetManifestVersion(urn string, version string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current, ok := c.manifests[urn]; ok && current == version {
+		return nil
+	}
+	c.manifests[urn] = version
+
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*domain.DerivativeCacheEntry)
+		if entry.URN == urn && entry.ManifestVersion != version {
+			c.removeLocked(elem)
+		}
+		elem = next
+	}
+
+	data, err := json.Marshal(c.manifests)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, "manifests.json"), data, 0o644)
+}
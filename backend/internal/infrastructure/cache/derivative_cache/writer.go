@@ -0,0 +1,123 @@
+package derivative_cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// cacheWriter はレスポンスを一時ファイルに書き込みながらハッシュを計算します
+type cacheWriter struct {
+	cache *DerivativeCache
+	entry *domain.DerivativeCacheEntry
+	file  *os.File
+	hash  hash.Hash
+	size  int64
+	done  bool
+}
+
+// NewWriter はキャッシュへの書き込みを開始します
+func (c *DerivativeCache) NewWriter(entry *domain.DerivativeCacheEntry) (domain.DerivativeCacheWriter, error) {
+	f, err := os.CreateTemp(filepath.Join(c.dir, "tmp"), "blob-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	return &cacheWriter{
+		cache: c,
+		entry: entry,
+		file:  f,
+		hash:  sha256.New(),
+	}, nil
+}
+
+func (w *cacheWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.hash.Write(p[:n])
+	w.size += int64(n)
+	return n, err
+}
+
+// Commit は書き込んだ内容をblobとして確定し、インデックスに登録します
+func (w *cacheWriter) Commit() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+
+	tmpPath := w.file.Name()
+	if err := w.file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	c := w.cache
+	blob := hex.EncodeToString(w.hash.Sum(nil))
+	blobPath := c.blobPath(blob)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 同じ内容のblobが既にあれば再利用する
+	if _, err := os.Stat(blobPath); err == nil {
+		os.Remove(tmpPath)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(blobPath), 0o755); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+		if err := os.Rename(tmpPath, blobPath); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+
+	w.entry.Blob = blob
+	w.entry.Size = w.size
+	w.entry.StoredAt = time.Now()
+
+	// 書き込み中にマニフェストが更新された場合は登録しない
+	if w.entry.ManifestVersion != c.manifests[w.entry.URN] {
+		if c.blobRefs[blob] == 0 {
+			os.Remove(blobPath)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(w.entry)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.indexPath(w.entry.Key), data, 0o644); err != nil {
+		return err
+	}
+
+	// 同じキーの古いエントリを置き換える。blobを共有している場合に消さないよう、先に新しい参照を数える
+	old, replaced := c.entries[w.entry.Key]
+	c.entries[w.entry.Key] = c.lru.PushFront(w.entry)
+	c.addBlobRef(w.entry)
+	if replaced {
+		c.lru.Remove(old)
+		c.releaseBlobRef(old.Value.(*domain.DerivativeCacheEntry))
+	}
+	c.evictLocked()
+
+	return nil
+}
+
+// Abort は書き込み途中の内容を破棄します
+func (w *cacheWriter) Abort() {
+	if w.done {
+		return
+	}
+	w.done = true
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
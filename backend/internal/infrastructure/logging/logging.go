@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Options はログの設定
+type Options struct {
+	// 出力するレベル（debug, info, warn, error）
+	Level string `yaml:"level"`
+	// 出力形式（text, json）
+	Format string `yaml:"format"`
+}
+
+// DefaultOptions は既定のログの設定を返します
+func DefaultOptions() Options {
+	return Options{
+		Level:  "info",
+		Format: "text",
+	}
+}
+
+// ParseLevel はログのレベルの名前をslog.Levelに変換します
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return 0, fmt.Errorf("unknown log level: %q", name)
+	}
+	return level, nil
+}
+
+// Setup はwへ出力するロガーを作成し、slogとlogパッケージの既定のロガーにします
+// ログにはコンテキストのリクエストIDとトレースIDを付け、トークンや署名付きURLの署名は伏せ字にします
+func Setup(opts Options, w io.Writer) (*slog.Logger, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+
+	var handler slog.Handler
+	switch opts.Format {
+	case "", "text":
+		handler = slog.NewTextHandler(w, handlerOpts)
+	case "json":
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unknown log format: %q", opts.Format)
+	}
+
+	logger := slog.New(&contextHandler{Handler: handler})
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+type requestIDKey struct{}
+
+// WithRequestID はコンテキストにリクエストIDを設定します
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID はコンテキストのリクエストIDを返します。設定されていない場合は空文字列を返します
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler はコンテキストのリクエストID・呼び出し元・トレースID・スパンIDをログに付けるslog.Handler
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		record.AddAttrs(slog.String("principal", principal.ID))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", span.TraceID().String()),
+			slog.String("span_id", span.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
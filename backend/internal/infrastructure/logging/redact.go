@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+)
+
+// 伏せ字にした値
+const redacted = "REDACTED"
+
+var (
+	// 署名付きURL（S3・CloudFront）の署名や認証情報のクエリパラメータ
+	signedParamPattern = regexp.MustCompile(`(?i)([?&](?:X-Amz-Signature|X-Amz-Credential|X-Amz-Security-Token|Signature|Policy|Key-Pair-Id)=)[^&"\s]+`)
+	// Authorizationヘッダーなどのトークン
+	bearerPattern = regexp.MustCompile(`(?i)(Bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	// JSONやフォームのトークンやシークレットの値
+	secretPattern = regexp.MustCompile(`(?i)("?(?:access_token|refresh_token|client_secret)"?\s*[:=]\s*"?)[^"&\s,}]+`)
+)
+
+// Redact は文字列中のトークンと署名付きURLの署名を伏せ字にします
+// エラーメッセージには送信先の署名付きURLが含まれることがあるため、ログに出す前に必ず通します
+func Redact(s string) string {
+	s = signedParamPattern.ReplaceAllString(s, "${1}"+redacted)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+	return secretPattern.ReplaceAllString(s, "${1}"+redacted)
+}
+
+// redactAttr は文字列とエラーの属性を伏せ字にします
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(Redact(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			attr.Value = slog.StringValue(Redact(err.Error()))
+		}
+	}
+	return attr
+}
@@ -0,0 +1,84 @@
+package mesh
+
+import (
+	"math"
+)
+
+// Bounds は軸に沿ったバウンディングボックス
+type Bounds struct {
+	Min    [3]float64 `json:"min"`
+	Max    [3]float64 `json:"max"`
+	Center [3]float64 `json:"center"`
+	Size   [3]float64 `json:"size"`
+}
+
+// Bounds はプリミティブのバウンディングボックスを返します。頂点がない場合はfalseを返します
+func (p *Primitive) Bounds() (Bounds, bool) {
+	b := newBounds()
+	for i := 0; i < p.VertexCount(); i++ {
+		x, y, z := p.position(uint32(i))
+		b.extend(x, y, z)
+	}
+	return b.finish()
+}
+
+// Bounds はシーン全体のバウンディングボックスを返します。頂点がない場合はfalseを返します
+func (s *Scene) Bounds() (Bounds, bool) {
+	b := newBounds()
+	for _, node := range s.Nodes {
+		for _, p := range node.Primitives {
+			for i := 0; i < p.VertexCount(); i++ {
+				x, y, z := p.position(uint32(i))
+				b.extend(x, y, z)
+			}
+		}
+	}
+	return b.finish()
+}
+
+// TriangleCount はシーン全体の三角形数を返します
+func (s *Scene) TriangleCount() int {
+	count := 0
+	for _, node := range s.Nodes {
+		for _, p := range node.Primitives {
+			count += p.TriangleCount()
+		}
+	}
+	return count
+}
+
+// VertexCount はシーン全体の頂点数を返します
+func (s *Scene) VertexCount() int {
+	count := 0
+	for _, node := range s.Nodes {
+		for _, p := range node.Primitives {
+			count += p.VertexCount()
+		}
+	}
+	return count
+}
+
+func newBounds() Bounds {
+	return Bounds{
+		Min: [3]float64{math.Inf(1), math.Inf(1), math.Inf(1)},
+		Max: [3]float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)},
+	}
+}
+
+func (b *Bounds) extend(x, y, z float64) {
+	for i, v := range [3]float64{x, y, z} {
+		b.Min[i] = math.Min(b.Min[i], v)
+		b.Max[i] = math.Max(b.Max[i], v)
+	}
+}
+
+func (b Bounds) finish() (Bounds, bool) {
+	if math.IsInf(b.Min[0], 1) {
+		return Bounds{}, false
+	}
+	for i := 0; i < 3; i++ {
+		b.Center[i] = (b.Min[i] + b.Max[i]) / 2
+		b.Size[i] = b.Max[i] - b.Min[i]
+	}
+	return b, true
+}
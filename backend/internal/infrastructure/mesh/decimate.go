@@ -0,0 +1,86 @@
+package mesh
+
+import (
+	"math"
+)
+
+// 頂点クラスタリングで試す格子の最大分割数
+const maxDecimateResolution = 1 << 16
+
+// clusterResult は頂点クラスタリングの結果
+type clusterResult struct {
+	positions []float32
+	indices   []uint32
+}
+
+// Decimate は頂点クラスタリングで三角形数をtarget以下に減らします
+// 格子の分割数を二分探索し、target以下になる中で最も細かい格子を採用します
+func (p *Primitive) Decimate(target int) {
+	if target <= 0 || p.TriangleCount() <= target {
+		return
+	}
+
+	bounds, ok := p.Bounds()
+	if !ok {
+		return
+	}
+	extent := max(bounds.Size[0], bounds.Size[1], bounds.Size[2])
+	if extent == 0 {
+		return
+	}
+
+	best := p.cluster(bounds, extent, 1)
+	lo, hi := 1, maxDecimateResolution
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		result := p.cluster(bounds, extent, mid)
+		if len(result.indices)/3 <= target {
+			best = result
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	p.Positions = best.positions
+	p.Indices = best.indices
+	p.Normals = nil
+}
+
+// cluster は格子の各セルに含まれる頂点を平均位置の1頂点にまとめます
+func (p *Primitive) cluster(bounds Bounds, extent float64, resolution int) clusterResult {
+	cellSize := extent / float64(resolution)
+	remap := make([]uint32, p.VertexCount())
+	cells := map[gridCell]uint32{}
+	var sums [][4]float64
+
+	for i := 0; i < p.VertexCount(); i++ {
+		x, y, z := p.position(uint32(i))
+		cell := gridCell{
+			int64(math.Floor((x - bounds.Min[0]) / cellSize)),
+			int64(math.Floor((y - bounds.Min[1]) / cellSize)),
+			int64(math.Floor((z - bounds.Min[2]) / cellSize)),
+		}
+		idx, ok := cells[cell]
+		if !ok {
+			idx = uint32(len(sums))
+			cells[cell] = idx
+			sums = append(sums, [4]float64{})
+		}
+		sums[idx][0] += x
+		sums[idx][1] += y
+		sums[idx][2] += z
+		sums[idx][3]++
+		remap[i] = idx
+	}
+
+	positions := make([]float32, 0, len(sums)*3)
+	for _, s := range sums {
+		positions = append(positions, float32(s[0]/s[3]), float32(s[1]/s[3]), float32(s[2]/s[3]))
+	}
+
+	return clusterResult{
+		positions: positions,
+		indices:   remapTriangles(p.Indices, remap, true),
+	}
+}
@@ -0,0 +1,283 @@
+package mesh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	glbMagic     = 0x46546C67 // "glTF"
+	glbVersion   = 2
+	chunkJSON    = 0x4E4F534A // "JSON"
+	chunkBIN     = 0x004E4942 // "BIN\0"
+	glbGenerator = "nextgo-aps-viewer"
+
+	componentFloat  = 5126
+	componentUint32 = 5125
+	targetArray     = 34962
+	targetElement   = 34963
+)
+
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Materials   []gltfMaterial   `json:"materials"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Nodes  []int          `json:"nodes"`
+	Extras map[string]any `json:"extras,omitempty"`
+}
+
+type gltfNode struct {
+	Name   string         `json:"name,omitempty"`
+	Mesh   int            `json:"mesh"`
+	Extras map[string]any `json:"extras,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name,omitempty"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    int            `json:"indices"`
+	Material   int            `json:"material"`
+}
+
+type gltfMaterial struct {
+	Name                 string  `json:"name,omitempty"`
+	PBRMetallicRoughness gltfPBR `json:"pbrMetallicRoughness"`
+	AlphaMode            string  `json:"alphaMode,omitempty"`
+	DoubleSided          bool    `json:"doubleSided"`
+}
+
+type gltfPBR struct {
+	BaseColorFactor [4]float32 `json:"baseColorFactor"`
+	MetallicFactor  float32    `json:"metallicFactor"`
+	RoughnessFactor float32    `json:"roughnessFactor"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float32 `json:"min,omitempty"`
+	Max           []float32 `json:"max,omitempty"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target"`
+}
+
+type gltfBuffer struct {
+	ByteLength int `json:"byteLength"`
+}
+
+// glbEncoder はバイナリチャンクを組み立てながらglTFのJSONを作成します
+type glbEncoder struct {
+	doc gltfDocument
+	bin bytes.Buffer
+}
+
+// EncodeGLB はSceneをバイナリglTF（GLB）として書き出します
+// dbIdを持つノードはnode.extras.dbIdに、dbIdからノード番号への対応はscene.extras.dbIdsに記録します
+// 座標系は変換せず、入力（Viewerと同じ座標系）のまま書き出します
+func EncodeGLB(w io.Writer, scene *Scene, extras map[string]any) error {
+	e := &glbEncoder{
+		doc: gltfDocument{
+			Asset:  gltfAsset{Version: "2.0", Generator: glbGenerator},
+			Scenes: []gltfScene{{Nodes: []int{}}},
+		},
+	}
+
+	for _, m := range scene.Materials {
+		material := gltfMaterial{
+			Name: m.Name,
+			PBRMetallicRoughness: gltfPBR{
+				BaseColorFactor: m.Color,
+				MetallicFactor:  0,
+				RoughnessFactor: 0.9,
+			},
+			DoubleSided: true,
+		}
+		if m.Color[3] < 1 {
+			material.AlphaMode = "BLEND"
+		}
+		e.doc.Materials = append(e.doc.Materials, material)
+	}
+	if len(e.doc.Materials) == 0 {
+		return fmt.Errorf("scene has no materials")
+	}
+
+	dbIDs := map[string]int{}
+	for _, node := range scene.Nodes {
+		mesh := gltfMesh{Name: node.Name}
+		for _, p := range node.Primitives {
+			if p.TriangleCount() == 0 {
+				continue
+			}
+			if p.Material < 0 || p.Material >= len(scene.Materials) {
+				return fmt.Errorf("primitive references unknown material %d", p.Material)
+			}
+			prim := gltfPrimitive{
+				Attributes: map[string]int{"POSITION": e.addVec3(p.Positions, true)},
+				Indices:    e.addIndices(p.Indices),
+				Material:   p.Material,
+			}
+			if len(p.Normals) == len(p.Positions) {
+				prim.Attributes["NORMAL"] = e.addVec3(p.Normals, false)
+			}
+			mesh.Primitives = append(mesh.Primitives, prim)
+		}
+		if len(mesh.Primitives) == 0 {
+			continue
+		}
+
+		e.doc.Meshes = append(e.doc.Meshes, mesh)
+		n := gltfNode{Name: node.Name, Mesh: len(e.doc.Meshes) - 1}
+		nodeIndex := len(e.doc.Nodes)
+		if len(node.Extras) > 0 {
+			n.Extras = map[string]any{}
+			for k, v := range node.Extras {
+				n.Extras[k] = v
+			}
+		}
+		if node.DbID != nil {
+			if n.Extras == nil {
+				n.Extras = map[string]any{}
+			}
+			n.Extras["dbId"] = *node.DbID
+			dbIDs[fmt.Sprint(*node.DbID)] = nodeIndex
+		}
+		e.doc.Nodes = append(e.doc.Nodes, n)
+		e.doc.Scenes[0].Nodes = append(e.doc.Scenes[0].Nodes, nodeIndex)
+	}
+	if len(e.doc.Nodes) == 0 {
+		return fmt.Errorf("scene has no geometry")
+	}
+
+	sceneExtras := map[string]any{}
+	for k, v := range extras {
+		sceneExtras[k] = v
+	}
+	if len(dbIDs) > 0 {
+		sceneExtras["dbIds"] = dbIDs
+	}
+	if len(sceneExtras) > 0 {
+		e.doc.Scenes[0].Extras = sceneExtras
+	}
+
+	e.doc.Buffers = []gltfBuffer{{ByteLength: e.bin.Len()}}
+	return e.write(w)
+}
+
+// addVec3 はfloat32のvec3配列をバイナリチャンクに追加し、アクセサ番号を返します
+func (e *glbEncoder) addVec3(values []float32, withBounds bool) int {
+	view := e.addBufferView(len(values)*4, targetArray)
+	for _, v := range values {
+		binary.Write(&e.bin, binary.LittleEndian, v)
+	}
+
+	accessor := gltfAccessor{
+		BufferView:    view,
+		ComponentType: componentFloat,
+		Count:         len(values) / 3,
+		Type:          "VEC3",
+	}
+	// POSITIONのアクセサにはmin/maxが必須
+	if withBounds {
+		lo := []float32{math.MaxFloat32, math.MaxFloat32, math.MaxFloat32}
+		hi := []float32{-math.MaxFloat32, -math.MaxFloat32, -math.MaxFloat32}
+		for i, v := range values {
+			lo[i%3] = min(lo[i%3], v)
+			hi[i%3] = max(hi[i%3], v)
+		}
+		accessor.Min, accessor.Max = lo, hi
+	}
+
+	e.doc.Accessors = append(e.doc.Accessors, accessor)
+	return len(e.doc.Accessors) - 1
+}
+
+// addIndices はインデックス配列をバイナリチャンクに追加し、アクセサ番号を返します
+func (e *glbEncoder) addIndices(indices []uint32) int {
+	view := e.addBufferView(len(indices)*4, targetElement)
+	for _, v := range indices {
+		binary.Write(&e.bin, binary.LittleEndian, v)
+	}
+
+	e.doc.Accessors = append(e.doc.Accessors, gltfAccessor{
+		BufferView:    view,
+		ComponentType: componentUint32,
+		Count:         len(indices),
+		Type:          "SCALAR",
+	})
+	return len(e.doc.Accessors) - 1
+}
+
+// addBufferView は4バイト境界に揃えたバッファビューを追加します
+func (e *glbEncoder) addBufferView(byteLength int, target int) int {
+	for e.bin.Len()%4 != 0 {
+		e.bin.WriteByte(0)
+	}
+	e.doc.BufferViews = append(e.doc.BufferViews, gltfBufferView{
+		Buffer:     0,
+		ByteOffset: e.bin.Len(),
+		ByteLength: byteLength,
+		Target:     target,
+	})
+	return len(e.doc.BufferViews) - 1
+}
+
+// write はGLBのヘッダー、JSONチャンク、バイナリチャンクを書き出します
+func (e *glbEncoder) write(w io.Writer) error {
+	jsonChunk, err := json.Marshal(e.doc)
+	if err != nil {
+		return err
+	}
+	for len(jsonChunk)%4 != 0 {
+		jsonChunk = append(jsonChunk, ' ')
+	}
+	binChunk := e.bin.Bytes()
+	for len(binChunk)%4 != 0 {
+		binChunk = append(binChunk, 0)
+	}
+
+	total := 12 + 8 + len(jsonChunk) + 8 + len(binChunk)
+	header := []uint32{
+		glbMagic, glbVersion, uint32(total),
+		uint32(len(jsonChunk)), chunkJSON,
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if _, err := w.Write(jsonChunk); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, []uint32{uint32(len(binChunk)), chunkBIN}); err != nil {
+		return err
+	}
+	_, err = w.Write(binChunk)
+	return err
+}
@@ -0,0 +1,64 @@
+package mesh
+
+// TriangleRange はマージ後のプリミティブの中で、元の要素が占める三角形の範囲
+type TriangleRange struct {
+	DbID          int `json:"dbId"`
+	FirstTriangle int `json:"firstTriangle"`
+	TriangleCount int `json:"triangleCount"`
+}
+
+// MergeByMaterial は同じマテリアルのプリミティブを1つのノードにまとめ、描画呼び出しの数を減らします
+// 元の要素のdbIdと三角形の範囲はnode.extras.dbIdRangesに残します
+func (s *Scene) MergeByMaterial() {
+	merged := make([]*Primitive, len(s.Materials))
+	ranges := make([][]TriangleRange, len(s.Materials))
+
+	for _, node := range s.Nodes {
+		for _, p := range node.Primitives {
+			if p.TriangleCount() == 0 {
+				continue
+			}
+			target := merged[p.Material]
+			if target == nil {
+				target = &Primitive{Material: p.Material}
+				merged[p.Material] = target
+			}
+
+			if node.DbID != nil {
+				ranges[p.Material] = append(ranges[p.Material], TriangleRange{
+					DbID:          *node.DbID,
+					FirstTriangle: target.TriangleCount(),
+					TriangleCount: p.TriangleCount(),
+				})
+			}
+
+			offset := uint32(target.VertexCount())
+			withNormals := len(p.Normals) == len(p.Positions) && len(target.Normals) == len(target.Positions)
+			target.Positions = append(target.Positions, p.Positions...)
+			if withNormals {
+				target.Normals = append(target.Normals, p.Normals...)
+			} else {
+				target.Normals = nil
+			}
+			for _, idx := range p.Indices {
+				target.Indices = append(target.Indices, idx+offset)
+			}
+		}
+	}
+
+	var nodes []*Node
+	for i, p := range merged {
+		if p == nil {
+			continue
+		}
+		if len(p.Normals) != len(p.Positions) {
+			p.ComputeNormals()
+		}
+		node := &Node{Name: s.Materials[i].Name, Primitives: []*Primitive{p}}
+		if len(ranges[i]) > 0 {
+			node.Extras = map[string]any{"dbIdRanges": ranges[i]}
+		}
+		nodes = append(nodes, node)
+	}
+	s.Nodes = nodes
+}
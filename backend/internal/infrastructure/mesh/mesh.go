@@ -0,0 +1,85 @@
+package mesh
+
+import (
+	"math"
+)
+
+// Scene はGLBに書き出すメッシュの集まり
+type Scene struct {
+	Nodes     []*Node
+	Materials []Material
+}
+
+// Node はシーン内の1要素。ViewerのdbIdが分かる場合はDbIDに保持します
+// ExtrasはglTFのnode.extrasにそのまま書き出します
+type Node struct {
+	Name       string
+	DbID       *int
+	Primitives []*Primitive
+	Extras     map[string]any
+}
+
+// Primitive は1つのマテリアルで描画される三角形の集まり
+// Positions・Normalsはxyzの並び、Indicesは三角形ごとの頂点インデックスです
+type Primitive struct {
+	Material  int
+	Positions []float32
+	Normals   []float32
+	Indices   []uint32
+}
+
+// Material はベースカラー（RGBA、0〜1）を持つマテリアル
+type Material struct {
+	Name  string
+	Color [4]float32
+}
+
+// DefaultMaterial はマテリアル指定がない面に使う既定のマテリアル
+var DefaultMaterial = Material{Name: "default", Color: [4]float32{0.8, 0.8, 0.8, 1}}
+
+// VertexCount は頂点数を返します
+func (p *Primitive) VertexCount() int {
+	return len(p.Positions) / 3
+}
+
+// TriangleCount は三角形数を返します
+func (p *Primitive) TriangleCount() int {
+	return len(p.Indices) / 3
+}
+
+// ComputeNormals は面積で重み付けした頂点法線を計算します
+func (p *Primitive) ComputeNormals() {
+	normals := make([]float64, len(p.Positions))
+	for i := 0; i+2 < len(p.Indices); i += 3 {
+		a, b, c := p.Indices[i], p.Indices[i+1], p.Indices[i+2]
+		ax, ay, az := p.position(a)
+		bx, by, bz := p.position(b)
+		cx, cy, cz := p.position(c)
+		// 外積の大きさは面積の2倍なので、正規化しないまま加算すると面積で重み付けされる
+		ux, uy, uz := bx-ax, by-ay, bz-az
+		vx, vy, vz := cx-ax, cy-ay, cz-az
+		nx, ny, nz := uy*vz-uz*vy, uz*vx-ux*vz, ux*vy-uy*vx
+		for _, idx := range []uint32{a, b, c} {
+			normals[idx*3] += nx
+			normals[idx*3+1] += ny
+			normals[idx*3+2] += nz
+		}
+	}
+
+	p.Normals = make([]float32, len(p.Positions))
+	for i := 0; i+2 < len(normals); i += 3 {
+		length := math.Sqrt(normals[i]*normals[i] + normals[i+1]*normals[i+1] + normals[i+2]*normals[i+2])
+		if length == 0 {
+			p.Normals[i+2] = 1
+			continue
+		}
+		p.Normals[i] = float32(normals[i] / length)
+		p.Normals[i+1] = float32(normals[i+1] / length)
+		p.Normals[i+2] = float32(normals[i+2] / length)
+	}
+}
+
+// position は頂点座標をfloat64で返します
+func (p *Primitive) position(i uint32) (float64, float64, float64) {
+	return float64(p.Positions[i*3]), float64(p.Positions[i*3+1]), float64(p.Positions[i*3+2])
+}
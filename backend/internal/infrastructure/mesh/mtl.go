@@ -0,0 +1,63 @@
+package mesh
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseMTL はMTLを読み込んでマテリアル名ごとのMaterialを返します
+// 使用するのは拡散色（Kd）と不透明度（d、またはTr）のみです
+func ParseMTL(r io.Reader) (map[string]Material, error) {
+	materials := map[string]Material{}
+	var current string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "newmtl":
+			current = strings.Join(fields[1:], " ")
+			materials[current] = Material{Name: current, Color: DefaultMaterial.Color}
+		case "Kd":
+			if current == "" || len(fields) < 4 {
+				continue
+			}
+			m := materials[current]
+			for i := 0; i < 3; i++ {
+				m.Color[i] = parseUnit(fields[i+1], m.Color[i])
+			}
+			materials[current] = m
+		case "d":
+			if current == "" || len(fields) < 2 {
+				continue
+			}
+			m := materials[current]
+			m.Color[3] = parseUnit(fields[1], m.Color[3])
+			materials[current] = m
+		case "Tr":
+			if current == "" || len(fields) < 2 {
+				continue
+			}
+			m := materials[current]
+			m.Color[3] = 1 - parseUnit(fields[1], 1-m.Color[3])
+			materials[current] = m
+		}
+	}
+
+	return materials, scanner.Err()
+}
+
+// parseUnit は0〜1の数値を読み込みます。読めない場合はfallbackを返します
+func parseUnit(s string, fallback float32) float32 {
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return fallback
+	}
+	return float32(min(max(v, 0), 1))
+}
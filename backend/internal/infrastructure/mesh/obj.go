@@ -0,0 +1,228 @@
+package mesh
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// グループ名の末尾に付く [1234] 形式のdbId
+var bracketDbID = regexp.MustCompile(`\[(\d+)\]\s*$`)
+
+// objVertexKey は面が参照する頂点（座標と法線の組）
+type objVertexKey struct {
+	position int
+	normal   int
+}
+
+// objBuilder はOBJを読み込みながらノードとプリミティブを組み立てます
+type objBuilder struct {
+	scene      *Scene
+	materials  map[string]int
+	positions  []float32
+	normals    []float32
+	node       *Node
+	nodes      map[string]*Node
+	material   int
+	primitives map[*Node]map[int]*Primitive
+	vertices   map[*Primitive]map[objVertexKey]uint32
+}
+
+// ParseOBJ はOBJを読み込んでSceneを作成します
+// グループ（g）またはオブジェクト（o）ごとにノードを作り、名前が数値か末尾が [dbId] の場合はViewerのdbIdとして保持します
+// usemtlで参照するマテリアルはParseMTLで読み込んだものを渡します
+func ParseOBJ(r io.Reader, materials map[string]Material) (*Scene, error) {
+	b := &objBuilder{
+		scene:      &Scene{Materials: []Material{DefaultMaterial}},
+		materials:  map[string]int{},
+		nodes:      map[string]*Node{},
+		primitives: map[*Node]map[int]*Primitive{},
+		vertices:   map[*Primitive]map[objVertexKey]uint32{},
+	}
+	// 出力を安定させるためマテリアルは名前順に並べる
+	names := slices.Sorted(maps.Keys(materials))
+	for _, name := range names {
+		b.materials[name] = len(b.scene.Materials)
+		b.scene.Materials = append(b.scene.Materials, materials[name])
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		var err error
+		switch fields[0] {
+		case "v":
+			b.positions, err = appendVec3(b.positions, fields[1:])
+		case "vn":
+			b.normals, err = appendVec3(b.normals, fields[1:])
+		case "g", "o":
+			b.useNode(strings.Join(fields[1:], " "))
+		case "usemtl":
+			idx, ok := b.materials[strings.Join(fields[1:], " ")]
+			if !ok {
+				idx = 0
+			}
+			b.material = idx
+		case "f":
+			err = b.addFace(fields[1:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("obj line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, node := range b.scene.Nodes {
+		for _, p := range node.Primitives {
+			if p.Normals == nil {
+				p.ComputeNormals()
+			}
+		}
+	}
+
+	return b.scene, nil
+}
+
+// useNode は以降の面を追加するノードを切り替えます
+func (b *objBuilder) useNode(name string) {
+	if node, ok := b.nodes[name]; ok {
+		b.node = node
+		return
+	}
+
+	node := &Node{Name: name}
+	if id, ok := parseDbID(name); ok {
+		node.DbID = &id
+	}
+	b.nodes[name] = node
+	b.scene.Nodes = append(b.scene.Nodes, node)
+	b.node = node
+}
+
+// addFace は多角形の面を扇形に三角形分割して追加します
+func (b *objBuilder) addFace(tokens []string) error {
+	if len(tokens) < 3 {
+		return fmt.Errorf("face needs at least 3 vertices")
+	}
+	if b.node == nil {
+		b.useNode("")
+	}
+
+	prims, ok := b.primitives[b.node]
+	if !ok {
+		prims = map[int]*Primitive{}
+		b.primitives[b.node] = prims
+	}
+	prim, ok := prims[b.material]
+	if !ok {
+		prim = &Primitive{Material: b.material}
+		prims[b.material] = prim
+		b.node.Primitives = append(b.node.Primitives, prim)
+		b.vertices[prim] = map[objVertexKey]uint32{}
+	}
+
+	indices := make([]uint32, len(tokens))
+	for i, token := range tokens {
+		key, err := b.parseVertex(token)
+		if err != nil {
+			return err
+		}
+		idx, ok := b.vertices[prim][key]
+		if !ok {
+			idx = uint32(prim.VertexCount())
+			prim.Positions = append(prim.Positions, b.positions[key.position*3:key.position*3+3]...)
+			if key.normal >= 0 {
+				prim.Normals = append(prim.Normals, b.normals[key.normal*3:key.normal*3+3]...)
+			}
+			b.vertices[prim][key] = idx
+		}
+		indices[i] = idx
+	}
+
+	// 法線の有無が混在する場合は頂点数と揃わなくなるため、あとで計算し直す
+	if len(prim.Normals) != 0 && len(prim.Normals) != len(prim.Positions) {
+		prim.Normals = nil
+	}
+
+	for i := 1; i+1 < len(indices); i++ {
+		prim.Indices = append(prim.Indices, indices[0], indices[i], indices[i+1])
+	}
+	return nil
+}
+
+// parseVertex は v, v/vt, v//vn, v/vt/vn 形式の頂点参照を解釈します（負の値は末尾からの相対参照）
+func (b *objBuilder) parseVertex(token string) (objVertexKey, error) {
+	parts := strings.Split(token, "/")
+	position, err := resolveIndex(parts[0], len(b.positions)/3)
+	if err != nil {
+		return objVertexKey{}, err
+	}
+
+	normal := -1
+	if len(parts) == 3 && parts[2] != "" {
+		normal, err = resolveIndex(parts[2], len(b.normals)/3)
+		if err != nil {
+			return objVertexKey{}, err
+		}
+	}
+
+	return objVertexKey{position: position, normal: normal}, nil
+}
+
+// resolveIndex は1始まり・負の相対参照のインデックスを0始まりに変換します
+func resolveIndex(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index %q", s)
+	}
+	if i < 0 {
+		i = count + i
+	} else {
+		i--
+	}
+	if i < 0 || i >= count {
+		return 0, fmt.Errorf("index %q out of range", s)
+	}
+	return i, nil
+}
+
+// appendVec3 は3つの数値を読み込んで追加します
+func appendVec3(dst []float32, fields []string) ([]float32, error) {
+	if len(fields) < 3 {
+		return dst, fmt.Errorf("expected 3 components")
+	}
+	for _, f := range fields[:3] {
+		v, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return dst, fmt.Errorf("invalid number %q", f)
+		}
+		dst = append(dst, float32(v))
+	}
+	return dst, nil
+}
+
+// parseDbID はグループ名からdbIdを取り出します
+func parseDbID(name string) (int, bool) {
+	if id, err := strconv.Atoi(strings.TrimSpace(name)); err == nil {
+		return id, true
+	}
+	if m := bracketDbID.FindStringSubmatch(name); m != nil {
+		id, err := strconv.Atoi(m[1])
+		return id, err == nil
+	}
+	return 0, false
+}
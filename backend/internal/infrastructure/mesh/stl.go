@@ -0,0 +1,100 @@
+package mesh
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EncodeSTL はSceneをSTLとして書き出します。binaryがfalseの場合はASCII形式で書き出します
+// STLは色やノードを持たないため、すべてのノードの三角形を1つのソリッドにまとめます
+func EncodeSTL(w io.Writer, scene *Scene, name string, binaryFormat bool) error {
+	if binaryFormat {
+		return encodeBinarySTL(w, scene, name)
+	}
+	return encodeASCIISTL(w, scene, name)
+}
+
+// encodeBinarySTL はバイナリ形式のSTLを書き出します
+func encodeBinarySTL(w io.Writer, scene *Scene, name string) error {
+	count := 0
+	for _, node := range scene.Nodes {
+		for _, p := range node.Primitives {
+			count += p.TriangleCount()
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	header := make([]byte, 80)
+	copy(header, name)
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, uint32(count)); err != nil {
+		return err
+	}
+
+	var facet [12]float32
+	for _, node := range scene.Nodes {
+		for _, p := range node.Primitives {
+			for i := 0; i+2 < len(p.Indices); i += 3 {
+				p.facet(i, &facet)
+				if err := binary.Write(bw, binary.LittleEndian, facet); err != nil {
+					return err
+				}
+				if err := binary.Write(bw, binary.LittleEndian, uint16(0)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// encodeASCIISTL はASCII形式のSTLを書き出します
+func encodeASCIISTL(w io.Writer, scene *Scene, name string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "solid %s\n", name)
+
+	var facet [12]float32
+	for _, node := range scene.Nodes {
+		for _, p := range node.Primitives {
+			for i := 0; i+2 < len(p.Indices); i += 3 {
+				p.facet(i, &facet)
+				fmt.Fprintf(bw, "  facet normal %g %g %g\n    outer loop\n", facet[0], facet[1], facet[2])
+				for v := 1; v <= 3; v++ {
+					fmt.Fprintf(bw, "      vertex %g %g %g\n", facet[v*3], facet[v*3+1], facet[v*3+2])
+				}
+				fmt.Fprintf(bw, "    endloop\n  endfacet\n")
+			}
+		}
+	}
+
+	fmt.Fprintf(bw, "endsolid %s\n", name)
+	return bw.Flush()
+}
+
+// facet はi番目から始まる三角形の面法線と3頂点を詰めます
+func (p *Primitive) facet(i int, facet *[12]float32) {
+	a, b, c := p.Indices[i], p.Indices[i+1], p.Indices[i+2]
+	ax, ay, az := p.position(a)
+	bx, by, bz := p.position(b)
+	cx, cy, cz := p.position(c)
+
+	ux, uy, uz := bx-ax, by-ay, bz-az
+	vx, vy, vz := cx-ax, cy-ay, cz-az
+	nx, ny, nz := uy*vz-uz*vy, uz*vx-ux*vz, ux*vy-uy*vx
+	if length := math.Sqrt(nx*nx + ny*ny + nz*nz); length > 0 {
+		nx, ny, nz = nx/length, ny/length, nz/length
+	}
+
+	*facet = [12]float32{
+		float32(nx), float32(ny), float32(nz),
+		float32(ax), float32(ay), float32(az),
+		float32(bx), float32(by), float32(bz),
+		float32(cx), float32(cy), float32(cz),
+	}
+}
@@ -0,0 +1,17 @@
+package mesh
+
+// ApplyMatrix は4x4行列（three.jsのMatrix4.elementsと同じ列優先の並び）で頂点座標を変換します
+// 変換後の法線は正しくなくなるため破棄します。必要に応じてComputeNormalsで計算し直してください
+func (p *Primitive) ApplyMatrix(m [16]float64) {
+	for i := 0; i+2 < len(p.Positions); i += 3 {
+		x, y, z := float64(p.Positions[i]), float64(p.Positions[i+1]), float64(p.Positions[i+2])
+		w := m[3]*x + m[7]*y + m[11]*z + m[15]
+		if w == 0 {
+			w = 1
+		}
+		p.Positions[i] = float32((m[0]*x + m[4]*y + m[8]*z + m[12]) / w)
+		p.Positions[i+1] = float32((m[1]*x + m[5]*y + m[9]*z + m[13]) / w)
+		p.Positions[i+2] = float32((m[2]*x + m[6]*y + m[10]*z + m[14]) / w)
+	}
+	p.Normals = nil
+}
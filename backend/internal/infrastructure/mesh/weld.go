@@ -0,0 +1,79 @@
+package mesh
+
+import (
+	"math"
+)
+
+// gridCell は頂点をまとめるための格子セル
+type gridCell [3]int64
+
+// Weld はtolerance間隔の格子で量子化して同じ位置とみなせる頂点を統合し、潰れた三角形を取り除きます
+// 法線は統合後の形状から計算し直す必要があるため破棄します
+func (p *Primitive) Weld(tolerance float64) {
+	if tolerance <= 0 || p.VertexCount() == 0 {
+		return
+	}
+
+	remap := make([]uint32, p.VertexCount())
+	seen := make(map[gridCell]uint32, p.VertexCount())
+	positions := make([]float32, 0, len(p.Positions))
+	for i := 0; i < p.VertexCount(); i++ {
+		x, y, z := p.position(uint32(i))
+		cell := gridCell{
+			int64(math.Round(x / tolerance)),
+			int64(math.Round(y / tolerance)),
+			int64(math.Round(z / tolerance)),
+		}
+		idx, ok := seen[cell]
+		if !ok {
+			idx = uint32(len(positions) / 3)
+			positions = append(positions, p.Positions[i*3:i*3+3]...)
+			seen[cell] = idx
+		}
+		remap[i] = idx
+	}
+
+	p.Positions = positions
+	p.Indices = remapTriangles(p.Indices, remap, false)
+	p.Normals = nil
+}
+
+// remapTriangles はインデックスを付け替え、潰れた三角形を取り除きます
+// dedupeがtrueの場合は同じ頂点からなる重複した三角形も取り除きます
+func remapTriangles(indices []uint32, remap []uint32, dedupe bool) []uint32 {
+	result := make([]uint32, 0, len(indices))
+	var seen map[[3]uint32]struct{}
+	if dedupe {
+		seen = map[[3]uint32]struct{}{}
+	}
+
+	for i := 0; i+2 < len(indices); i += 3 {
+		a, b, c := remap[indices[i]], remap[indices[i+1]], remap[indices[i+2]]
+		if a == b || b == c || a == c {
+			continue
+		}
+		if dedupe {
+			key := sortedTriangle(a, b, c)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+		}
+		result = append(result, a, b, c)
+	}
+	return result
+}
+
+// sortedTriangle は頂点の順序によらない三角形のキーを返します
+func sortedTriangle(a, b, c uint32) [3]uint32 {
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b, c = c, b
+	}
+	if a > b {
+		a, b = b, a
+	}
+	return [3]uint32{a, b, c}
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheusのメトリクス。/metricsでPrometheusのテキスト形式で公開します
+var (
+	// APS呼び出し1回（リトライは別の呼び出し）あたりのレスポンスヘッダーを受け取るまでの時間
+	// statusはHTTPステータス、通信エラーの場合はerror
+	APSRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "aps_request_duration_seconds",
+		Help:    "Latency of APS HTTP calls until response headers, by API, method and status.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"api", "method", "status"})
+
+	// APS呼び出しのリトライ回数。reasonはstatus_429, status_503, networkなど
+	APSRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "aps_request_retries_total",
+		Help: "Retries of APS HTTP calls, by API and reason.",
+	}, []string{"api", "reason"})
+
+	// S3の署名付きURLへアップロードしたバイト数（成功したパートのみ）
+	UploadBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "aps_upload_bytes_total",
+		Help: "Bytes uploaded to APS signed S3 URLs.",
+	})
+
+	// S3の署名付きURLへのパートのPUTを再送した回数
+	UploadPartRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "aps_upload_part_retries_total",
+		Help: "Retries of part uploads to APS signed S3 URLs.",
+	})
+
+	// トークンを取得した回数。reasonは期限切れ・未取得（expiry）か、APSが401を返した（unauthorized）か
+	TokenRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "aps_token_refreshes_total",
+		Help: "APS access tokens fetched, by reason.",
+	}, []string{"reason"})
+
+	// 翻訳ジョブの送信から成功を確認するまでの時間
+	TranslationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "aps_translation_duration_seconds",
+		Help:    "Time from translation job submission until success was observed, by source file extension.",
+		Buckets: []float64{5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+	}, []string{"extension"})
+
+	// 終了した翻訳ジョブの数。statusはsuccess, failed, timeout
+	TranslationJobs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "aps_translation_jobs_total",
+		Help: "Translation jobs that finished, by source file extension and status.",
+	}, []string{"extension", "status"})
+
+	// 送信して終了をまだ確認していない翻訳ジョブの数
+	TranslationJobsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "aps_translation_jobs_in_flight",
+		Help: "Translation jobs submitted whose completion has not been observed yet.",
+	})
+
+	// レート制限で429を返したリクエストの数。classはtoken, upload, translate, read
+	RateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_rate_limited_requests_total",
+		Help: "Requests rejected with 429 by the per-client rate limiter, by route class.",
+	}, []string{"class"})
+)
+
+// APIName はAPS呼び出しのURLからメトリクスのapiラベルを返します
+// ベースURLは設定で変わるため、パスで判定します。いずれにも当たらないものは署名付きURL（s3）とみなします
+func APIName(req *http.Request) string {
+	p := req.URL.Path
+	switch {
+	case strings.Contains(p, "/authentication/"):
+		return "auth"
+	case strings.Contains(p, "/oss/"):
+		return "oss"
+	case strings.Contains(p, "/modelderivative/"):
+		return "modelderivative"
+	case strings.Contains(p, "/derivativeservice/"):
+		return "viewer"
+	default:
+		return "s3"
+	}
+}
+
+// StatusLabel はレスポンスのstatusラベルを返します
+func StatusLabel(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return "error"
+	}
+	return strconv.Itoa(resp.StatusCode)
+}
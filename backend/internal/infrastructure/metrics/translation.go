@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 終了を確認しないまま残った翻訳ジョブを数えなくなるまでの時間
+const translationMaxAge = 24 * time.Hour
+
+// TranslationTracker は翻訳ジョブの送信時刻を覚えておき、マニフェストで終了を確認したときに所要時間を記録します
+// 送信時刻はメモリ上にのみ保持するため、再起動前に送信したジョブは記録しません
+type TranslationTracker struct {
+	mu   sync.Mutex
+	jobs map[string]translationJob
+	now  func() time.Time
+}
+
+type translationJob struct {
+	extension   string
+	submittedAt time.Time
+}
+
+// NewTranslationTracker は新しいTranslationTrackerを作成します
+func NewTranslationTracker() *TranslationTracker {
+	return &TranslationTracker{
+		jobs: map[string]translationJob{},
+		now:  time.Now,
+	}
+}
+
+// JobSubmitted は翻訳ジョブを送信した時刻を記録します。同じURNを送信し直した場合は時刻を更新します
+func (t *TranslationTracker) JobSubmitted(urn string, fileName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for key, job := range t.jobs {
+		if now.Sub(job.submittedAt) > translationMaxAge {
+			delete(t.jobs, key)
+			TranslationJobsInFlight.Dec()
+		}
+	}
+	if _, ok := t.jobs[urn]; !ok {
+		TranslationJobsInFlight.Inc()
+	}
+	t.jobs[urn] = translationJob{extension: extensionLabel(fileName), submittedAt: now}
+}
+
+// JobStatus はマニフェストで確認した翻訳の状態を受け取り、終了していれば所要時間と結果を記録します
+func (t *TranslationTracker) JobStatus(urn string, status string) {
+	switch status {
+	case "success", "failed", "timeout":
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	job, ok := t.jobs[urn]
+	if ok {
+		delete(t.jobs, urn)
+	}
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	TranslationJobsInFlight.Dec()
+	TranslationJobs.WithLabelValues(job.extension, status).Inc()
+	if status == "success" {
+		TranslationDuration.WithLabelValues(job.extension).Observe(t.now().Sub(job.submittedAt).Seconds())
+	}
+}
+
+// extensionLabel はファイル名の拡張子を小文字・ドットなしで返します
+// ラベルの値が増えすぎないよう、英数字以外を含む拡張子や長い拡張子はotherにまとめます
+func extensionLabel(fileName string) string {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(fileName), "."))
+	if ext == "" {
+		return "none"
+	}
+	if len(ext) > 8 {
+		return "other"
+	}
+	for _, c := range ext {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return "other"
+		}
+	}
+	return ext
+}
+
+// インターフェースの実装を確認
+var _ domain.TranslationMetrics = (*TranslationTracker)(nil)
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// 満杯になったバケットを削除する間隔
+const sweepInterval = time.Minute
+
+// MemoryStore はプロセスのメモリにトークンバケットを保持するStore
+// インスタンスごとに独立して制限するため、複数のインスタンスで動かす場合はRedisStoreを使います
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// NewMemoryStore は新しいMemoryStoreを作成します
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+// Take はキーのバケットに経過時間分のトークンを補充してから1個取り出します
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / limit.perSecond()
+		return Decision{RetryAfter: time.Duration(wait * float64(time.Second))}, nil
+	}
+	b.tokens--
+	return Decision{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// refill は前回からの経過時間分のトークンを容量まで補充します
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.perSecond())
+		b.updated = now
+	}
+}
+
+// sweep は満杯になったバケットを削除します。満杯のバケットは新しく作るものと同じ状態のため、削除しても制限は変わりません
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock はテストで進める時計
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = clock.Now
+	return s, clock
+}
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newTestStore()
+	limit := Limit{PerMinute: 60, Burst: 3}
+
+	take := func() Decision {
+		t.Helper()
+		d, err := s.Take(ctx, "token:principal:alice", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	// 容量までは続けて受け付ける
+	for want := 2; want >= 0; want-- {
+		d := take()
+		if !d.Allowed || d.Remaining != want {
+			t.Fatalf("Take() = %+v, want allowed with %d remaining", d, want)
+		}
+	}
+	d := take()
+	if d.Allowed || d.RetryAfter != time.Second {
+		t.Fatalf("Take() = %+v, want denied with RetryAfter 1s", d)
+	}
+
+	// 1秒に1個補充する
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	if d := take(); d.Allowed || d.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("Take() = %+v, want denied with RetryAfter 500ms", d)
+	}
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	if d := take(); !d.Allowed {
+		t.Fatalf("Take() = %+v after refill, want allowed", d)
+	}
+
+	// 長く空けても容量を超えては貯まらない
+	clock.now = clock.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if d := take(); !d.Allowed {
+			t.Fatalf("Take() #%d = %+v, want allowed", i, d)
+		}
+	}
+	if d := take(); d.Allowed {
+		t.Fatalf("Take() = %+v, want denied after the burst", d)
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestStore()
+	limit := Limit{PerMinute: 1, Burst: 1}
+
+	if d, _ := s.Take(ctx, "token:ip:192.0.2.1", limit); !d.Allowed {
+		t.Fatal("first request was denied")
+	}
+	if d, _ := s.Take(ctx, "token:ip:192.0.2.1", limit); d.Allowed {
+		t.Fatal("second request from the same client was allowed")
+	}
+	if d, _ := s.Take(ctx, "token:ip:192.0.2.2", limit); !d.Allowed {
+		t.Fatal("request from another client was denied")
+	}
+	if d, _ := s.Take(ctx, "read:ip:192.0.2.1", limit); !d.Allowed {
+		t.Fatal("request of another class was denied")
+	}
+}
+
+func TestMemoryStoreSweepKeepsPartialBuckets(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newTestStore()
+	limit := Limit{PerMinute: 1, Burst: 2}
+
+	s.Take(ctx, "token:principal:alice", limit)
+	s.Take(ctx, "token:principal:alice", limit)
+
+	// 削除の間隔を過ぎても、空になったバケットは補充した分しか使えない
+	clock.now = clock.now.Add(sweepInterval)
+	if d, _ := s.Take(ctx, "token:principal:alice", limit); !d.Allowed || d.Remaining != 0 {
+		t.Fatalf("Take() = %+v, want allowed with 0 remaining", d)
+	}
+	if d, _ := s.Take(ctx, "token:principal:alice", limit); d.Allowed {
+		t.Fatal("sweep reset a bucket that was not full")
+	}
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Class はレート制限の枠を分けるルートの種類
+type Class string
+
+const (
+	// Viewer用トークンの発行
+	ClassToken Class = "token"
+	// 署名付きURLによるファイルのアップロード
+	ClassUpload Class = "upload"
+	// アップロードと翻訳の開始、エクスポートとGLB変換の作成
+	ClassTranslate Class = "translate"
+	// その他の操作
+	ClassRead Class = "read"
+)
+
+// ClassOf は操作のルートの種類を返します
+func ClassOf(action domain.Action) Class {
+	switch action {
+	case domain.ActionTokenCreate, domain.ActionShareView:
+		return ClassToken
+	case domain.ActionObjectUpload:
+		return ClassUpload
+	case domain.ActionObjectTranslate, domain.ActionExportCreate, domain.ActionMeshProcess:
+		return ClassTranslate
+	default:
+		return ClassRead
+	}
+}
+
+// Limit はトークンバケットによる制限
+// 容量Burstのバケットに1分あたりPerMinute個のトークンを補充し、1リクエストごとに1個消費します
+type Limit struct {
+	PerMinute float64 `yaml:"perMinute"`
+	Burst     int     `yaml:"burst"`
+}
+
+// Enabled は制限するか判定します。PerMinuteが0の場合は制限しません
+func (l Limit) Enabled() bool {
+	return l.PerMinute > 0
+}
+
+// perSecond は1秒あたりに補充するトークンの数を返します
+func (l Limit) perSecond() float64 {
+	return l.PerMinute / 60
+}
+
+// String は"60/m:20"の形式で制限を返します
+func (l Limit) String() string {
+	return fmt.Sprintf("%g/m:%d", l.PerMinute, l.Burst)
+}
+
+// Options はレート制限の設定
+type Options struct {
+	Enabled bool `yaml:"enabled"`
+	// 制限の状態を保持する場所（memory, redis）。複数のインスタンスで制限を共有する場合はredis
+	Backend string `yaml:"backend"`
+	// Backendがredisの場合の接続先（例: redis://:password@localhost:6379/0）
+	RedisURL string `yaml:"redisUrl"`
+	// 匿名の呼び出し元を識別するIPアドレスにX-Forwarded-Forの末尾を使うか。リバースプロキシの後ろで動かす場合に有効にします
+	// 先頭側の値は呼び出し元が自由に指定できるため、直前のプロキシが追加した末尾の値のみを信頼します
+	TrustForwardedFor bool `yaml:"trustForwardedFor"`
+
+	// ルートの種類ごとの制限
+	Token     Limit `yaml:"token"`
+	Upload    Limit `yaml:"upload"`
+	Translate Limit `yaml:"translate"`
+	Read      Limit `yaml:"read"`
+}
+
+// DefaultOptions は既定のレート制限の設定を返します
+func DefaultOptions() Options {
+	return Options{
+		Enabled:   true,
+		Backend:   "memory",
+		Token:     Limit{PerMinute: 60, Burst: 20},
+		Upload:    Limit{PerMinute: 300, Burst: 100},
+		Translate: Limit{PerMinute: 20, Burst: 5},
+		Read:      Limit{PerMinute: 3000, Burst: 1000},
+	}
+}
+
+// Limits はルートの種類ごとの制限を返します
+func (o Options) Limits() map[Class]Limit {
+	return map[Class]Limit{
+		ClassToken:     o.Token,
+		ClassUpload:    o.Upload,
+		ClassTranslate: o.Translate,
+		ClassRead:      o.Read,
+	}
+}
+
+// Decision はリクエストを受け付けるかの判定結果
+type Decision struct {
+	Allowed bool
+	// バケットに残っているトークンの数
+	Remaining int
+	// 拒否した場合に次のトークンが補充されるまでの時間
+	RetryAfter time.Duration
+}
+
+// Store はキーごとのトークンバケットを保持します
+type Store interface {
+	// Take はキーのバケットからトークンを1個取り出します
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// Limiter は呼び出し元とルートの種類ごとにリクエストを制限します
+type Limiter struct {
+	store             Store
+	limits            map[Class]Limit
+	trustForwardedFor bool
+}
+
+// New は設定に従ってLimiterを作成します。レート制限を無効にしている場合はnilを返します
+func New(opts Options) (*Limiter, error) {
+	if !opts.Enabled {
+		return nil, nil
+	}
+	var store Store
+	switch opts.Backend {
+	case "", "memory":
+		store = NewMemoryStore()
+	case "redis":
+		s, err := NewRedisStore(opts.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		store = s
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend: %q", opts.Backend)
+	}
+	return NewLimiter(store, opts), nil
+}
+
+// NewLimiter はstoreに状態を保持するLimiterを作成します
+func NewLimiter(store Store, opts Options) *Limiter {
+	return &Limiter{store: store, limits: opts.Limits(), trustForwardedFor: opts.TrustForwardedFor}
+}
+
+// Limit はルートの種類の制限を返します
+func (l *Limiter) Limit(class Class) Limit {
+	return l.limits[class]
+}
+
+// Allow は呼び出し元のルートの種類のバケットからトークンを取り出します
+func (l *Limiter) Allow(ctx context.Context, class Class, client string) (Decision, error) {
+	limit := l.limits[class]
+	if !limit.Enabled() {
+		return Decision{Allowed: true}, nil
+	}
+	return l.store.Take(ctx, string(class)+":"+client, limit)
+}
+
+// ClientKey はリクエストの呼び出し元を識別するキーを返します
+// 認証した呼び出し元はIDで、匿名の呼び出し元と認証を無効にした場合はIPアドレスで識別します
+func (l *Limiter) ClientKey(r *http.Request) string {
+	if principal, ok := domain.PrincipalFromContext(r.Context()); ok && principal.Method != domain.AuthMethodAnonymous && principal.Method != domain.AuthMethodDisabled {
+		return "principal:" + principal.ID
+	}
+	if l.trustForwardedFor {
+		// プロキシはヘッダーの値の末尾に追加するか、ヘッダーを追加するため、最後のヘッダーの最後の値を使う
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			last := values[len(values)-1]
+			if i := strings.LastIndex(last, ","); i >= 0 {
+				last = last[i+1:]
+			}
+			if ip := strings.TrimSpace(last); ip != "" {
+				return "ip:" + ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
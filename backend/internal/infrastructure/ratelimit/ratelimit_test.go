@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+func TestClientKey(t *testing.T) {
+	alice := &domain.Principal{ID: "alice", Method: domain.AuthMethodAPIKey}
+	tests := []struct {
+		name              string
+		trustForwardedFor bool
+		principal         *domain.Principal
+		forwardedFor      []string
+		want              string
+	}{
+		{name: "authenticated caller", principal: alice, forwardedFor: []string{"198.51.100.1"}, want: "principal:alice"},
+		{name: "anonymous caller", principal: domain.AnonymousPrincipal, want: "ip:192.0.2.1"},
+		{name: "authentication disabled", principal: domain.DevelopmentPrincipal, want: "ip:192.0.2.1"},
+		{name: "forwarded for is ignored by default", forwardedFor: []string{"198.51.100.1"}, want: "ip:192.0.2.1"},
+		{name: "rightmost forwarded entry", trustForwardedFor: true, forwardedFor: []string{"203.0.113.9, 198.51.100.1"}, want: "ip:198.51.100.1"},
+		{name: "last forwarded header", trustForwardedFor: true, forwardedFor: []string{"203.0.113.9", "198.51.100.1"}, want: "ip:198.51.100.1"},
+		{name: "empty forwarded entry", trustForwardedFor: true, forwardedFor: []string{"203.0.113.9, "}, want: "ip:192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(NewMemoryStore(), Options{TrustForwardedFor: tt.trustForwardedFor})
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/models", nil)
+			r.RemoteAddr = "192.0.2.1:54321"
+			for _, v := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if tt.principal != nil {
+				r = r.WithContext(domain.WithPrincipal(r.Context(), tt.principal))
+			}
+			if got := l.ClientKey(r); got != tt.want {
+				t.Errorf("ClientKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(NewMemoryStore(), Options{
+		Token: Limit{PerMinute: 1, Burst: 1},
+		// PerMinuteが0の種類は制限しない
+		Read: Limit{},
+	})
+
+	if d, _ := l.Allow(ctx, ClassOf(domain.ActionShareView), "ip:192.0.2.1"); !d.Allowed {
+		t.Fatal("first share exchange was denied")
+	}
+	if d, _ := l.Allow(ctx, ClassOf(domain.ActionTokenCreate), "ip:192.0.2.1"); d.Allowed {
+		t.Fatal("token requests do not share the token class limit")
+	}
+	for i := 0; i < 10; i++ {
+		if d, _ := l.Allow(ctx, ClassRead, "ip:192.0.2.1"); !d.Allowed {
+			t.Fatal("request of an unlimited class was denied")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redisのキーの接頭辞
+const redisKeyPrefix = "aps-viewer:ratelimit:"
+
+// takeScript はトークンバケットの補充と取り出しをRedis上で不可分に行います
+// インスタンス間の時計のずれの影響を受けないよう、時刻はRedisサーバーのものを使います
+// KEYS[1]: バケットのキー, ARGV[1]: 1ミリ秒あたりに補充するトークンの数, ARGV[2]: 容量
+// 戻り値: {受け付けたか(1/0), 残りのトークンの数, 次のトークンまでのミリ秒}
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+if now > updated then
+	tokens = math.min(burst, tokens + (now - updated) * rate)
+else
+	now = updated
+end
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+-- 満杯になるまでの時間が過ぎたら、新しく作るものと同じ状態のため削除する
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, math.floor(tokens), wait}
+`)
+
+// RedisStore はRedisにトークンバケットを保持するStore。複数のインスタンスで制限を共有します
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore はredis://またはrediss://のURLで接続するRedisStoreを作成します
+func NewRedisStore(url string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit redis url: %w", err)
+	}
+	return &RedisStore{client: redis.NewClient(opts)}, nil
+}
+
+// Take はRedis上のキーのバケットからトークンを1個取り出します
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Decision, error) {
+	result, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, limit.perSecond()/1000, limit.Burst).Int64Slice()
+	if err != nil {
+		return Decision{}, fmt.Errorf("rate limit redis: %w", err)
+	}
+	if len(result) != 3 {
+		return Decision{}, fmt.Errorf("rate limit redis: unexpected result %v", result)
+	}
+	return Decision{
+		Allowed:    result[0] == 1,
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+	}, nil
+}
+
+// Close はRedisへの接続を閉じます
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,190 @@
+package audit_log
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 最初の記録のPrevHash
+const genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// AuditLog は監査ログを1行1記録のJSONLファイルに追記するリポジトリ実装
+// 各記録のハッシュは直前の記録のハッシュを含めて計算するため、途中の記録の書き換えや削除を検出できます
+type AuditLog struct {
+	path string
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	lastSeq  int64
+	lastHash string
+}
+
+// snapshot は書き込み済みのファイルの大きさと、最後の記録の通し番号とハッシュ
+type snapshot struct {
+	size     int64
+	lastSeq  int64
+	lastHash string
+}
+
+// NewAuditLog は監査ログのファイルを開き、最後の記録の通し番号とハッシュを読み込みます
+// 書き込みの途中で異常終了して最後の行が途中までしかない場合は、その行を<path>.incompleteへ移してから続けます
+func NewAuditLog(path string) (*AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat audit log: %w", err)
+	}
+
+	l := &AuditLog{path: path, file: file, size: info.Size(), lastHash: genesisHash}
+	incomplete, err := l.scan(l.size, func(entry *domain.AuditEntry) bool {
+		l.lastSeq = entry.Seq
+		l.lastHash = entry.Hash
+		return true
+	})
+	if err == nil && len(incomplete) > 0 {
+		err = l.quarantine(incomplete)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// quarantine は途中までしか書き込まれていない最後の行を<path>.incompleteへ移し、監査ログから取り除きます
+// 取り除かないと、続けて追記した記録が同じ行につながって読めなくなります
+func (l *AuditLog) quarantine(incomplete []byte) error {
+	slog.Warn("audit log ends with an incomplete entry, moving it aside", "path", l.path, "bytes", len(incomplete))
+
+	f, err := os.OpenFile(l.incompletePath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open incomplete audit log: %w", err)
+	}
+	if _, err := f.Write(append(incomplete, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write incomplete audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	l.size -= int64(len(incomplete))
+	if err := l.file.Truncate(l.size); err != nil {
+		return fmt.Errorf("failed to truncate audit log: %w", err)
+	}
+	return l.file.Sync()
+}
+
+// incompletePath は途中までしか書き込まれていない行を移すファイルのパスを返します
+func (l *AuditLog) incompletePath() string {
+	return l.path + ".incomplete"
+}
+
+// Append は通し番号と直前の記録のハッシュを設定し、ハッシュを計算して追記します
+// 書き込みはディスクへの同期まで待ちます。途中で失敗した場合は書き込んだ分を取り消します
+func (l *AuditLog) Append(entry *domain.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.Seq = l.lastSeq + 1
+	entry.PrevHash = l.lastHash
+	hash, err := hashOf(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if _, err := l.file.Write(line); err != nil {
+		l.file.Truncate(l.size)
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		l.file.Truncate(l.size)
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+	l.size += int64(len(line))
+	l.lastSeq = entry.Seq
+	l.lastHash = entry.Hash
+	return nil
+}
+
+// Close は監査ログのファイルを閉じます
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// snapshot は書き込み済みの大きさと最後の記録を返します
+// 読み込みはこの大きさまでに限るため、追記中の行を読むことはありません
+func (l *AuditLog) snapshot() snapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return snapshot{size: l.size, lastSeq: l.lastSeq, lastHash: l.lastHash}
+}
+
+// scan はファイルの先頭からsizeバイトまでの記録を古い順に読み、fnがfalseを返すと止めます
+// 最後の行が改行で終わっていない場合は、書き込みの途中の行としてその内容を返します
+func (l *AuditLog) scan(size int64, fn func(entry *domain.AuditEntry) bool) ([]byte, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(io.LimitReader(f, size))
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entry domain.AuditEntry
+		if uerr := json.Unmarshal(data, &entry); uerr != nil {
+			return nil, fmt.Errorf("audit log line %d is corrupted: %w", line, uerr)
+		}
+		if !fn(&entry) {
+			return nil, nil
+		}
+	}
+}
+
+// hashOf はHashを除いた記録のJSONのSHA-256を返します。PrevHashを含むため記録が連鎖します
+func hashOf(entry *domain.AuditEntry) (string, error) {
+	unsigned := *entry
+	unsigned.Hash = ""
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// インターフェースの実装を確認
+var _ domain.AuditRepository = (*AuditLog)(nil)
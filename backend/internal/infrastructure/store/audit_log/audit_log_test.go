@@ -0,0 +1,179 @@
+package audit_log
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+func newTestLog(t *testing.T, path string) *AuditLog {
+	t.Helper()
+	l, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func appendEntries(t *testing.T, l *AuditLog, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		err := l.Append(&domain.AuditEntry{
+			Time:      time.Now().UTC(),
+			Principal: "alice",
+			Action:    domain.ActionBucketDelete,
+			Target:    fmt.Sprintf("bucket:teama-%d", i),
+			Outcome:   domain.AuditOutcomeSuccess,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func verify(t *testing.T, l *AuditLog) *domain.AuditVerification {
+	t.Helper()
+	result, err := l.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := newTestLog(t, path)
+	appendEntries(t, l, 3)
+
+	result := verify(t, l)
+	if !result.OK || result.Entries != 3 || result.Incomplete != 0 {
+		t.Errorf("Verify() = %+v, want OK with 3 entries", result)
+	}
+}
+
+func TestVerifyDetectsTailTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := newTestLog(t, path)
+	appendEntries(t, l, 5)
+
+	// 最新の2件を削除しても、残りの連鎖は正しいまま
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if err := os.WriteFile(path, bytes.Join(lines[:3], nil), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result := verify(t, l)
+	if result.OK || result.BrokenAt != 4 {
+		t.Errorf("Verify() = %+v, want broken at seq 4", result)
+	}
+}
+
+func TestVerifyDetectsModifiedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := newTestLog(t, path)
+	appendEntries(t, l, 3)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("bucket:teama-1"), []byte("bucket:teamb-1"), 1)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result := verify(t, l)
+	if result.OK || result.BrokenAt != 2 {
+		t.Errorf("Verify() = %+v, want broken at seq 2", result)
+	}
+}
+
+func TestQueryAndVerifyWhileAppending(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := newTestLog(t, path)
+
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 200; i++ {
+			if err := l.Append(&domain.AuditEntry{Time: time.Now().UTC(), Action: domain.ActionBucketDelete}); err != nil {
+				done <- err
+				return
+			}
+		}
+		close(done)
+	}()
+	for {
+		if _, err := l.Query(domain.AuditFilter{Limit: 10}); err != nil {
+			t.Fatalf("Query() = %v", err)
+		}
+		if result := verify(t, l); !result.OK || result.Incomplete != 0 {
+			t.Fatalf("Verify() = %+v while appending", result)
+		}
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := verify(t, l); !result.OK || result.Entries != 200 {
+				t.Errorf("Verify() = %+v, want OK with 200 entries", result)
+			}
+			return
+		default:
+		}
+	}
+}
+
+func TestNewAuditLogRecoversIncompleteEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := newTestLog(t, path)
+	appendEntries(t, l, 2)
+	l.Close()
+
+	// 書き込みの途中で異常終了した状態を再現する
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"seq":3,"time":"2026-`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	l = newTestLog(t, path)
+	appendEntries(t, l, 1)
+
+	entries, err := l.Query(domain.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].Seq != 3 {
+		t.Errorf("Query() returned %d entries, want 3 with the newest at seq 3", len(entries))
+	}
+	result := verify(t, l)
+	if !result.OK || result.Entries != 3 || result.Incomplete != 1 {
+		t.Errorf("Verify() = %+v, want OK with 3 entries and 1 incomplete", result)
+	}
+	if _, err := os.Stat(path + ".incomplete"); err != nil {
+		t.Errorf("incomplete entry was not kept: %v", err)
+	}
+}
+
+func TestNewAuditLogRejectsCorruptedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := os.WriteFile(path, []byte("not json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAuditLog(path); err == nil {
+		t.Fatal("NewAuditLog() succeeded with a corrupted entry")
+	}
+}
@@ -0,0 +1,131 @@
+package audit_log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Query は条件に合う記録を新しい順に最大filter.Limit件返します
+func (l *AuditLog) Query(filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var matched []domain.AuditEntry
+	_, err := l.scan(l.snapshot().size, func(entry *domain.AuditEntry) bool {
+		if matches(entry, &filter) {
+			matched = append(matched, *entry)
+			// 古い記録から読むため、上限を超えたら最も古いものを捨てる
+			if filter.Limit > 0 && len(matched) > filter.Limit {
+				matched = matched[1:]
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AuditEntry, 0, len(matched))
+	for i := len(matched) - 1; i >= 0; i-- {
+		result = append(result, matched[i])
+	}
+	return result, nil
+}
+
+func matches(entry *domain.AuditEntry, filter *domain.AuditFilter) bool {
+	switch {
+	case filter.Principal != "" && entry.Principal != filter.Principal:
+		return false
+	case filter.Action != "" && entry.Action != filter.Action:
+		return false
+	case filter.TargetPrefix != "" && !strings.HasPrefix(entry.Target, filter.TargetPrefix):
+		return false
+	case filter.Outcome != "" && entry.Outcome != filter.Outcome:
+		return false
+	case !filter.Since.IsZero() && entry.Time.Before(filter.Since):
+		return false
+	case !filter.Until.IsZero() && !entry.Time.Before(filter.Until):
+		return false
+	default:
+		return true
+	}
+}
+
+// Verify はすべての記録の通し番号とハッシュの連鎖を確認します
+// 最後の記録が書き込んだ記録と一致するかも確認するため、末尾の記録を削除した場合も検出できます
+// 途中までしか書き込まれていない記録は、起動時に取り除いたものも含めてIncompleteに数えます
+func (l *AuditLog) Verify() (*domain.AuditVerification, error) {
+	snap := l.snapshot()
+	result := &domain.AuditVerification{OK: true}
+	prevHash := genesisHash
+	var verr error
+	incomplete, err := l.scan(snap.size, func(entry *domain.AuditEntry) bool {
+		result.Entries++
+		hash, err := hashOf(entry)
+		if err != nil {
+			verr = err
+			return false
+		}
+		var detail string
+		switch {
+		case entry.Seq != result.Entries:
+			detail = fmt.Sprintf("expected seq %d, found %d", result.Entries, entry.Seq)
+		case entry.PrevHash != prevHash:
+			detail = "prevHash does not match the previous entry"
+		case entry.Hash != hash:
+			detail = "hash does not match the entry"
+		}
+		if detail != "" {
+			result.OK = false
+			result.BrokenAt = result.Entries
+			result.Detail = detail
+			return false
+		}
+		prevHash = entry.Hash
+		return true
+	})
+	if verr != nil {
+		return nil, verr
+	}
+	switch {
+	case err != nil:
+		// 壊れた行がある場合も検証の失敗として返す
+		result.OK = false
+		result.BrokenAt = result.Entries + 1
+		result.Detail = err.Error()
+	case !result.OK:
+	case result.Entries != snap.lastSeq || prevHash != snap.lastHash:
+		// 連鎖は正しくても、書き込んだ最後の記録まで残っていなければ末尾が削除されている
+		result.OK = false
+		result.BrokenAt = result.Entries + 1
+		result.Detail = fmt.Sprintf("log ends at seq %d but the last written entry is seq %d", result.Entries, snap.lastSeq)
+	}
+
+	if len(incomplete) > 0 {
+		result.Incomplete++
+	}
+	quarantined, err := countLines(l.incompletePath())
+	if err != nil {
+		return nil, err
+	}
+	result.Incomplete += quarantined
+	if result.Incomplete > 0 && result.Detail == "" {
+		result.Detail = fmt.Sprintf("%d incomplete entries were written when the server stopped unexpectedly, see %s", result.Incomplete, filepath.Base(l.incompletePath()))
+	}
+	return result, nil
+}
+
+// countLines はファイルの行数を返します。ファイルがない場合は0を返します
+func countLines(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Count(data, []byte("\n")), nil
+}
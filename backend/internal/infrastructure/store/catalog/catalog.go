@@ -0,0 +1,44 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	// database/sqlにsqliteドライバーを登録する（cgoを使わないSQLite）
+	_ "modernc.org/sqlite"
+)
+
+// 書き込みが重なった場合にロックの解放を待つ時間
+const busyTimeout = 5 * time.Second
+
+// Open はカタログのSQLiteデータベースを開き、未適用のマイグレーションを適用します。ファイルがない場合は作成します
+func Open(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create catalog directory: %w", err)
+	}
+
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(%d)",
+		filepath.ToSlash(path), busyTimeout.Milliseconds())
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open catalog: %w", err)
+	}
+	// SQLiteは書き込みを1つずつしか行えないため、接続を1つにしてロックの競合を避ける
+	db.SetMaxOpenConns(1)
+
+	if err := Migrate(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Check はカタログに読み書きできるか確認します
+func Check(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "PRAGMA user_version = user_version")
+	return err
+}
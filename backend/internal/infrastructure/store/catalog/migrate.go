@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrations は番号_説明.sqlの名前で並べたスキーマの変更
+// 適用済みのファイルは変更せず、変更は新しい番号のファイルで追加します
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migration はスキーマの変更1件
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations は埋め込んだマイグレーションを番号順に返します
+func loadMigrations() ([]migration, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	var list []migration
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".sql")
+		number, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(number)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s must start with a version number", entry.Name())
+		}
+		data, err := migrations.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, migration{version: version, name: name, sql: string(data)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].version < list[j].version })
+	for i := 1; i < len(list); i++ {
+		if list[i].version == list[i-1].version {
+			return nil, fmt.Errorf("duplicate migration version %d", list[i].version)
+		}
+	}
+	return list, nil
+}
+
+// Migrate は未適用のマイグレーションを番号順に1件ずつトランザクションで適用し、schema_migrationsに記録します
+func Migrate(ctx context.Context, db *sql.DB) error {
+	list, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if latest := list[len(list)-1].version; current > latest {
+		return fmt.Errorf("catalog schema version %d is newer than this build supports (%d)", current, latest)
+	}
+
+	for _, m := range list {
+		if m.version <= current {
+			continue
+		}
+		if err := apply(ctx, db, m); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+		}
+		slog.Info("applied catalog migration", "version", m.version, "name", m.name)
+	}
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		m.version, m.name, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,32 @@
+-- アップロードしたファイルのカタログ
+CREATE TABLE models (
+    id                  TEXT PRIMARY KEY,
+    name                TEXT NOT NULL,
+    description         TEXT NOT NULL DEFAULT '',
+    file_name           TEXT NOT NULL,
+    file_type           TEXT NOT NULL,
+    size                INTEGER NOT NULL DEFAULT 0,
+    bucket_key          TEXT NOT NULL,
+    object_key          TEXT NOT NULL,
+    object_id           TEXT NOT NULL UNIQUE,
+    urn                 TEXT NOT NULL UNIQUE,
+    translation_profile TEXT NOT NULL DEFAULT '',
+    status              TEXT NOT NULL,
+    progress            TEXT NOT NULL DEFAULT '',
+    has_thumbnail       INTEGER NOT NULL DEFAULT 0,
+    uploaded_by         TEXT NOT NULL DEFAULT '',
+    created_at          TEXT NOT NULL,
+    updated_at          TEXT NOT NULL
+);
+
+CREATE INDEX models_bucket_key ON models (bucket_key);
+CREATE INDEX models_status ON models (status);
+CREATE INDEX models_created_at ON models (created_at);
+
+CREATE TABLE model_tags (
+    model_id TEXT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+    tag      TEXT NOT NULL,
+    PRIMARY KEY (model_id, tag)
+);
+
+CREATE INDEX model_tags_tag ON model_tags (tag);
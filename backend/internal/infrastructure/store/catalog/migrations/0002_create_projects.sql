@@ -0,0 +1,27 @@
+-- モデルを整理するプロジェクトと入れ子のフォルダ
+CREATE TABLE projects (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by  TEXT NOT NULL DEFAULT '',
+    created_at  TEXT NOT NULL,
+    updated_at  TEXT NOT NULL
+);
+
+CREATE TABLE folders (
+    id         TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    parent_id  TEXT REFERENCES folders (id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- 同じ親に同じ名前のフォルダは作れない（プロジェクト直下はparent_idがNULLのため空文字列に置き換えて比較する）
+CREATE UNIQUE INDEX folders_parent_name ON folders (project_id, COALESCE(parent_id, ''), name COLLATE NOCASE);
+
+-- プロジェクトやフォルダを削除してもモデルはカタログに残す
+ALTER TABLE models ADD COLUMN project_id TEXT REFERENCES projects (id) ON DELETE SET NULL;
+ALTER TABLE models ADD COLUMN folder_id TEXT REFERENCES folders (id) ON DELETE SET NULL;
+
+CREATE INDEX models_project_folder ON models (project_id, folder_id);
@@ -0,0 +1,59 @@
+-- 翻訳の完了後にModel Derivativeから取り出した要素の名前とプロパティの値
+CREATE TABLE model_properties (
+    model_id   TEXT PRIMARY KEY REFERENCES models (id) ON DELETE CASCADE,
+    text       TEXT NOT NULL,
+    indexed_at TEXT NOT NULL
+);
+
+-- モデルの全文検索の索引。モデル・タグ・プロパティの変更はトリガーで反映する
+CREATE VIRTUAL TABLE model_search USING fts5 (
+    model_id UNINDEXED,
+    name,
+    description,
+    file_name,
+    tags,
+    properties,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER models_search_insert AFTER INSERT ON models BEGIN
+    INSERT INTO model_search (model_id, name, description, file_name, tags, properties)
+    VALUES (NEW.id, NEW.name, NEW.description, NEW.file_name, '', '');
+END;
+
+CREATE TRIGGER models_search_update AFTER UPDATE OF name, description, file_name ON models BEGIN
+    UPDATE model_search SET name = NEW.name, description = NEW.description, file_name = NEW.file_name
+    WHERE model_id = NEW.id;
+END;
+
+CREATE TRIGGER models_search_delete AFTER DELETE ON models BEGIN
+    DELETE FROM model_search WHERE model_id = OLD.id;
+END;
+
+CREATE TRIGGER model_tags_search_insert AFTER INSERT ON model_tags BEGIN
+    UPDATE model_search SET tags = (SELECT group_concat(tag, ' ') FROM model_tags WHERE model_id = NEW.model_id)
+    WHERE model_id = NEW.model_id;
+END;
+
+CREATE TRIGGER model_tags_search_delete AFTER DELETE ON model_tags BEGIN
+    UPDATE model_search SET tags = COALESCE((SELECT group_concat(tag, ' ') FROM model_tags WHERE model_id = OLD.model_id), '')
+    WHERE model_id = OLD.model_id;
+END;
+
+CREATE TRIGGER model_properties_search_insert AFTER INSERT ON model_properties BEGIN
+    UPDATE model_search SET properties = NEW.text WHERE model_id = NEW.model_id;
+END;
+
+CREATE TRIGGER model_properties_search_update AFTER UPDATE ON model_properties BEGIN
+    UPDATE model_search SET properties = NEW.text WHERE model_id = NEW.model_id;
+END;
+
+CREATE TRIGGER model_properties_search_delete AFTER DELETE ON model_properties BEGIN
+    UPDATE model_search SET properties = '' WHERE model_id = OLD.model_id;
+END;
+
+-- 既存のモデルを索引に追加する
+INSERT INTO model_search (model_id, name, description, file_name, tags, properties)
+SELECT id, name, description, file_name,
+    COALESCE((SELECT group_concat(tag, ' ') FROM model_tags WHERE model_id = models.id), ''), ''
+FROM models;
@@ -0,0 +1,17 @@
+-- モデルの共有リンク。トークンとパスワードはハッシュのみを保存する
+CREATE TABLE shares (
+    id             TEXT PRIMARY KEY,
+    model_id       TEXT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+    urn            TEXT NOT NULL,
+    token_hash     TEXT NOT NULL UNIQUE,
+    password_hash  TEXT NOT NULL DEFAULT '',
+    max_views      INTEGER NOT NULL DEFAULT 0,
+    view_count     INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TEXT,
+    expires_at     TEXT NOT NULL,
+    revoked_at     TEXT,
+    created_by     TEXT NOT NULL DEFAULT '',
+    created_at     TEXT NOT NULL
+);
+
+CREATE INDEX shares_model ON shares (model_id, created_at);
@@ -0,0 +1,282 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ModelRepository はカタログのモデルをSQLiteに保存するリポジトリ実装
+type ModelRepository struct {
+	db *sql.DB
+}
+
+// NewModelRepository は新しいModelRepositoryを作成します
+func NewModelRepository(db *sql.DB) *ModelRepository {
+	return &ModelRepository{db: db}
+}
+
+// インターフェースの実装を確認
+var _ domain.ModelRepository = (*ModelRepository)(nil)
+
+const modelColumns = `id, name, description, file_name, file_type, size, bucket_key, object_key, object_id, urn,
+	translation_profile, status, progress, has_thumbnail, uploaded_by, created_at, updated_at, project_id, folder_id`
+
+// 並び順に指定できる項目と列
+var modelSortColumns = map[string]string{
+	"createdAt": "created_at",
+	"name":      "name COLLATE NOCASE",
+	"size":      "size",
+}
+
+// Save はモデルとタグを追加または更新します
+func (r *ModelRepository) Save(ctx context.Context, model *domain.Model) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO models (`+modelColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name, description = excluded.description, file_name = excluded.file_name,
+			file_type = excluded.file_type, size = excluded.size, bucket_key = excluded.bucket_key,
+			object_key = excluded.object_key, object_id = excluded.object_id, urn = excluded.urn,
+			translation_profile = excluded.translation_profile, status = excluded.status, progress = excluded.progress,
+			has_thumbnail = excluded.has_thumbnail, uploaded_by = excluded.uploaded_by, updated_at = excluded.updated_at,
+			project_id = excluded.project_id, folder_id = excluded.folder_id`,
+		model.ID, model.Name, model.Description, model.FileName, model.FileType, model.Size, model.BucketKey,
+		model.ObjectKey, model.ObjectID, model.URN, model.TranslationProfile, model.Status, model.Progress,
+		model.HasThumbnail, model.UploadedBy, formatTime(model.CreatedAt), formatTime(model.UpdatedAt),
+		nullString(model.ProjectID), nullString(model.FolderID))
+	if err != nil {
+		return fmt.Errorf("failed to save model: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM model_tags WHERE model_id = ?", model.ID); err != nil {
+		return fmt.Errorf("failed to save model tags: %w", err)
+	}
+	for _, tag := range model.Tags {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO model_tags (model_id, tag) VALUES (?, ?)", model.ID, tag); err != nil {
+			return fmt.Errorf("failed to save model tags: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Get はIDのモデルを返します
+func (r *ModelRepository) Get(ctx context.Context, id string) (*domain.Model, error) {
+	return r.getBy(ctx, "id", id)
+}
+
+// GetByObjectID はオブジェクトIDのモデルを返します
+func (r *ModelRepository) GetByObjectID(ctx context.Context, objectID string) (*domain.Model, error) {
+	return r.getBy(ctx, "object_id", objectID)
+}
+
+// GetByURN はURNのモデルを返します
+func (r *ModelRepository) GetByURN(ctx context.Context, urn string) (*domain.Model, error) {
+	return r.getBy(ctx, "urn", urn)
+}
+
+func (r *ModelRepository) getBy(ctx context.Context, column string, value string) (*domain.Model, error) {
+	model, err := scanModel(r.db.QueryRowContext(ctx, "SELECT "+modelColumns+" FROM models WHERE "+column+" = ?", value))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrModelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model: %w", err)
+	}
+	if err := loadTags(ctx, r.db, []*domain.Model{model}); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// List は条件に一致するモデルの1ページと、すべての件数を返します
+func (r *ModelRepository) List(ctx context.Context, filter domain.ModelFilter) (*domain.ModelList, error) {
+	var where []string
+	var args []any
+	for _, c := range []struct {
+		column string
+		value  string
+	}{
+		{"bucket_key", filter.BucketKey},
+		{"status", filter.Status},
+		{"file_type", filter.FileType},
+		{"uploaded_by", filter.UploadedBy},
+		{"project_id", filter.ProjectID},
+	} {
+		if c.value != "" {
+			where = append(where, c.column+" = ?")
+			args = append(args, c.value)
+		}
+	}
+	switch filter.FolderID {
+	case "":
+	case domain.RootFolderID:
+		where = append(where, "folder_id IS NULL")
+	default:
+		where = append(where, "folder_id = ?")
+		args = append(args, filter.FolderID)
+	}
+	if filter.Tag != "" {
+		where = append(where, "id IN (SELECT model_id FROM model_tags WHERE tag = ?)")
+		args = append(args, filter.Tag)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		where = append(where, `(name LIKE ? ESCAPE '\' OR file_name LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+	clause := ""
+	if len(where) > 0 {
+		clause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	order, err := orderBy(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &domain.ModelList{Items: []domain.Model{}, Limit: filter.Limit, Offset: filter.Offset}
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM models"+clause, args...).Scan(&list.Total); err != nil {
+		return nil, fmt.Errorf("failed to count models: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+modelColumns+" FROM models"+clause+" ORDER BY "+order+", id LIMIT ? OFFSET ?",
+		append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	defer rows.Close()
+
+	var models []*domain.Model
+	for rows.Next() {
+		model, err := scanModel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list models: %w", err)
+		}
+		models = append(models, model)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	rows.Close()
+
+	if err := loadTags(ctx, r.db, models); err != nil {
+		return nil, err
+	}
+	for _, model := range models {
+		list.Items = append(list.Items, *model)
+	}
+	return list, nil
+}
+
+// Delete はモデルとタグを削除します
+func (r *ModelRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM models WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete model: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrModelNotFound
+	}
+	return nil
+}
+
+// loadTags はモデルのタグを読み込みます
+func loadTags(ctx context.Context, db *sql.DB, models []*domain.Model) error {
+	if len(models) == 0 {
+		return nil
+	}
+	byID := make(map[string]*domain.Model, len(models))
+	placeholders := make([]string, 0, len(models))
+	args := make([]any, 0, len(models))
+	for _, model := range models {
+		model.Tags = []string{}
+		byID[model.ID] = model
+		placeholders = append(placeholders, "?")
+		args = append(args, model.ID)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT model_id, tag FROM model_tags WHERE model_id IN ("+strings.Join(placeholders, ", ")+") ORDER BY tag", args...)
+	if err != nil {
+		return fmt.Errorf("failed to load model tags: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, tag string
+		if err := rows.Scan(&id, &tag); err != nil {
+			return fmt.Errorf("failed to load model tags: %w", err)
+		}
+		byID[id].Tags = append(byID[id].Tags, tag)
+	}
+	return rows.Err()
+}
+
+// rowScanner は*sql.Rowと*sql.Rowsに共通のScan
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanModel(row rowScanner) (*domain.Model, error) {
+	var m domain.Model
+	var createdAt, updatedAt string
+	var projectID, folderID sql.NullString
+	err := row.Scan(&m.ID, &m.Name, &m.Description, &m.FileName, &m.FileType, &m.Size, &m.BucketKey, &m.ObjectKey,
+		&m.ObjectID, &m.URN, &m.TranslationProfile, &m.Status, &m.Progress, &m.HasThumbnail, &m.UploadedBy,
+		&createdAt, &updatedAt, &projectID, &folderID)
+	if err != nil {
+		return nil, err
+	}
+	m.ProjectID = projectID.String
+	m.FolderID = folderID.String
+	m.CreatedAt = parseTime(createdAt)
+	m.UpdatedAt = parseTime(updatedAt)
+	return &m, nil
+}
+
+// orderBy は並び順の指定をORDER BY句に変換します
+func orderBy(sort string) (string, error) {
+	if sort == "" {
+		sort = "-createdAt"
+	}
+	field, desc := strings.CutPrefix(sort, "-")
+	column, ok := modelSortColumns[field]
+	if !ok {
+		return "", fmt.Errorf("%w: sort must be createdAt, name or size with an optional - prefix", domain.ErrInvalidModelRequest)
+	}
+	if desc {
+		return column + " DESC", nil
+	}
+	return column + " ASC", nil
+}
+
+// escapeLike はLIKEのワイルドカードをエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// nullString は空文字列をNULLとして保存します
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// 時刻は文字列の比較で並ぶよう、UTCの固定長の形式で保存する
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(s string) time.Time {
+	t, _ := time.Parse(timeLayout, s)
+	return t
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+func TestModelRepositoryListFiltersByProject(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "catalog.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	projects := NewProjectRepository(db)
+	models := NewModelRepository(db)
+
+	now := time.Now().UTC()
+	for _, id := range []string{"p1", "p2"} {
+		if err := projects.SaveProject(ctx, &domain.Project{ID: id, Name: id, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range []struct{ id, projectID string }{
+		{"m1", "p1"},
+		{"m2", "p1"},
+		{"m3", "p2"},
+		{"m4", ""},
+	} {
+		model := &domain.Model{
+			ID:        m.id,
+			Name:      m.id,
+			BucketKey: "bucket",
+			ObjectKey: m.id + ".rvt",
+			ObjectID:  "urn:adsk.objects:os.object:bucket/" + m.id + ".rvt",
+			URN:       "urn-" + m.id,
+			ProjectID: m.projectID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := models.Save(ctx, model); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.ModelFilter
+		want   []string
+	}{
+		{"project", domain.ModelFilter{ProjectID: "p1"}, []string{"m1", "m2"}},
+		{"other project", domain.ModelFilter{ProjectID: "p2"}, []string{"m3"}},
+		{"project root", domain.ModelFilter{ProjectID: "p1", FolderID: domain.RootFolderID}, []string{"m1", "m2"}},
+		{"all", domain.ModelFilter{}, []string{"m1", "m2", "m3", "m4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Sort = "name"
+			tt.filter.Limit = 10
+			list, err := models.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if list.Total != len(tt.want) {
+				t.Errorf("total = %d, want %d", list.Total, len(tt.want))
+			}
+			var got []string
+			for _, m := range list.Items {
+				got = append(got, m.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("items = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("items = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
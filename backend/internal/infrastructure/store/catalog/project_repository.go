@@ -0,0 +1,181 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ProjectRepository はプロジェクトとフォルダをSQLiteに保存するリポジトリ実装
+type ProjectRepository struct {
+	db *sql.DB
+}
+
+// NewProjectRepository は新しいProjectRepositoryを作成します
+func NewProjectRepository(db *sql.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// インターフェースの実装を確認
+var _ domain.ProjectRepository = (*ProjectRepository)(nil)
+
+const projectColumns = `id, name, description, created_by, created_at, updated_at`
+
+const folderColumns = `id, project_id, parent_id, name, created_at, updated_at`
+
+// SaveProject はプロジェクトを追加または更新します
+func (r *ProjectRepository) SaveProject(ctx context.Context, project *domain.Project) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO projects (`+projectColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name, description = excluded.description, updated_at = excluded.updated_at`,
+		project.ID, project.Name, project.Description, project.CreatedBy,
+		formatTime(project.CreatedAt), formatTime(project.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+	return nil
+}
+
+// GetProject はIDのプロジェクトを返します
+func (r *ProjectRepository) GetProject(ctx context.Context, id string) (*domain.Project, error) {
+	project, err := scanProject(r.db.QueryRowContext(ctx, "SELECT "+projectColumns+" FROM projects WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// ListProjects はすべてのプロジェクトを名前順に返します
+func (r *ProjectRepository) ListProjects(ctx context.Context) ([]domain.Project, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+projectColumns+" FROM projects ORDER BY name COLLATE NOCASE, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []domain.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		projects = append(projects, *project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
+
+// DeleteProject はプロジェクトを削除します。フォルダは外部キーで削除され、モデルはプロジェクトから外れます
+func (r *ProjectRepository) DeleteProject(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM projects WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrProjectNotFound
+	}
+	return nil
+}
+
+// SaveFolder はフォルダを追加または更新します
+func (r *ProjectRepository) SaveFolder(ctx context.Context, folder *domain.Folder) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO folders (`+folderColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			parent_id = excluded.parent_id, name = excluded.name, updated_at = excluded.updated_at`,
+		folder.ID, folder.ProjectID, nullString(folder.ParentID), folder.Name,
+		formatTime(folder.CreatedAt), formatTime(folder.UpdatedAt))
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%w: a folder named %q already exists", domain.ErrFolderConflict, folder.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save folder: %w", err)
+	}
+	return nil
+}
+
+// GetFolder はIDのフォルダを返します
+func (r *ProjectRepository) GetFolder(ctx context.Context, id string) (*domain.Folder, error) {
+	folder, err := scanFolder(r.db.QueryRowContext(ctx, "SELECT "+folderColumns+" FROM folders WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrFolderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder: %w", err)
+	}
+	return folder, nil
+}
+
+// ListFolders はプロジェクトのすべてのフォルダを名前順に返します
+func (r *ProjectRepository) ListFolders(ctx context.Context, projectID string) ([]domain.Folder, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+folderColumns+" FROM folders WHERE project_id = ? ORDER BY name COLLATE NOCASE, id", projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+	defer rows.Close()
+
+	folders := []domain.Folder{}
+	for rows.Next() {
+		folder, err := scanFolder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list folders: %w", err)
+		}
+		folders = append(folders, *folder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+	return folders, nil
+}
+
+// DeleteFolder はフォルダを削除します
+func (r *ProjectRepository) DeleteFolder(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM folders WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrFolderNotFound
+	}
+	return nil
+}
+
+func scanProject(row rowScanner) (*domain.Project, error) {
+	var p domain.Project
+	var createdAt, updatedAt string
+	if err := row.Scan(&p.ID, &p.Name, &p.Description, &p.CreatedBy, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	p.CreatedAt = parseTime(createdAt)
+	p.UpdatedAt = parseTime(updatedAt)
+	return &p, nil
+}
+
+func scanFolder(row rowScanner) (*domain.Folder, error) {
+	var f domain.Folder
+	var parentID sql.NullString
+	var createdAt, updatedAt string
+	if err := row.Scan(&f.ID, &f.ProjectID, &parentID, &f.Name, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	f.ParentID = parentID.String
+	f.CreatedAt = parseTime(createdAt)
+	f.UpdatedAt = parseTime(updatedAt)
+	return &f, nil
+}
+
+// isUniqueViolation は一意制約の違反のエラーか判定します
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
@@ -0,0 +1,225 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// SearchIndex はカタログのSQLiteのFTS5によるモデルの全文検索の索引
+// 索引はマイグレーションで作成したトリガーがmodels・model_tags・model_propertiesの変更に合わせて更新します
+type SearchIndex struct {
+	db *sql.DB
+}
+
+// NewSearchIndex は新しいSearchIndexを作成します
+func NewSearchIndex(db *sql.DB) *SearchIndex {
+	return &SearchIndex{db: db}
+}
+
+// インターフェースの実装を確認
+var _ domain.SearchIndex = (*SearchIndex)(nil)
+
+// bm25の列ごとの重み（model_id, name, description, file_name, tags, properties）
+// 名前やタグに一致したモデルを、多くの要素のプロパティに一致したモデルより上位にする
+const searchWeights = "0.0, 10.0, 2.0, 5.0, 5.0, 1.0"
+
+// 絞り込みの候補として返す値の数の上限
+const maxFacetValues = 50
+
+// 結合した検索で使う、m.を付けたmodelsの列
+var qualifiedModelColumns = func() string {
+	columns := strings.Split(modelColumns, ",")
+	for i, c := range columns {
+		columns[i] = "m." + strings.TrimSpace(c)
+	}
+	return strings.Join(columns, ", ")
+}()
+
+// searchFilter は検索の絞り込みの1項目
+type searchFilter struct {
+	name   string
+	column string
+	value  string
+}
+
+// Search は条件に一致するモデルを一致の度合いの順に返し、ファイルの種類・プロジェクト・状態ごとの件数を集計します
+func (s *SearchIndex) Search(ctx context.Context, query domain.SearchQuery) (*domain.SearchResult, error) {
+	match, err := matchExpression(query.Query)
+	if err != nil {
+		return nil, err
+	}
+	filters := []searchFilter{
+		{"fileType", "m.file_type", query.FileType},
+		{"projectId", "m.project_id", query.ProjectID},
+		{"status", "m.status", query.Status},
+	}
+
+	result := &domain.SearchResult{Items: []domain.SearchHit{}, Limit: query.Limit, Offset: query.Offset}
+	clause, args := searchWhere(match, filters, "")
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*)"+clause, args...).Scan(&result.Total); err != nil {
+		return nil, fmt.Errorf("failed to search models: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+qualifiedModelColumns+", -bm25(model_search, "+searchWeights+") AS score,"+
+		" snippet(model_search, -1, '[', ']', '…', 12)"+clause+" ORDER BY score DESC, m.id LIMIT ? OFFSET ?",
+		append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search models: %w", err)
+	}
+	defer rows.Close()
+
+	var models []*domain.Model
+	var hits []domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		model, err := scanModel(extraScanner{row: rows, extra: []any{&hit.Score, &hit.Snippet}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search models: %w", err)
+		}
+		models = append(models, model)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search models: %w", err)
+	}
+	rows.Close()
+
+	if err := loadTags(ctx, s.db, models); err != nil {
+		return nil, err
+	}
+	for i, model := range models {
+		hits[i].Model = *model
+		result.Items = append(result.Items, hits[i])
+	}
+
+	for _, facet := range []struct {
+		name string
+		expr string
+		dst  *[]domain.SearchFacet
+	}{
+		{"fileType", "m.file_type", &result.Facets.FileType},
+		{"projectId", "COALESCE(m.project_id, '')", &result.Facets.ProjectID},
+		{"status", "m.status", &result.Facets.Status},
+	} {
+		values, err := s.facet(ctx, match, filters, facet.name, facet.expr)
+		if err != nil {
+			return nil, err
+		}
+		*facet.dst = values
+	}
+	return result, nil
+}
+
+// facet は名前がnameの絞り込み以外を適用した検索結果を、exprの値ごとに数えます
+func (s *SearchIndex) facet(ctx context.Context, match string, filters []searchFilter, name string, expr string) ([]domain.SearchFacet, error) {
+	clause, args := searchWhere(match, filters, name)
+	rows, err := s.db.QueryContext(ctx, "SELECT "+expr+", COUNT(*)"+clause+" GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT ?",
+		append(args, maxFacetValues)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+	defer rows.Close()
+
+	values := []domain.SearchFacet{}
+	for rows.Next() {
+		var v domain.SearchFacet
+		if err := rows.Scan(&v.Value, &v.Count); err != nil {
+			return nil, fmt.Errorf("failed to count search facets: %w", err)
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+// SetProperties はモデルの要素の名前とプロパティの値を索引に登録します。既に登録している場合は置き換えます
+func (s *SearchIndex) SetProperties(ctx context.Context, modelID string, text string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO model_properties (model_id, text, indexed_at) VALUES (?, ?, ?)
+		ON CONFLICT (model_id) DO UPDATE SET text = excluded.text, indexed_at = excluded.indexed_at`,
+		modelID, text, formatTime(time.Now()))
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+		return domain.ErrModelNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to index model properties: %w", err)
+	}
+	return nil
+}
+
+// searchWhere は索引とモデルを結合したFROM句とWHERE句を返します。名前がexcludeの絞り込みは適用しません
+func searchWhere(match string, filters []searchFilter, exclude string) (string, []any) {
+	where := []string{"model_search MATCH ?"}
+	args := []any{match}
+	for _, f := range filters {
+		switch {
+		case f.value == "" || f.name == exclude:
+		case f.name == "projectId" && f.value == domain.RootFolderID:
+			where = append(where, f.column+" IS NULL")
+		default:
+			where = append(where, f.column+" = ?")
+			args = append(args, f.value)
+		}
+	}
+	return " FROM model_search JOIN models m ON m.id = model_search.model_id WHERE " + strings.Join(where, " AND "), args
+}
+
+// matchExpression は検索語をFTS5の検索式に変換します
+// 語と引用符で囲んだ語句はそれぞれ引用符で囲んでAND条件にし、FTS5の演算子として解釈されないようにします
+// 末尾の*は前方一致として残します。閉じていない引用符は文字列の最後までを語句とみなします
+func matchExpression(query string) (string, error) {
+	var terms []string
+	add := func(term string, prefix bool) {
+		term = strings.TrimSpace(strings.ReplaceAll(term, `"`, ""))
+		if !strings.ContainsFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) {
+			return
+		}
+		quoted := `"` + term + `"`
+		if prefix {
+			quoted += "*"
+		}
+		terms = append(terms, quoted)
+	}
+
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		if phrase, ok := strings.CutPrefix(rest, `"`); ok {
+			phrase, rest, _ = strings.Cut(phrase, `"`)
+			add(phrase, false)
+		} else {
+			end := strings.IndexFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if end < 0 {
+				end = len(rest)
+			}
+			word := rest[:end]
+			rest = rest[end:]
+			base, prefix := strings.CutSuffix(word, "*")
+			add(base, prefix)
+		}
+		rest = strings.TrimSpace(rest)
+	}
+
+	if len(terms) == 0 {
+		return "", fmt.Errorf("%w: q must contain at least one word", domain.ErrInvalidSearchRequest)
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// extraScanner はモデルの列に続く列を読み込むScan
+type extraScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (s extraScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
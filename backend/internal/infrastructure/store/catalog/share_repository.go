@@ -0,0 +1,137 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ShareRepository はモデルの共有リンクをSQLiteに保存するリポジトリ実装
+type ShareRepository struct {
+	db *sql.DB
+}
+
+// NewShareRepository は新しいShareRepositoryを作成します
+func NewShareRepository(db *sql.DB) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+// インターフェースの実装を確認
+var _ domain.ShareRepository = (*ShareRepository)(nil)
+
+const shareColumns = `id, model_id, urn, token_hash, password_hash, max_views, view_count, last_viewed_at,
+	expires_at, revoked_at, created_by, created_at`
+
+// SaveShare は共有リンクを追加または更新します。更新できるのは取り消しの時刻のみです
+func (r *ShareRepository) SaveShare(ctx context.Context, share *domain.Share) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO shares (`+shareColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET revoked_at = excluded.revoked_at`,
+		share.ID, share.ModelID, share.URN, share.TokenHash, share.PasswordHash, share.MaxViews, share.ViewCount,
+		nullTime(share.LastViewedAt), formatTime(share.ExpiresAt), nullTime(share.RevokedAt),
+		share.CreatedBy, formatTime(share.CreatedAt))
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+		return domain.ErrModelNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save share: %w", err)
+	}
+	return nil
+}
+
+// GetShare はIDの共有リンクを返します
+func (r *ShareRepository) GetShare(ctx context.Context, id string) (*domain.Share, error) {
+	return r.getShare(ctx, "id", id)
+}
+
+// GetShareByTokenHash はトークンのハッシュが一致する共有リンクを返します
+func (r *ShareRepository) GetShareByTokenHash(ctx context.Context, tokenHash string) (*domain.Share, error) {
+	return r.getShare(ctx, "token_hash", tokenHash)
+}
+
+func (r *ShareRepository) getShare(ctx context.Context, column string, value string) (*domain.Share, error) {
+	share, err := scanShare(r.db.QueryRowContext(ctx, "SELECT "+shareColumns+" FROM shares WHERE "+column+" = ?", value))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrShareNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share: %w", err)
+	}
+	return share, nil
+}
+
+// ListShares はモデルの共有リンクを新しい順に返します
+func (r *ShareRepository) ListShares(ctx context.Context, modelID string) ([]domain.Share, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+shareColumns+" FROM shares WHERE model_id = ? ORDER BY created_at DESC, id", modelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+	defer rows.Close()
+
+	shares := []domain.Share{}
+	for rows.Next() {
+		share, err := scanShare(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list shares: %w", err)
+		}
+		shares = append(shares, *share)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+	return shares, nil
+}
+
+// RecordShareView は閲覧の回数を1増やします
+// 同時に引き換えても上限を超えないよう、回数の確認と更新を1つの文で行います
+func (r *ShareRepository) RecordShareView(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE shares SET view_count = view_count + 1, last_viewed_at = ?
+		WHERE id = ? AND (max_views = 0 OR view_count < max_views)`, formatTime(at), id)
+	if err != nil {
+		return fmt.Errorf("failed to record share view: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to record share view: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("%w: view limit reached", domain.ErrShareUnavailable)
+	}
+	return nil
+}
+
+func scanShare(row rowScanner) (*domain.Share, error) {
+	var s domain.Share
+	var lastViewedAt, revokedAt sql.NullString
+	var expiresAt, createdAt string
+	if err := row.Scan(&s.ID, &s.ModelID, &s.URN, &s.TokenHash, &s.PasswordHash, &s.MaxViews, &s.ViewCount, &lastViewedAt,
+		&expiresAt, &revokedAt, &s.CreatedBy, &createdAt); err != nil {
+		return nil, err
+	}
+	s.HasPassword = s.PasswordHash != ""
+	s.LastViewedAt = parseNullTime(lastViewedAt)
+	s.ExpiresAt = parseTime(expiresAt)
+	s.RevokedAt = parseNullTime(revokedAt)
+	s.CreatedAt = parseTime(createdAt)
+	return &s, nil
+}
+
+func nullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(*t), Valid: true}
+}
+
+func parseNullTime(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t := parseTime(s.String)
+	return &t
+}
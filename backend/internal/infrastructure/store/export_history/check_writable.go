@@ -0,0 +1,20 @@
+package export_history
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// CheckWritable はエクスポートの保存先に書き込めるか確認します
+func (r *ExportHistoryRepository) CheckWritable() error {
+	f, err := os.CreateTemp(filepath.Join(r.dir, "files"), ".probe-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write([]byte("ok")); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,39 @@
+package export_history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ExportHistoryRepository はエクスポート履歴をURNごとのJSONファイルに保存するリポジトリ実装
+// 出力ファイルはfiles/ディレクトリにエクスポートIDの名前で保存します
+type ExportHistoryRepository struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewExportHistoryRepository は新しいExportHistoryRepositoryを作成します
+func NewExportHistoryRepository(dir string) (*ExportHistoryRepository, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "files"), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	return &ExportHistoryRepository{dir: dir}, nil
+}
+
+// historyPath はURNの履歴ファイルのパスを返します
+func (r *ExportHistoryRepository) historyPath(urn string) string {
+	return filepath.Join(r.dir, urn+".json")
+}
+
+// filePath はエクスポートの出力ファイルのパスを返します
+func (r *ExportHistoryRepository) filePath(record *domain.ExportRecord) string {
+	return filepath.Join(r.dir, "files", record.ID+filepath.Ext(record.FileName))
+}
+
+// インターフェースの実装を確認
+var _ domain.ExportRepository = (*ExportHistoryRepository)(nil)
@@ -0,0 +1,45 @@
+package export_history
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// SaveFile はエクスポートの出力ファイルを保存します
+func (r *ExportHistoryRepository) SaveFile(record *domain.ExportRecord, write func(w io.Writer) error) (int64, error) {
+	dst := r.filePath(record)
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "export-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// OpenFile はエクスポートの出力ファイルを開きます
+func (r *ExportHistoryRepository) OpenFile(record *domain.ExportRecord) (io.ReadSeekCloser, error) {
+	f, err := os.Open(r.filePath(record))
+	if os.IsNotExist(err) {
+		return nil, domain.ErrExportNotReady
+	}
+	return f, err
+}
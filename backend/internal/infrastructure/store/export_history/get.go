@@ -0,0 +1,39 @@
+package export_history
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Get はエクスポート履歴を1件取得します
+func (r *ExportHistoryRepository) Get(urn string, id string) (*domain.ExportRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records, err := r.load(urn)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i], nil
+		}
+	}
+	return nil, domain.ErrExportNotFound
+}
+
+// ListByURN はURNのエクスポート履歴を新しい順に返します
+func (r *ExportHistoryRepository) ListByURN(urn string) ([]domain.ExportRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records, err := r.load(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ExportRecord, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		result = append(result, records[i])
+	}
+	return result, nil
+}
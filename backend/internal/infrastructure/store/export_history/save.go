@@ -0,0 +1,60 @@
+package export_history
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Save はエクスポート履歴を追加または更新します
+func (r *ExportHistoryRepository) Save(record *domain.ExportRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records, err := r.load(record.URN)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range records {
+		if records[i].ID == record.ID {
+			records[i] = *record
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		records = append(records, *record)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// 書き込み途中で壊れないよう一時ファイルに書いてから置き換える
+	tmp := r.historyPath(record.URN) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.historyPath(record.URN))
+}
+
+// load はURNの履歴を読み込みます。履歴がない場合は空を返します
+func (r *ExportHistoryRepository) load(urn string) ([]domain.ExportRecord, error) {
+	data, err := os.ReadFile(r.historyPath(urn))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.ExportRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,105 @@
+package grant_store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// GrantStore は権限の付与を1つのJSONファイルに保存するリポジトリ実装
+// 認可はリクエストごとに行うため、内容はメモリに保持し、変更時のみファイルへ書き込みます
+type GrantStore struct {
+	path   string
+	mu     sync.RWMutex
+	grants []domain.Grant
+}
+
+// NewGrantStore はファイルから権限の付与を読み込んだGrantStoreを作成します。ファイルがない場合は空で始めます
+func NewGrantStore(path string) (*GrantStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create grant directory: %w", err)
+	}
+
+	s := &GrantStore{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.grants); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// List は権限の付与を作成順に返します
+func (s *GrantStore) List() ([]domain.Grant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.Grant(nil), s.grants...), nil
+}
+
+// Save は権限の付与を追加または更新します
+func (s *GrantStore) Save(grant *domain.Grant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grants := append([]domain.Grant(nil), s.grants...)
+	replaced := false
+	for i := range grants {
+		if grants[i].ID == grant.ID {
+			grants[i] = *grant
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		grants = append(grants, *grant)
+	}
+	return s.write(grants)
+}
+
+// Delete は権限の付与を削除します
+func (s *GrantStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grants := make([]domain.Grant, 0, len(s.grants))
+	for _, grant := range s.grants {
+		if grant.ID != id {
+			grants = append(grants, grant)
+		}
+	}
+	if len(grants) == len(s.grants) {
+		return domain.ErrGrantNotFound
+	}
+	return s.write(grants)
+}
+
+// write はファイルを書き換えてから、メモリの内容を置き換えます
+func (s *GrantStore) write(grants []domain.Grant) error {
+	data, err := json.MarshalIndent(grants, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// 書き込み途中で壊れないよう一時ファイルに書いてから置き換える
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	s.grants = grants
+	return nil
+}
+
+// インターフェースの実装を確認
+var _ domain.GrantRepository = (*GrantStore)(nil)
@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 計装ライブラリ名
+const instrumentationName = "github.com/maixhashi/nextgo-aps-viewer/backend"
+
+// スパンの属性のキー
+const (
+	BucketKey  = attribute.Key("aps.bucket_key")
+	ObjectKey  = attribute.Key("aps.object_key")
+	ObjectID   = attribute.Key("aps.object_id")
+	URN        = attribute.Key("aps.urn")
+	ModelGUID  = attribute.Key("aps.model_guid")
+	PartNumber = attribute.Key("aps.part_number")
+	Parts      = attribute.Key("aps.parts")
+	PartBytes  = attribute.Key("aps.part_bytes")
+	API        = attribute.Key("aps.api")
+	RetryCount = attribute.Key("aps.retry_count")
+	// マニフェストの翻訳の状態（pending, inprogress, success, failed, timeout）
+	TranslationStatus = attribute.Key("aps.translation_status")
+)
+
+// Options はトレースの送信の設定
+type Options struct {
+	// 送信先（none, stdout, otlp）。noneの場合はスパンを作成しません
+	Exporter string `yaml:"exporter"`
+	// OTLP/HTTPの送信先（例: http://localhost:4318）。空の場合はOTEL_EXPORTER_OTLP_ENDPOINTまたはhttp://localhost:4318
+	OTLPEndpoint string `yaml:"otlpEndpoint"`
+	// service.name
+	ServiceName string `yaml:"serviceName"`
+	// サンプリングする割合（0〜1）。親スパンがある場合は親の判定に従います
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// DefaultOptions は既定のトレースの設定を返します
+func DefaultOptions() Options {
+	return Options{
+		Exporter:    "none",
+		ServiceName: "aps-viewer-backend",
+		SampleRatio: 1,
+	}
+}
+
+// Setup はトレースの送信先を設定し、終了時に未送信のスパンを送る関数を返します
+// W3C Trace Contextの伝搬は送信先によらず有効にします
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch opts.Exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case "otlp":
+		var exporterOpts []otlptracehttp.Option
+		if opts.OTLPEndpoint != "" {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithEndpointURL(opts.OTLPEndpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, exporterOpts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter: %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(opts.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer はこのアプリケーションのスパンを作成するTracerを返します
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start は属性を付けた内部処理のスパンを開始します
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End はエラーがあればスパンに記録してから終了します
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
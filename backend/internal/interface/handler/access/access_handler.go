@@ -0,0 +1,17 @@
+package access
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// AccessHandler は権限の付与と呼び出し元の確認のハンドラ
+type AccessHandler struct {
+	accessUseCase domain.AccessUseCase
+}
+
+// NewAccessHandler は新しいAccessHandlerを作成します
+func NewAccessHandler(accessUseCase domain.AccessUseCase) *AccessHandler {
+	return &AccessHandler{
+		accessUseCase: accessUseCase,
+	}
+}
@@ -0,0 +1,85 @@
+package access
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 権限の付与の一覧
+// @Description 呼び出し元やグループに付与した、バケットの接頭辞ごとのロールを作成順に返します
+// @Tags Access
+// @Produce json
+// @Success 200 {array} domain.Grant
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/grants [get]
+func (h *AccessHandler) ListGrants(w http.ResponseWriter, r *http.Request) {
+	grants, err := h.accessUseCase.ListGrants(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(grants)
+}
+
+// @Summary 権限の付与
+// @Description 呼び出し元（APIキーの名前、JWTのsub）または"group:"に続けたグループに、接頭辞で指定したバケットに対するロールを付与します
+// @Tags Access
+// @Accept json
+// @Produce json
+// @Param request body domain.GrantRequest true "付与する内容（roleはviewer、uploader、admin。bucketPrefixが空の場合はすべてのバケット）"
+// @Success 201 {object} domain.Grant
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/grants [post]
+func (h *AccessHandler) CreateGrant(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.GrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	grant, err := h.accessUseCase.CreateGrant(r.Context(), &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(grant)
+}
+
+// @Summary 権限の付与の取り消し
+// @Description 権限の付与を削除します
+// @Tags Access
+// @Param grantId path string true "権限の付与のID"
+// @Success 204
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/grants/{grantId} [delete]
+func (h *AccessHandler) DeleteGrant(w http.ResponseWriter, r *http.Request) {
+	if err := h.accessUseCase.DeleteGrant(r.Context(), mux.Vars(r)["grantId"]); err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
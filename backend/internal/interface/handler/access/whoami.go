@@ -0,0 +1,26 @@
+package access
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// @Summary 呼び出し元の確認
+// @Description 認証された呼び出し元のID・認証方法・ロール・グループを返します。フロントエンドで操作の表示を切り替えるのに使います
+// @Tags Access
+// @Produce json
+// @Success 200 {object} domain.Principal
+// @Failure 401 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/auth/me [get]
+func (h *AccessHandler) WhoAmI(w http.ResponseWriter, r *http.Request) {
+	principal, ok := domain.PrincipalFromContext(r.Context())
+	if !ok {
+		principal = domain.AnonymousPrincipal
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(principal)
+}
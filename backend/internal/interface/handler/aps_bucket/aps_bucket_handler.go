@@ -8,10 +8,6 @@ type APSBucketHandler struct {
     bucketUseCase *aps_bucket.APSBucketUseCase
 }
 
-type ErrorResponse struct {
-    Message string `json:"message"`
-}
-
 func NewAPSBucketHandler(bucketUseCase *aps_bucket.APSBucketUseCase) *APSBucketHandler {
     return &APSBucketHandler{
         bucketUseCase: bucketUseCase,
@@ -3,6 +3,8 @@ package aps_bucket
 import (
     "encoding/json"
     "net/http"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSバケット作成
@@ -11,12 +13,17 @@ import (
 // @Accept json
 // @Produce json
 // @Success 200 {object} domain.APSBucket
-// @Failure 500 {object} ErrorResponse
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets [post]
 func (h *APSBucketHandler) CreateBucket(w http.ResponseWriter, r *http.Request) {
-    bucket, err := h.bucketUseCase.CreateBucket()
+    bucket, err := h.bucketUseCase.CreateBucket(r.Context())
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
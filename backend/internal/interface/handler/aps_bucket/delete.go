@@ -3,6 +3,8 @@ package aps_bucket
 import (
     "net/http"
     "github.com/gorilla/mux"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSバケット削除
@@ -12,15 +14,20 @@ import (
 // @Produce json
 // @Param bucketKey path string true "バケットキー"
 // @Success 200 
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets/{bucketKey} [delete]
 func (h *APSBucketHandler) DeleteBucket(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
     bucketKey := vars["bucketKey"]
 
-    if err := h.bucketUseCase.DeleteBucket(bucketKey); err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+    if err := h.bucketUseCase.DeleteBucket(r.Context(), bucketKey); err != nil {
+        problem.WriteError(w, r, err)
         return
     }
 
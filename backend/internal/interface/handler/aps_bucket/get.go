@@ -3,6 +3,8 @@ package aps_bucket
 import (
     "encoding/json"
     "net/http"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary Get buckets list
@@ -12,13 +14,19 @@ import (
 // @Produce json
 // @Security Bearer
 // @Success 200 {array} domain.APSBucket
-// @Failure 400 {object} ErrorResponse
-// @Failure 401 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets [get]
 func (h *APSBucketHandler) GetBuckets(w http.ResponseWriter, r *http.Request) {
     bucketsResp, err := h.bucketUseCase.GetBuckets(r.Context())
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
@@ -4,6 +4,8 @@ import (
     "encoding/json"
     "net/http"
     "github.com/gorilla/mux"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSバケット詳細取得
@@ -13,16 +15,21 @@ import (
 // @Produce json
 // @Param bucketKey path string true "バケットキー"
 // @Success 200 {object} domain.APSBucketDetail
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets/{bucketKey}/details [get]
 func (h *APSBucketHandler) GetBucketDetail(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
     bucketKey := vars["bucketKey"]
 
-    detail, err := h.bucketUseCase.GetBucketDetail(bucketKey)
+    detail, err := h.bucketUseCase.GetBucketDetail(r.Context(), bucketKey)
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
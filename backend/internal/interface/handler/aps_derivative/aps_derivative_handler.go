@@ -0,0 +1,17 @@
+package aps_derivative
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// APSDerivativeHandler はModel Derivativeの派生ファイルのハンドラ
+type APSDerivativeHandler struct {
+	derivativeUseCase domain.APSDerivativeUseCase
+}
+
+// NewAPSDerivativeHandler は新しいAPSDerivativeHandlerを作成します
+func NewAPSDerivativeHandler(derivativeUseCase domain.APSDerivativeUseCase) *APSDerivativeHandler {
+	return &APSDerivativeHandler{
+		derivativeUseCase: derivativeUseCase,
+	}
+}
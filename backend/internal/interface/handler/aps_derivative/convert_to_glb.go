@@ -0,0 +1,119 @@
+package aps_derivative
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// ConvertToGLBRequest はGLB変換のリクエストボディ
+type ConvertToGLBRequest struct {
+	ModelGUID string `json:"modelGuid"`
+	ObjectIDs []int  `json:"objectIds"`
+}
+
+// @Summary OBJ派生ファイルからGLBへの変換
+// @Description OBJ派生ファイルを作成してバイナリglTFに変換します。変換中は202を返すため、successになるまで再送してください
+// @Tags APS Derivative
+// @Accept json
+// @Produce json
+// @Param urn path string true "Base64エンコードされたURN"
+// @Param request body ConvertToGLBRequest true "モデルビューのGUIDと対象のobjectIds（省略時はモデル全体。objectIdsにはmodelGuidが必要）"
+// @Success 200 {object} domain.GLBConversion
+// @Success 202 {object} domain.GLBConversion
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/glb [post]
+func (h *APSDerivativeHandler) ConvertToGLB(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+
+	var reqBody ConvertToGLBRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	conversion, err := h.derivativeUseCase.ConvertToGLB(r.Context(), urn, reqBody.ModelGUID, reqBody.ObjectIDs)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	writeConversion(w, conversion)
+}
+
+// @Summary 変換済みGLBの取得
+// @Description 変換済みのGLBを返します。React Three FiberのuseGLTFから直接読み込めます。変換中は202と変換状況を返します
+// @Tags APS Derivative
+// @Produce model/gltf-binary
+// @Param urn path string true "Base64エンコードされたURN"
+// @Param modelGuid query string false "モデルビューのGUID"
+// @Param objectIds query string false "カンマ区切りのobjectIds（modelGuidが必要）"
+// @Success 200 {file} file
+// @Success 202 {object} domain.GLBConversion
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/glb [get]
+func (h *APSDerivativeHandler) GetGLB(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+
+	var objectIDs []int
+	if s := r.URL.Query().Get("objectIds"); s != "" {
+		for _, part := range strings.Split(s, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				problem.Write(w, r, http.StatusBadRequest, "invalid objectIds parameter")
+				return
+			}
+			objectIDs = append(objectIDs, id)
+		}
+	}
+
+	conversion, err := h.derivativeUseCase.ConvertToGLB(r.Context(), urn, r.URL.Query().Get("modelGuid"), objectIDs)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	if conversion.Status != "success" {
+		writeConversion(w, conversion)
+		return
+	}
+
+	glb, err := h.derivativeUseCase.OpenGLB(conversion)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	defer glb.Close()
+
+	w.Header().Set("Content-Type", "model/gltf-binary")
+	w.Header().Set("ETag", strconv.Quote(conversion.Key))
+	http.ServeContent(w, r, "", time.Time{}, glb)
+}
+
+// writeConversion は変換状況をJSONで返します。変換が終わっていない場合は202を返します
+func writeConversion(w http.ResponseWriter, conversion *domain.GLBConversion) {
+	w.Header().Set("Content-Type", "application/json")
+	if conversion.Status != "success" {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(conversion)
+}
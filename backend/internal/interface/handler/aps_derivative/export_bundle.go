@@ -0,0 +1,50 @@
+package aps_derivative
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary オフライン閲覧用バンドルのエクスポート
+// @Description マニフェストを辿ってSVFの派生ファイルをすべてダウンロードし、Viewerのローカルモードで読み込めるzipとして返します
+// @Description SVF2のみに翻訳したモデルはローカルモードで読み込めないため422を返します
+// @Tags APS Derivative
+// @Produce application/zip
+// @Param urn path string true "Base64エンコードされたURN"
+// @Success 200 {file} file
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 422 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/bundle [get]
+func (h *APSDerivativeHandler) ExportBundle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+	if urn == "" {
+		problem.Write(w, r, http.StatusBadRequest, "urn is required")
+		return
+	}
+
+	// ダウンロードと検証を終えてからレスポンスを書き始める
+	bundle, err := h.derivativeUseCase.PrepareBundle(r.Context(), urn)
+	if err != nil {
+		problem.WriteError(w, r, fmt.Errorf("failed to prepare bundle: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", urn+".zip"))
+	if err := h.derivativeUseCase.WriteBundleZip(bundle, w); err != nil {
+		// ヘッダー送信後のためステータスは変更できない
+		slog.ErrorContext(r.Context(), "failed to write bundle zip", "urn", urn, "error", err)
+	}
+}
@@ -0,0 +1,75 @@
+package aps_derivative
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// プロキシのルートのパス接頭辞
+const proxyPathPrefix = "/api/v1/aps/proxy/"
+
+// ProxyURN はプロキシのリクエストが参照するモデルのURNを返します。プロキシが許可しないパスの場合は空文字列を返します
+func ProxyURN(r *http.Request) string {
+	urn, _, err := domain.ParseDerivativePath(strings.TrimPrefix(r.URL.EscapedPath(), proxyPathPrefix))
+	if err != nil {
+		return ""
+	}
+	return urn
+}
+
+// @Summary Viewer用派生ファイルプロキシ
+// @Description ViewerのModel Derivativeへのリクエストをサーバーのトークンで転送し、派生ファイルをディスクにキャッシュします
+// @Tags APS Derivative
+// @Produce octet-stream
+// @Param path path string true "developer.api.autodesk.com以下のパス（例: modelderivative/v2/designdata/{urn}/manifest）"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/proxy/{path} [get]
+func (h *APSDerivativeHandler) ProxyDerivative(w http.ResponseWriter, r *http.Request) {
+	// 派生URNに含まれる%2Fを保つため、エスケープされたままのパスを転送する
+	path := strings.TrimPrefix(r.URL.EscapedPath(), proxyPathPrefix)
+
+	resp, err := h.derivativeUseCase.ProxyDerivative(r.Context(), &domain.DerivativeProxyRequest{
+		Method:   r.Method,
+		Path:     path,
+		RawQuery: r.URL.RawQuery,
+		Header:   r.Header,
+	})
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	WriteProxyResponse(w, r, resp)
+}
+
+// WriteProxyResponse はプロキシのレスポンスを書き込み、キャッシュから返したかをX-Cacheで示します
+func WriteProxyResponse(w http.ResponseWriter, r *http.Request, resp *domain.DerivativeProxyResponse) {
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+
+	// キャッシュにヒットした場合はRangeやHEADの処理をServeContentに任せる
+	if resp.Content != nil {
+		defer resp.Content.Close()
+		w.Header().Set("X-Cache", "HIT")
+		http.ServeContent(w, r, "", resp.ModTime, resp.Content)
+		return
+	}
+
+	defer resp.Body.Close()
+	w.Header().Set("X-Cache", "MISS")
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		slog.WarnContext(r.Context(), "failed to stream derivative", "error", err)
+	}
+}
@@ -0,0 +1,17 @@
+package aps_export
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// APSExportHandler は選択した要素のエクスポートのハンドラ
+type APSExportHandler struct {
+	exportUseCase domain.APSExportUseCase
+}
+
+// NewAPSExportHandler は新しいAPSExportHandlerを作成します
+func NewAPSExportHandler(exportUseCase domain.APSExportUseCase) *APSExportHandler {
+	return &APSExportHandler{
+		exportUseCase: exportUseCase,
+	}
+}
@@ -0,0 +1,47 @@
+package aps_export
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 選択した要素のエクスポート作成
+// @Description モデルビューのGUIDとobjectIdsを指定して、選択した要素をOBJ（MTLとのzip）またはSTL（ascii/binary）としてエクスポートします
+// @Tags APS Export
+// @Accept json
+// @Produce json
+// @Param urn path string true "Base64エンコードされたURN"
+// @Param request body domain.ExportRequest true "エクスポートの内容（formatはobjまたはstl、encodingはstlの場合のみasciiまたはbinary）"
+// @Success 202 {object} domain.ExportRecord
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/exports [post]
+func (h *APSExportHandler) CreateExport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+
+	var reqBody domain.ExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	record, err := h.exportUseCase.CreateExport(r.Context(), urn, &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(record)
+}
@@ -0,0 +1,47 @@
+package aps_export
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary エクスポートのダウンロード
+// @Description 完了したエクスポートのファイルをダウンロードします。何度でもダウンロードできます
+// @Tags APS Export
+// @Produce octet-stream
+// @Param urn path string true "Base64エンコードされたURN"
+// @Param exportId path string true "エクスポートID"
+// @Success 200 {file} file
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 409 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/exports/{exportId}/download [get]
+func (h *APSExportHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+	exportID := vars["exportId"]
+
+	record, file, err := h.exportUseCase.OpenExportFile(r.Context(), urn, exportID)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	modTime := time.Time{}
+	if record.CompletedAt != nil {
+		modTime = *record.CompletedAt
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", record.FileName))
+	http.ServeContent(w, r, record.FileName, modTime, file)
+}
@@ -0,0 +1,67 @@
+package aps_export
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary エクスポート履歴の取得
+// @Description モデルのエクスポート履歴を新しい順に返します
+// @Tags APS Export
+// @Produce json
+// @Param urn path string true "Base64エンコードされたURN"
+// @Success 200 {array} domain.ExportRecord
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/exports [get]
+func (h *APSExportHandler) ListExports(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+
+	records, err := h.exportUseCase.ListExports(r.Context(), urn)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+// @Summary エクスポートの取得
+// @Description エクスポートの状況を返します
+// @Tags APS Export
+// @Produce json
+// @Param urn path string true "Base64エンコードされたURN"
+// @Param exportId path string true "エクスポートID"
+// @Success 200 {object} domain.ExportRecord
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/aps/objects/{urn}/exports/{exportId} [get]
+func (h *APSExportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	urn := vars["urn"]
+	exportID := vars["exportId"]
+
+	record, err := h.exportUseCase.GetExport(r.Context(), urn, exportID)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(record)
+}
@@ -1,22 +1,42 @@
 package aps_object
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
+// UploadLimits はアップロードで受け付けるリクエストボディの上限
+type UploadLimits struct {
+	// マルチパートでのアップロードの上限バイト数
+	MaxBytes int64
+	// 署名付きURLへのPUTで受け付けるパートの上限バイト数
+	MaxPartBytes int64
+}
+
 // APSObjectHandler はAPSオブジェクトのハンドラ
 type APSObjectHandler struct {
 	objectUseCase domain.APSObjectUseCase
+	limits        UploadLimits
 }
 
 // NewAPSObjectHandler は新しいAPSObjectHandlerを作成します
-func NewAPSObjectHandler(objectUseCase domain.APSObjectUseCase) *APSObjectHandler {
+func NewAPSObjectHandler(objectUseCase domain.APSObjectUseCase, limits UploadLimits) *APSObjectHandler {
 	return &APSObjectHandler{
 		objectUseCase: objectUseCase,
+		limits:        limits,
 	}
 }
 
-// ErrorResponse はエラーレスポンスの構造体
-type ErrorResponse struct {
-	Error string `json:"error"`
+// writeBodyError はリクエストボディを読み込めなかった場合のエラーを返します
+// 上限を超えた場合は413、それ以外は400を返します
+func writeBodyError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		problem.WriteError(w, r, err)
+		return
+	}
+	problem.Write(w, r, http.StatusBadRequest, message+": "+err.Error())
 }
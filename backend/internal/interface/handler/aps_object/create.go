@@ -4,6 +4,8 @@ import (
     "encoding/json"
     "net/http"
     "github.com/gorilla/mux"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSオブジェクトの作成完了
@@ -15,8 +17,13 @@ import (
 // @Param objectKey path string true "オブジェクトキー"
 // @Param uploadKey body string true "アップロードキー"
 // @Success 200 {object} domain.APSObject
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets/{bucketKey}/objects/{objectKey}/signeds3upload [post]
 func (h *APSObjectHandler) CreateObject(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
@@ -27,13 +34,13 @@ func (h *APSObjectHandler) CreateObject(w http.ResponseWriter, r *http.Request)
         UploadKey string `json:"uploadKey"`
     }
     if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-        http.Error(w, "invalid request body", http.StatusBadRequest)
+        problem.Write(w, r, http.StatusBadRequest, "invalid request body")
         return
     }
 
-    apsObject, err := h.objectUseCase.CreateObject(bucketKey, objectKey, reqBody.UploadKey)
+    apsObject, err := h.objectUseCase.CreateObject(r.Context(), bucketKey, objectKey, reqBody.UploadKey)
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
@@ -4,6 +4,8 @@ import (
     "encoding/json"
     "net/http"
     "github.com/gorilla/mux"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary オブジェクトURNのBase64エンコード
@@ -13,8 +15,12 @@ import (
 // @Produce json
 // @Param objectId path string true "オブジェクトID"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/objects/{objectId}/base64urn [get]
 func (h *APSObjectHandler) GenerateBase64EncodedURN(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
@@ -22,7 +28,7 @@ func (h *APSObjectHandler) GenerateBase64EncodedURN(w http.ResponseWriter, r *ht
     
     base64URN, err := h.objectUseCase.GenerateBase64EncodedURN(objectId)
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
@@ -2,12 +2,14 @@ package aps_object
 
 import (
 	"encoding/json"
-	"fmt"
+	"log/slog"
 	"net/http"
 	"path/filepath"
 	"strconv"
 
 	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary S3署名付きURLの取得
@@ -19,15 +21,21 @@ import (
 // @Param file formData file true "アップロードするファイル"
 // @Param parts query int false "パート数" default(1)
 // @Success 200 {object} domain.APSObject
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 413 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets/{bucketKey}/objects/signeds3upload [post]
 func (h *APSObjectHandler) GetS3SignedURLs(w http.ResponseWriter, r *http.Request) {
 	// URLパラメータからバケットキーを取得
 	vars := mux.Vars(r)
 	bucketKey := vars["bucketKey"]
 	if bucketKey == "" {
-		http.Error(w, "bucket key is required", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "bucket key is required")
 		return
 	}
 
@@ -38,22 +46,23 @@ func (h *APSObjectHandler) GetS3SignedURLs(w http.ResponseWriter, r *http.Reques
 		var err error
 		parts, err = strconv.Atoi(partsStr)
 		if err != nil {
-			http.Error(w, "invalid parts parameter", http.StatusBadRequest)
+			problem.Write(w, r, http.StatusBadRequest, "invalid parts parameter")
 			return
 		}
 	}
 
-	// マルチパートフォームを解析（最大32MBまで）
+	// マルチパートフォームを解析（32MBを超える部分は一時ファイルに保存される）
+	r.Body = http.MaxBytesReader(w, r.Body, h.limits.MaxBytes)
 	err := r.ParseMultipartForm(32 << 20)
 	if err != nil {
-		http.Error(w, "failed to parse multipart form: "+err.Error(), http.StatusBadRequest)
+		writeBodyError(w, r, "failed to parse multipart form", err)
 		return
 	}
 
 	// アップロードされたファイルを取得
 	file, handler, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "failed to get uploaded file: "+err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "failed to get uploaded file: "+err.Error())
 		return
 	}
 	defer file.Close()
@@ -61,7 +70,7 @@ func (h *APSObjectHandler) GetS3SignedURLs(w http.ResponseWriter, r *http.Reques
 	// ファイル名をオブジェクトキーとして使用
 	objectKey := handler.Filename
 	if objectKey == "" {
-		http.Error(w, "file name cannot be empty", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "file name cannot be empty")
 		return
 	}
 
@@ -69,12 +78,12 @@ func (h *APSObjectHandler) GetS3SignedURLs(w http.ResponseWriter, r *http.Reques
 	ext := filepath.Ext(objectKey)
 	// 必要に応じてサポートされているファイル形式をチェック
 	// 例: .rvt, .rfa, .dwg など
-	fmt.Printf("File uploaded: %s, Size: %d bytes, Extension: %s\n", objectKey, handler.Size, ext)
+	slog.InfoContext(r.Context(), "file uploaded", "object_key", objectKey, "size", handler.Size, "extension", ext)
 
 	// ユースケース層に処理を委譲
-	apsObject, err := h.objectUseCase.GetS3SignedURLs(bucketKey, objectKey, parts)
+	apsObject, err := h.objectUseCase.GetS3SignedURLs(r.Context(), bucketKey, objectKey, parts)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, err)
 		return
 	}
 
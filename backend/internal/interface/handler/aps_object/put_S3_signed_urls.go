@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary S3署名付きURLを使用したオブジェクトのアップロード
@@ -14,28 +16,34 @@ import (
 // @Param signedURL query string true "署名付きURL"
 // @Param file body []byte true "アップロードするファイルのバイナリデータ"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 413 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/objects/signeds3upload [put]
 func (h *APSObjectHandler) PutS3SignedURLs(w http.ResponseWriter, r *http.Request) {
 	// クエリパラメータから署名付きURLを取得
 	signedURL := r.URL.Query().Get("signedURL")
 	if signedURL == "" {
-		http.Error(w, "signed URL is required", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "signed URL is required")
 		return
 	}
 
 	// リクエストボディからファイルコンテンツを読み取り
-	fileContent, err := readRequestBody(r)
+	fileContent, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.limits.MaxPartBytes))
 	if err != nil {
-		http.Error(w, "failed to read file content: "+err.Error(), http.StatusBadRequest)
+		writeBodyError(w, r, "failed to read file content", err)
 		return
 	}
 
 	// ユースケース層に処理を委譲
-	err = h.objectUseCase.PutS3SignedURLs(signedURL, fileContent)
+	err = h.objectUseCase.PutS3SignedURLs(r.Context(), signedURL, fileContent)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, err)
 		return
 	}
 
@@ -46,11 +54,3 @@ func (h *APSObjectHandler) PutS3SignedURLs(w http.ResponseWriter, r *http.Reques
 	}
 	json.NewEncoder(w).Encode(response)
 }
-
-// リクエストボディからファイルコンテンツを読み取るヘルパー関数
-func readRequestBody(r *http.Request) ([]byte, error) {
-	// 最大32MBまでに制限
-	limitedReader := http.MaxBytesReader(nil, r.Body, 32<<20)
-	// リクエストボディを読み取り
-	return io.ReadAll(limitedReader)
-}
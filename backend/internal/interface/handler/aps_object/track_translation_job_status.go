@@ -4,6 +4,8 @@ import (
     "encoding/json"
     "net/http"
     "github.com/gorilla/mux"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary 翻訳ジョブのステータス確認
@@ -13,16 +15,21 @@ import (
 // @Produce json
 // @Param urn path string true "Base64エンコードされたURN"
 // @Success 200 {object} domain.TranslationStatus
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/objects/{urn}/status [get]
 func (h *APSObjectHandler) TrackTranslationJobStatus(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
     urn := vars["urn"]
 
-    status, err := h.objectUseCase.TrackTranslationJobStatus(urn)
+    status, err := h.objectUseCase.TrackTranslationJobStatus(r.Context(), urn)
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
@@ -4,6 +4,8 @@ import (
     "encoding/json"
     "net/http"
     "github.com/gorilla/mux"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSオブジェクトの翻訳ジョブ作成
@@ -14,8 +16,13 @@ import (
 // @Param objectId path string true "オブジェクトID"
 // @Param objectKey path string true "オブジェクトキー"
 // @Success 200 {object} domain.TranslateJobResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/objects/{objectId}/translate [post]
 func (h *APSObjectHandler) TranslateObject(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
@@ -25,14 +32,14 @@ func (h *APSObjectHandler) TranslateObject(w http.ResponseWriter, r *http.Reques
     // Base64エンコードされたURNを生成
     base64URN, err := h.objectUseCase.GenerateBase64EncodedURN(objectId)
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
     // 翻訳ジョブを作成
-    response, err := h.objectUseCase.TranslateObject(base64URN, objectKey)
+    response, err := h.objectUseCase.TranslateObject(r.Context(), base64URN, objectKey)
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
 
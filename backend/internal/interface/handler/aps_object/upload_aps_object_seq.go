@@ -10,6 +10,9 @@ import (
 	"strconv"
 
 	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSオブジェクトのアップロードシーケンス
@@ -21,15 +24,21 @@ import (
 // @Param file formData file true "アップロードするファイル"
 // @Param parts query int false "パート数" default(1)
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 413 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/buckets/{bucketKey}/objects/upload [post]
 func (h *APSObjectHandler) UploadAPSObjectSequence(w http.ResponseWriter, r *http.Request) {
 	// URLパラメータからバケットキーを取得
 	vars := mux.Vars(r)
 	bucketKey := vars["bucketKey"]
 	if bucketKey == "" {
-		http.Error(w, "bucket key is required", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "bucket key is required")
 		return
 	}
 
@@ -40,22 +49,23 @@ func (h *APSObjectHandler) UploadAPSObjectSequence(w http.ResponseWriter, r *htt
 		var err error
 		parts, err = strconv.Atoi(partsStr)
 		if err != nil {
-			http.Error(w, "invalid parts parameter", http.StatusBadRequest)
+			problem.Write(w, r, http.StatusBadRequest, "invalid parts parameter")
 			return
 		}
 	}
 
-	// マルチパートフォームを解析（最大32MBまで）
+	// マルチパートフォームを解析（32MBを超える部分は一時ファイルに保存される）
+	r.Body = http.MaxBytesReader(w, r.Body, h.limits.MaxBytes)
 	err := r.ParseMultipartForm(32 << 20)
 	if err != nil {
-		http.Error(w, "failed to parse multipart form: "+err.Error(), http.StatusBadRequest)
+		writeBodyError(w, r, "failed to parse multipart form", err)
 		return
 	}
 
 	// アップロードされたファイルを取得
 	file, handler, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "failed to get uploaded file: "+err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "failed to get uploaded file: "+err.Error())
 		return
 	}
 	defer file.Close()
@@ -63,74 +73,77 @@ func (h *APSObjectHandler) UploadAPSObjectSequence(w http.ResponseWriter, r *htt
 	// ファイル名をオブジェクトキーとして使用
 	objectKey := handler.Filename
 	if objectKey == "" {
-		http.Error(w, "file name cannot be empty", http.StatusBadRequest)
+		problem.Write(w, r, http.StatusBadRequest, "file name cannot be empty")
 		return
 	}
 
 	// ファイル内容を一時ファイルに保存
 	tempFile, err := saveToTempFile(file, objectKey)
 	if err != nil {
-		http.Error(w, "failed to save file: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to save file: %w", err))
 		return
 	}
 	defer os.Remove(tempFile) // 処理完了後に一時ファイルを削除
 
 	// ステップ1: S3署名付きURLを取得
-	apsObject, err := h.objectUseCase.GetS3SignedURLs(bucketKey, objectKey, parts)
+	apsObject, err := h.objectUseCase.GetS3SignedURLs(r.Context(), bucketKey, objectKey, parts)
 	if err != nil {
-		http.Error(w, "failed to get signed URLs: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to get signed URLs: %w", err))
 		return
 	}
 
 	// URLが取得できなかった場合
 	if len(apsObject.URLs) == 0 {
-		http.Error(w, "no signed URLs returned", http.StatusInternalServerError)
+		problem.Write(w, r, http.StatusInternalServerError, "no signed URLs returned")
 		return
 	}
 
 	// ステップ2: 一時ファイルの内容を読み込み
 	fileContent, err := os.ReadFile(tempFile)
 	if err != nil {
-		http.Error(w, "failed to read temp file: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to read temp file: %w", err))
 		return
 	}
 
 	// ステップ3: S3署名付きURLを使用してファイルをアップロード
 	// 現在の実装では単一パートのみサポート
 	for i, signedURL := range apsObject.URLs {
-		err = h.objectUseCase.PutS3SignedURLs(signedURL, fileContent)
+		partCtx, span := tracing.Start(r.Context(), "UploadPart",
+			tracing.ObjectKey.String(objectKey), tracing.PartNumber.Int(i+1), tracing.Parts.Int(len(apsObject.URLs)))
+		err = h.objectUseCase.PutS3SignedURLs(partCtx, signedURL, fileContent)
+		tracing.End(span, err)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to upload file part %d: %s", i+1, err.Error()), http.StatusInternalServerError)
+			problem.WriteError(w, r, fmt.Errorf("failed to upload file part %d: %w", i+1, err))
 			return
 		}
 	}
 
 	// ステップ4: オブジェクトの作成を完了
-	finalObject, err := h.objectUseCase.CreateObject(bucketKey, objectKey, apsObject.UploadKey)
+	finalObject, err := h.objectUseCase.CreateObject(r.Context(), bucketKey, objectKey, apsObject.UploadKey)
 	if err != nil {
-		http.Error(w, "failed to complete object creation: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to complete object creation: %w", err))
 		return
 	}
 
 	// Base64エンコードされたURNを生成
 	base64URN, err := h.objectUseCase.GenerateBase64EncodedURN(finalObject.ObjectId)
 	if err != nil {
-		http.Error(w, "failed to generate base64 URN: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to generate base64 URN: %w", err))
 		return
 	}
 
 	// ステップ5: 翻訳ジョブを作成
-	translateResponse, err := h.objectUseCase.TranslateObject(base64URN, objectKey)
+	translateResponse, err := h.objectUseCase.TranslateObject(r.Context(), base64URN, objectKey)
 	if err != nil {
-		http.Error(w, "failed to create translation job: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to create translation job: %w", err))
 		return
 	}
 
 	// ステップ6: 翻訳ジョブのステータスを確認
 	// 初回のステータス確認
-	translationStatus, err := h.objectUseCase.TrackTranslationJobStatus(base64URN)
+	translationStatus, err := h.objectUseCase.TrackTranslationJobStatus(r.Context(), base64URN)
 	if err != nil {
-		http.Error(w, "failed to track translation status: "+err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, fmt.Errorf("failed to track translation status: %w", err))
 		return
 	}
 
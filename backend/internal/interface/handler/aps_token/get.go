@@ -3,20 +3,28 @@ package aps_token
 import (
     "encoding/json"
     "net/http"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
 )
 
 // @Summary APSトークン取得
-// @Description 2-legged認証でAPSアクセストークンを取得します
+// @Description 2-legged認証でViewer用のAPSアクセストークンを取得します
+// @Description トークンのスコープはviewables:readのみで、バケットやオブジェクトの作成・変更・削除には使えません
 // @Tags APS Token
 // @Accept json
 // @Produce json
 // @Success 200 {object} domain.APSToken
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/aps/token [post]
 func (h *APSTokenHandler) GetToken(w http.ResponseWriter, r *http.Request) {
-    token, err := h.tokenUseCase.GetToken()
+    token, err := h.tokenUseCase.IssueToken(r.Context())
     if err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
+        problem.WriteError(w, r, err)
         return
     }
     
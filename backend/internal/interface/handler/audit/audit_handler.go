@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// AuditHandler は監査ログの検索と検証のハンドラ
+type AuditHandler struct {
+	auditUseCase domain.AuditUseCase
+}
+
+// NewAuditHandler は新しいAuditHandlerを作成します
+func NewAuditHandler(auditUseCase domain.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}
+}
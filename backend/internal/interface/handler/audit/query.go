@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 監査ログの検索
+// @Description バケットの作成・削除、アップロード、翻訳、トークンの発行、権限の変更、拒否した操作の記録を新しい順に返します
+// @Tags Audit
+// @Produce json
+// @Param principal query string false "呼び出し元のID"
+// @Param action query string false "操作（例: bucket:delete）"
+// @Param target query string false "対象の前方一致（例: bucket:teama-）"
+// @Param outcome query string false "結果（success, failure, denied）"
+// @Param since query string false "この日時以降（RFC 3339）"
+// @Param until query string false "この日時より前（RFC 3339）"
+// @Param limit query int false "件数の上限（最大1000）" default(100)
+// @Success 200 {array} domain.AuditEntry
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/audit [get]
+func (h *AuditHandler) QueryAudit(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.AuditFilter{
+		Principal:    query.Get("principal"),
+		Action:       domain.Action(query.Get("action")),
+		TargetPrefix: query.Get("target"),
+		Outcome:      query.Get("outcome"),
+	}
+
+	var err error
+	if v := query.Get("since"); v != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, v); err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "invalid since parameter")
+			return
+		}
+	}
+	if v := query.Get("until"); v != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, v); err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "invalid until parameter")
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit <= 0 {
+			problem.Write(w, r, http.StatusBadRequest, "invalid limit parameter")
+			return
+		}
+	}
+
+	entries, err := h.auditUseCase.Query(r.Context(), filter)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// @Summary 監査ログの検証
+// @Description すべての記録の通し番号とハッシュの連鎖を確認し、書き換えや削除がないか返します
+// @Tags Audit
+// @Produce json
+// @Success 200 {object} domain.AuditVerification
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/audit/verify [get]
+func (h *AuditHandler) VerifyAudit(w http.ResponseWriter, r *http.Request) {
+	result, err := h.auditUseCase.Verify(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,64 @@
+package embed
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	_ "embed"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// oEmbedのprovider_name
+const providerName = "APS Viewer"
+
+// 埋め込みページで読み込むViewerのバージョン
+const viewerVersion = "7.*"
+
+//go:embed embed.html
+var pageHTML string
+
+var pageTemplate = template.Must(template.New("embed").Parse(pageHTML))
+
+// Options は埋め込みの設定
+type Options struct {
+	// 外部から見たバックエンドのベースURL。空の場合はリクエストのホストから組み立てます
+	PublicURL string
+	// 埋め込みページをiframeで表示できるドメイン
+	Domains []string
+	// oEmbedで返すiframeの既定の幅と高さ
+	Width  int
+	Height int
+}
+
+// EmbedHandler は共有リンクの埋め込みページとoEmbedのハンドラ
+type EmbedHandler struct {
+	shareUseCase domain.ShareUseCase
+	options      Options
+}
+
+// NewEmbedHandler は新しいEmbedHandlerを作成します
+func NewEmbedHandler(shareUseCase domain.ShareUseCase, options Options) *EmbedHandler {
+	return &EmbedHandler{
+		shareUseCase: shareUseCase,
+		options:      options,
+	}
+}
+
+// baseURL は共有リンクのURLの組み立てに使うベースURLを返します
+func (h *EmbedHandler) baseURL(r *http.Request) string {
+	if h.options.PublicURL != "" {
+		return strings.TrimRight(h.options.PublicURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// frameAncestors は埋め込みページを表示できる親ページを制限するCSPを返します
+func (h *EmbedHandler) frameAncestors() string {
+	return strings.Join(append([]string{"frame-ancestors", "'self'"}, h.options.Domains...), " ")
+}
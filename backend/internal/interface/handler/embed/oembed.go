@@ -0,0 +1,96 @@
+package embed
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary oEmbed
+// @Description 共有リンクのURL（/embed/{token}）に対するoEmbed（type=rich）を返します。htmlは埋め込みページを表示するiframeです。認証は不要です
+// @Description 期限切れや取り消し済みの共有リンクは410を返します。パスワードを設定した共有リンクにはサムネイルを含めません
+// @Tags Share
+// @Produce json
+// @Param url query string true "共有リンクのURL"
+// @Param maxwidth query int false "iframeの幅の上限"
+// @Param maxheight query int false "iframeの高さの上限"
+// @Param format query string false "形式（jsonのみ対応）"
+// @Success 200 {object} domain.OEmbed
+// @Failure 400 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 410 {object} problem.Details
+// @Failure 501 {object} problem.Details
+// @Router /oembed [get]
+func (h *EmbedHandler) GetOEmbed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" && format != "json" {
+		problem.Write(w, r, http.StatusNotImplemented, "only json format is supported")
+		return
+	}
+
+	width, height := h.options.Width, h.options.Height
+	for name, dst := range map[string]*int{"maxwidth": &width, "maxheight": &height} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				problem.Write(w, r, http.StatusBadRequest, "invalid "+name+" parameter")
+				return
+			}
+			*dst = min(*dst, n)
+		}
+	}
+
+	base := h.baseURL(r)
+	token, ok := shareToken(query.Get("url"), base)
+	if !ok {
+		problem.Write(w, r, http.StatusNotFound, "url is not a share url of this server")
+		return
+	}
+	shared, err := h.shareUseCase.GetSharedModel(r.Context(), token)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	embedURL := base + domain.ShareEmbedPath + token
+	oembed := domain.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        shared.Model.Name,
+		ProviderName: providerName,
+		ProviderURL:  base,
+		HTML: fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" title="%s" style="border:0" allow="fullscreen" allowfullscreen></iframe>`,
+			html.EscapeString(embedURL), width, height, html.EscapeString(shared.Model.Name)),
+		Width:    width,
+		Height:   height,
+		CacheAge: int(time.Until(shared.Share.ExpiresAt).Seconds()),
+	}
+	if !shared.Share.HasPassword && shared.Model.HasThumbnail {
+		oembed.ThumbnailURL = embedURL + "/thumbnail"
+		oembed.ThumbnailWidth = domain.ShareThumbnailSize
+		oembed.ThumbnailHeight = domain.ShareThumbnailSize
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(oembed)
+}
+
+// shareToken はこのサーバーの共有リンクのURL（ベースURLに/embed/{token}を続けたもの）からトークンを取り出します
+func shareToken(raw string, base string) (string, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	u.RawQuery, u.Fragment = "", ""
+	token, ok := strings.CutPrefix(u.String(), base+domain.ShareEmbedPath)
+	token = strings.TrimSuffix(token, "/")
+	return token, ok && token != "" && !strings.Contains(token, "/")
+}
@@ -0,0 +1,82 @@
+package embed
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// pageData は埋め込みページのテンプレートに渡す値
+type pageData struct {
+	Title         string
+	Error         string
+	Token         string
+	URN           string
+	HasPassword   bool
+	ExchangeURL   string
+	RefreshURL    string
+	ProxyURL      string
+	OEmbedURL     string
+	ViewerVersion string
+}
+
+// @Summary 共有リンクの埋め込みページ
+// @Description 共有リンクのトークンでViewer用トークンを引き換え、APS Viewerでモデルを表示するページを返します。認証は不要です
+// @Description パスワードを設定した共有リンクはページでパスワードを入力します。iframeでの表示は設定したドメインからのみ許可します
+// @Tags Share
+// @Produce html
+// @Param token path string true "共有リンクのトークン"
+// @Success 200 {string} string "HTML"
+// @Failure 404 {string} string "HTML"
+// @Failure 410 {string} string "HTML"
+// @Router /embed/{token} [get]
+func (h *EmbedHandler) GetEmbedPage(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+	base := h.baseURL(r)
+
+	w.Header().Set("Content-Security-Policy", h.frameAncestors())
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	shared, err := h.shareUseCase.GetSharedModel(r.Context(), token)
+	if err != nil {
+		status := problem.StatusOf(err)
+		message := "モデルを表示できません"
+		switch {
+		case errors.Is(err, domain.ErrShareNotFound):
+			message = "共有リンクが見つかりません"
+		case errors.Is(err, domain.ErrShareUnavailable):
+			message = "共有リンクの有効期限が切れたか、取り消されました"
+		case status >= http.StatusInternalServerError:
+			slog.ErrorContext(r.Context(), "failed to render embed page", "error", err)
+		}
+		h.render(w, r, status, &pageData{Title: providerName, Error: message})
+		return
+	}
+
+	h.render(w, r, http.StatusOK, &pageData{
+		Title:         shared.Model.Name,
+		Token:         token,
+		URN:           shared.Share.URN,
+		HasPassword:   shared.Share.HasPassword,
+		ExchangeURL:   base + "/api/v1/public/shares/exchange",
+		RefreshURL:    base + "/api/v1/public/shares/refresh",
+		ProxyURL:      base + "/api/v1/aps/proxy",
+		OEmbedURL:     base + "/oembed?url=" + url.QueryEscape(base+domain.ShareEmbedPath+token),
+		ViewerVersion: viewerVersion,
+	})
+}
+
+func (h *EmbedHandler) render(w http.ResponseWriter, r *http.Request, status int, data *pageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := pageTemplate.Execute(w, data); err != nil {
+		slog.WarnContext(r.Context(), "failed to write embed page", "error", err)
+	}
+}
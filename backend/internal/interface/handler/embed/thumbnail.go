@@ -0,0 +1,29 @@
+package embed
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_derivative"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 共有リンクのサムネイル
+// @Description 共有しているモデルのサムネイル（400x400）を返します。認証は不要です。パスワードを設定した共有リンクは401を返します
+// @Tags Share
+// @Produce png
+// @Param token path string true "共有リンクのトークン"
+// @Success 200 {file} file
+// @Failure 401 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 410 {object} problem.Details
+// @Router /embed/{token}/thumbnail [get]
+func (h *EmbedHandler) GetThumbnail(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.shareUseCase.GetShareThumbnail(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	aps_derivative.WriteProxyResponse(w, r, resp)
+}
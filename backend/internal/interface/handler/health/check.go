@@ -0,0 +1,42 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// @Summary 生存確認
+// @Description プロセスが動作していることを返します。APSやストアへの接続は確認しません
+// @Tags Health
+// @Produce json
+// @Success 200 {object} domain.HealthReport
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, h.healthUseCase.Live())
+}
+
+// @Summary 準備状態の確認
+// @Description APSの認証情報でトークンを取得できるか、OSSへ接続できるか、ローカルのストアに書き込めるかを確認し、項目ごとの結果と所要時間を返します
+// @Description APSの確認結果は一定時間キャッシュします
+// @Tags Health
+// @Produce json
+// @Success 200 {object} domain.HealthReport
+// @Failure 503 {object} domain.HealthReport
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, h.healthUseCase.Ready(r.Context()))
+}
+
+// writeReport は確認結果を返します。失敗した項目がある場合は503を返します
+func writeReport(w http.ResponseWriter, report *domain.HealthReport) {
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,17 @@
+package health
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// HealthHandler は生存確認・準備状態の確認のハンドラ
+type HealthHandler struct {
+	healthUseCase domain.HealthUseCase
+}
+
+// NewHealthHandler は新しいHealthHandlerを作成します
+func NewHealthHandler(healthUseCase domain.HealthUseCase) *HealthHandler {
+	return &HealthHandler{
+		healthUseCase: healthUseCase,
+	}
+}
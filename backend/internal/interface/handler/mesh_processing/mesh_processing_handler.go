@@ -0,0 +1,17 @@
+package mesh_processing
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// MeshProcessingHandler はViewerから抽出したメッシュの後処理のハンドラ
+type MeshProcessingHandler struct {
+	meshUseCase domain.MeshProcessingUseCase
+}
+
+// NewMeshProcessingHandler は新しいMeshProcessingHandlerを作成します
+func NewMeshProcessingHandler(meshUseCase domain.MeshProcessingUseCase) *MeshProcessingHandler {
+	return &MeshProcessingHandler{
+		meshUseCase: meshUseCase,
+	}
+}
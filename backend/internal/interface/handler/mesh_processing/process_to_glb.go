@@ -0,0 +1,64 @@
+package mesh_processing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 抽出したメッシュのGLB変換
+// @Description Viewerから抽出したメッシュ（dbIdごとの頂点とインデックス）を受け取り、頂点の溶接・マテリアルごとの結合・目標三角形数までの間引きを行ってGLBを返します。バウンディングボックスと三角形数はscene.extrasとX-Meshヘッダーに含まれます
+// @Description 間引きで要素を取り除くことはありません。目標の三角形数まで間引けなかった要素は間引く前の形状のまま残し、そのdbIdをscene.extras.unmetDbIdsに、件数をX-Mesh-Unmet-Elementsヘッダーに含めます
+// @Tags Mesh
+// @Accept json
+// @Produce model/gltf-binary
+// @Param request body domain.MeshProcessRequest true "抽出したメッシュと後処理の設定"
+// @Success 200 {file} file
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 413 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/meshes/glb [post]
+func (h *MeshProcessingHandler) ProcessToGLB(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.meshUseCase.Limits().MaxRequestBytes)
+
+	var reqBody domain.MeshProcessRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			problem.WriteError(w, r, err)
+			return
+		}
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var buf bytes.Buffer
+	result, err := h.meshUseCase.ProcessToGLB(r.Context(), &reqBody, &buf)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "model/gltf-binary")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Header().Set("X-Mesh-Input-Triangles", strconv.Itoa(result.InputTriangles))
+	w.Header().Set("X-Mesh-Output-Triangles", strconv.Itoa(result.OutputTriangles))
+	w.Header().Set("X-Mesh-Target-Triangles", strconv.Itoa(result.TargetTriangles))
+	// dbIdの一覧は長くなるため、ヘッダーには件数のみを含める
+	w.Header().Set("X-Mesh-Unmet-Elements", strconv.Itoa(len(result.UnmetDbIDs)))
+	if result.Bounds != nil {
+		b := result.Bounds
+		w.Header().Set("X-Mesh-Bounds", fmt.Sprintf("%g,%g,%g,%g,%g,%g", b.Min[0], b.Min[1], b.Min[2], b.Max[0], b.Max[1], b.Max[2]))
+	}
+	buf.WriteTo(w)
+}
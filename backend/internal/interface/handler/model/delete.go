@@ -0,0 +1,29 @@
+package model
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary モデルの削除
+// @Description カタログからモデルを削除します。OSSのオブジェクトと翻訳した派生ファイルは削除しません
+// @Tags Model
+// @Param modelId path string true "モデルのID"
+// @Success 204
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId} [delete]
+func (h *ModelHandler) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	if err := h.modelUseCase.DeleteModel(r.Context(), mux.Vars(r)["modelId"]); err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
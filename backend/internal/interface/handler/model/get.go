@@ -0,0 +1,34 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary モデルの詳細
+// @Description カタログのモデルを返します
+// @Tags Model
+// @Produce json
+// @Param modelId path string true "モデルのID"
+// @Success 200 {object} domain.Model
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId} [get]
+func (h *ModelHandler) GetModel(w http.ResponseWriter, r *http.Request) {
+	model, err := h.modelUseCase.GetModel(r.Context(), mux.Vars(r)["modelId"])
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model)
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary モデルの一覧
+// @Description アップロードしたモデルのカタログを条件で絞り込み、1ページ分返します
+// @Tags Model
+// @Produce json
+// @Param bucketKey query string false "バケットキー"
+// @Param status query string false "状態（uploaded, pending, inprogress, success, failed, timeout）"
+// @Param fileType query string false "拡張子（例: rvt）"
+// @Param uploadedBy query string false "アップロードした呼び出し元のID"
+// @Param tag query string false "タグ"
+// @Param projectId query string false "プロジェクトのID"
+// @Param folderId query string false "フォルダのID（rootでプロジェクト直下）"
+// @Param q query string false "名前またはファイル名の部分一致"
+// @Param sort query string false "並び順（createdAt, name, size。先頭に-で降順）" default(-createdAt)
+// @Param limit query int false "件数（最大200）" default(50)
+// @Param offset query int false "先頭から飛ばす件数" default(0)
+// @Success 200 {object} domain.ModelList
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models [get]
+func (h *ModelHandler) ListModels(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.ModelFilter{
+		BucketKey:  query.Get("bucketKey"),
+		Status:     query.Get("status"),
+		FileType:   query.Get("fileType"),
+		UploadedBy: query.Get("uploadedBy"),
+		Tag:        query.Get("tag"),
+		ProjectID:  query.Get("projectId"),
+		FolderID:   query.Get("folderId"),
+		Query:      query.Get("q"),
+		Sort:       query.Get("sort"),
+	}
+	for name, dst := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				problem.Write(w, r, http.StatusBadRequest, "invalid "+name+" parameter")
+				return
+			}
+			*dst = n
+		}
+	}
+
+	list, err := h.modelUseCase.ListModels(r.Context(), filter)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
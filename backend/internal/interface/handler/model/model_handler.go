@@ -0,0 +1,17 @@
+package model
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ModelHandler はモデルのカタログのハンドラ
+type ModelHandler struct {
+	modelUseCase domain.ModelUseCase
+}
+
+// NewModelHandler は新しいModelHandlerを作成します
+func NewModelHandler(modelUseCase domain.ModelUseCase) *ModelHandler {
+	return &ModelHandler{
+		modelUseCase: modelUseCase,
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary モデルの更新
+// @Description モデルの名前・説明・タグのうち、指定した項目を更新します
+// @Tags Model
+// @Accept json
+// @Produce json
+// @Param modelId path string true "モデルのID"
+// @Param request body domain.ModelUpdate true "更新する項目"
+// @Success 200 {object} domain.Model
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId} [patch]
+func (h *ModelHandler) UpdateModel(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ModelUpdate
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	model, err := h.modelUseCase.UpdateModel(r.Context(), mux.Vars(r)["modelId"], &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model)
+}
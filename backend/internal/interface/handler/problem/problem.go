@@ -0,0 +1,135 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ContentType はRFC 7807のエラーレスポンスのContent-Type
+const ContentType = "application/problem+json"
+
+// Details はRFC 7807のproblem+jsonのエラーレスポンス
+// APSのエラーが原因の場合は、APSのステータス・reason・errorCode・リクエストIDも含めます
+type Details struct {
+	Type           string `json:"type" example:"about:blank"`
+	Title          string `json:"title" example:"Not Found"`
+	Status         int    `json:"status" example:"404"`
+	Detail         string `json:"detail,omitempty"`
+	Instance       string `json:"instance,omitempty" example:"/api/v1/aps/buckets/my-bucket/details"`
+	UpstreamStatus int    `json:"upstreamStatus,omitempty" example:"404"`
+	Reason         string `json:"reason,omitempty"`
+	ErrorCode      string `json:"errorCode,omitempty"`
+	RequestID      string `json:"requestId,omitempty"`
+}
+
+// Write は指定したステータスとメッセージでエラーレスポンスを返します
+func Write(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	write(w, &Details{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+// WriteError はエラーの種類に応じたステータスでエラーレスポンスを返します
+// 5xxのエラーはファイルパスや接続先などの内部の情報を含むため、ログに記録してレスポンスには汎用のメッセージを返します
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusOf(err)
+	details := &Details{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+	}
+	if status >= http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "request failed", "status", status, "error", err)
+		details.Detail = serverErrorDetail(status)
+	}
+
+	var apsErr *domain.APSError
+	if errors.As(err, &apsErr) {
+		details.UpstreamStatus = apsErr.StatusCode
+		details.Reason = apsErr.Reason
+		details.ErrorCode = apsErr.ErrorCode
+		details.RequestID = apsErr.RequestID
+		if status == http.StatusTooManyRequests && apsErr.RetryAfter != "" {
+			w.Header().Set("Retry-After", apsErr.RetryAfter)
+		}
+	}
+
+	write(w, details)
+}
+
+// serverErrorDetail は5xxのエラーレスポンスで返す汎用のメッセージを返します
+func serverErrorDetail(status int) string {
+	switch status {
+	case http.StatusBadGateway:
+		return "upstream request failed"
+	case http.StatusGatewayTimeout:
+		return "upstream request timed out"
+	default:
+		return "internal server error"
+	}
+}
+
+// StatusOf はエラーに対応するHTTPステータスを返します
+// APSのエラーはクライアントが対処できるものはそのまま、それ以外は502として返します
+func StatusOf(err error) int {
+	var apsErr *domain.APSError
+	if errors.As(err, &apsErr) {
+		switch apsErr.StatusCode {
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden,
+			http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests:
+			return apsErr.StatusCode
+		default:
+			return http.StatusBadGateway
+		}
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	var urlErr *url.Error
+	switch {
+	case errors.Is(err, domain.ErrInvalidExportRequest), errors.Is(err, domain.ErrInvalidMeshRequest), errors.Is(err, domain.ErrInvalidGrant),
+		errors.Is(err, domain.ErrInvalidModelRequest), errors.Is(err, domain.ErrInvalidProjectRequest), errors.Is(err, domain.ErrInvalidSearchRequest),
+		errors.Is(err, domain.ErrInvalidShareRequest), errors.Is(err, domain.ErrInvalidGLBRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInvalidSharePassword), errors.Is(err, domain.ErrInvalidShareToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrDerivativePathNotAllowed), errors.Is(err, domain.ErrSignedURLNotAllowed), errors.Is(err, domain.ErrAccessDenied):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrExportNotFound), errors.Is(err, domain.ErrGrantNotFound), errors.Is(err, domain.ErrModelNotFound),
+		errors.Is(err, domain.ErrProjectNotFound), errors.Is(err, domain.ErrFolderNotFound), errors.Is(err, domain.ErrShareNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrExportNotReady), errors.Is(err, domain.ErrFolderConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrShareUnavailable):
+		return http.StatusGone
+	case errors.Is(err, domain.ErrBundleNotSupported):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &maxBytesErr), errors.Is(err, domain.ErrMeshLimitExceeded):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &urlErr):
+		// APSへ接続できなかった
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func write(w http.ResponseWriter, details *Details) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(details.Status)
+	json.NewEncoder(w).Encode(details)
+}
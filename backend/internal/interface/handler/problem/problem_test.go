@@ -0,0 +1,61 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+func TestWriteErrorHidesServerErrorDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantDetail string
+	}{
+		{
+			name:       "client error keeps the message",
+			err:        fmt.Errorf("%w: name is required", domain.ErrInvalidModelRequest),
+			wantStatus: http.StatusBadRequest,
+			wantDetail: "invalid model request: name is required",
+		},
+		{
+			name:       "internal error hides the message",
+			err:        errors.New("open /var/lib/aps/catalog.db: permission denied"),
+			wantStatus: http.StatusInternalServerError,
+			wantDetail: "internal server error",
+		},
+		{
+			name:       "upstream error hides the message",
+			err:        fmt.Errorf("failed to get manifest: %w", &domain.APSError{StatusCode: http.StatusServiceUnavailable, RequestID: "req-1"}),
+			wantStatus: http.StatusBadGateway,
+			wantDetail: "upstream request failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, httptest.NewRequest(http.MethodGet, "/api/v1/models", nil), tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var details Details
+			if err := json.NewDecoder(w.Body).Decode(&details); err != nil {
+				t.Fatal(err)
+			}
+			if details.Detail != tt.wantDetail {
+				t.Errorf("detail = %q, want %q", details.Detail, tt.wantDetail)
+			}
+			if tt.wantStatus >= http.StatusInternalServerError && strings.Contains(w.Body.String(), "/var/lib") {
+				t.Errorf("response leaks the error: %s", w.Body.String())
+			}
+		})
+	}
+}
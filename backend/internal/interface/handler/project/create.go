@@ -0,0 +1,41 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary プロジェクトの作成
+// @Description モデルを整理するプロジェクトを作成します
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param request body domain.ProjectRequest true "プロジェクトの名前と説明"
+// @Success 201 {object} domain.Project
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects [post]
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	project, err := h.projectUseCase.CreateProject(r.Context(), &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(project)
+}
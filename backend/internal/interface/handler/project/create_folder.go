@@ -0,0 +1,46 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary フォルダの作成
+// @Description プロジェクト直下、またはparentIdのフォルダの中にフォルダを作成します
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param projectId path string true "プロジェクトのID"
+// @Param request body domain.FolderRequest true "フォルダの名前と親フォルダ"
+// @Success 201 {object} domain.Folder
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 409 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId}/folders [post]
+func (h *ProjectHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.FolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	folder, err := h.projectUseCase.CreateFolder(r.Context(), mux.Vars(r)["projectId"], &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(folder)
+}
@@ -0,0 +1,54 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary プロジェクトの削除
+// @Description プロジェクトとフォルダを削除します。モデルはプロジェクトから外れ、カタログには残ります
+// @Description purge=trueの場合は、プロジェクトのモデルのOSSのオブジェクト・翻訳の派生ファイル・カタログの項目も削除します
+// @Description dryRun=trueの場合は削除せずに、削除する内容を返します
+// @Tags Project
+// @Produce json
+// @Param projectId path string true "プロジェクトのID"
+// @Param purge query bool false "OSSのオブジェクトと派生ファイルも削除する" default(false)
+// @Param dryRun query bool false "削除せずに削除する内容のみ返す" default(false)
+// @Success 200 {object} domain.ProjectDeletion
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Failure 502 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId} [delete]
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	var opts domain.ProjectDeleteOptions
+	for name, dst := range map[string]*bool{"purge": &opts.Purge, "dryRun": &opts.DryRun} {
+		if v := r.URL.Query().Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				problem.Write(w, r, http.StatusBadRequest, "invalid "+name+" parameter")
+				return
+			}
+			*dst = b
+		}
+	}
+
+	deletion, err := h.projectUseCase.DeleteProject(r.Context(), mux.Vars(r)["projectId"], opts)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deletion)
+}
@@ -0,0 +1,32 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary フォルダの削除
+// @Description 空のフォルダを削除します。サブフォルダやモデルがある場合は409を返します
+// @Tags Project
+// @Param projectId path string true "プロジェクトのID"
+// @Param folderId path string true "フォルダのID"
+// @Success 204
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 409 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId}/folders/{folderId} [delete]
+func (h *ProjectHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.projectUseCase.DeleteFolder(r.Context(), vars["projectId"], vars["folderId"]); err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary フォルダの中身
+// @Description フォルダ直下のサブフォルダと、モデルを名前順に1ページ分返します。モデルには翻訳の状態とサムネイルのURLを含めます
+// @Description folderIdにrootを指定するとプロジェクト直下を返します
+// @Tags Project
+// @Produce json
+// @Param projectId path string true "プロジェクトのID"
+// @Param folderId path string true "フォルダのID（rootでプロジェクト直下）"
+// @Param limit query int false "モデルの件数（最大200）" default(50)
+// @Param offset query int false "先頭から飛ばすモデルの件数" default(0)
+// @Success 200 {object} domain.FolderContents
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId}/folders/{folderId}/contents [get]
+func (h *ProjectHandler) GetFolderContents(w http.ResponseWriter, r *http.Request) {
+	var limit, offset int
+	for name, dst := range map[string]*int{"limit": &limit, "offset": &offset} {
+		if v := r.URL.Query().Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				problem.Write(w, r, http.StatusBadRequest, "invalid "+name+" parameter")
+				return
+			}
+			*dst = n
+		}
+	}
+
+	vars := mux.Vars(r)
+	contents, err := h.projectUseCase.GetFolderContents(r.Context(), vars["projectId"], vars["folderId"], limit, offset)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	for i, item := range contents.Models {
+		if item.HasThumbnail {
+			contents.Models[i].ThumbnailURL = thumbnailURL(item.URN)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contents)
+}
+
+// thumbnailURL は派生ファイルのプロキシ経由でモデルのサムネイルを取得するURLを返します
+func thumbnailURL(urn string) string {
+	return "/api/v1/aps/proxy/modelderivative/v2/designdata/" + urn + "/thumbnail"
+}
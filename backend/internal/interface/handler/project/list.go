@@ -0,0 +1,30 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary プロジェクトの一覧
+// @Description すべてのプロジェクトを名前順に返します
+// @Tags Project
+// @Produce json
+// @Success 200 {array} domain.Project
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects [get]
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.projectUseCase.ListProjects(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(projects)
+}
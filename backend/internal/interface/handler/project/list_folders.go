@@ -0,0 +1,34 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary フォルダの一覧
+// @Description プロジェクトのすべてのフォルダを返します。parentIdをたどるとフォルダの階層になります
+// @Tags Project
+// @Produce json
+// @Param projectId path string true "プロジェクトのID"
+// @Success 200 {array} domain.Folder
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId}/folders [get]
+func (h *ProjectHandler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	folders, err := h.projectUseCase.ListFolders(r.Context(), mux.Vars(r)["projectId"])
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(folders)
+}
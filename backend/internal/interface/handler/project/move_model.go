@@ -0,0 +1,45 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary モデルの移動
+// @Description モデルを別のプロジェクトやフォルダへ移動します。再アップロードは不要です
+// @Description folderIdを指定するとそのフォルダへ、projectIdのみの場合はプロジェクト直下へ移動し、どちらも空の場合はプロジェクトから外します
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param modelId path string true "モデルのID"
+// @Param request body domain.ModelMove true "移動先"
+// @Success 200 {object} domain.Model
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId}/move [post]
+func (h *ProjectHandler) MoveModel(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ModelMove
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	model, err := h.projectUseCase.MoveModel(r.Context(), mux.Vars(r)["modelId"], &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model)
+}
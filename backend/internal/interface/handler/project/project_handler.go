@@ -0,0 +1,17 @@
+package project
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ProjectHandler はプロジェクトとフォルダのハンドラ
+type ProjectHandler struct {
+	projectUseCase domain.ProjectUseCase
+}
+
+// NewProjectHandler は新しいProjectHandlerを作成します
+func NewProjectHandler(projectUseCase domain.ProjectUseCase) *ProjectHandler {
+	return &ProjectHandler{
+		projectUseCase: projectUseCase,
+	}
+}
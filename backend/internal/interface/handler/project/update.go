@@ -0,0 +1,44 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary プロジェクトの更新
+// @Description プロジェクトの名前と説明のうち、指定した項目を更新します
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param projectId path string true "プロジェクトのID"
+// @Param request body domain.ProjectUpdate true "更新する項目"
+// @Success 200 {object} domain.Project
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId} [patch]
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ProjectUpdate
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	project, err := h.projectUseCase.UpdateProject(r.Context(), mux.Vars(r)["projectId"], &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
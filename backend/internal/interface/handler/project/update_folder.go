@@ -0,0 +1,47 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary フォルダの更新
+// @Description フォルダの名前を変更し、parentIdを指定した場合は別の親フォルダへ移動します（空文字列でプロジェクト直下）
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param projectId path string true "プロジェクトのID"
+// @Param folderId path string true "フォルダのID"
+// @Param request body domain.FolderUpdate true "更新する項目"
+// @Success 200 {object} domain.Folder
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 409 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/projects/{projectId}/folders/{folderId} [patch]
+func (h *ProjectHandler) UpdateFolder(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.FolderUpdate
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	vars := mux.Vars(r)
+	folder, err := h.projectUseCase.UpdateFolder(r.Context(), vars["projectId"], vars["folderId"], &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(folder)
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary モデルの全文検索
+// @Description モデルの名前・説明・ファイル名・タグと、翻訳済みのモデルの要素の名前とプロパティの値から検索語に一致するモデルを探し、一致の度合いの順に1ページ分返します
+// @Description 空白区切りの語はすべてを含むモデルに一致します。"Level 3"のように引用符で囲むと語句として、fire*のように末尾に*を付けると前方一致で探します
+// @Description facetsには、ファイルの種類・プロジェクト・状態ごとの件数を、その項目以外の絞り込みを適用して返します
+// @Tags Search
+// @Produce json
+// @Param q query string true "検索語"
+// @Param fileType query string false "拡張子（例: rvt）"
+// @Param projectId query string false "プロジェクトのID（rootでプロジェクトに入っていないモデル）"
+// @Param status query string false "状態（uploaded, pending, inprogress, success, failed, timeout）"
+// @Param limit query int false "件数（最大100）" default(20)
+// @Param offset query int false "先頭から飛ばす件数" default(0)
+// @Success 200 {object} domain.SearchResult
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	searchQuery := domain.SearchQuery{
+		Query:     query.Get("q"),
+		FileType:  query.Get("fileType"),
+		ProjectID: query.Get("projectId"),
+		Status:    query.Get("status"),
+	}
+	for name, dst := range map[string]*int{"limit": &searchQuery.Limit, "offset": &searchQuery.Offset} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				problem.Write(w, r, http.StatusBadRequest, "invalid "+name+" parameter")
+				return
+			}
+			*dst = n
+		}
+	}
+
+	result, err := h.searchUseCase.Search(r.Context(), searchQuery)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,17 @@
+package search
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// SearchHandler はモデルの全文検索のハンドラ
+type SearchHandler struct {
+	searchUseCase domain.SearchUseCase
+}
+
+// NewSearchHandler は新しいSearchHandlerを作成します
+func NewSearchHandler(searchUseCase domain.SearchUseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUseCase: searchUseCase,
+	}
+}
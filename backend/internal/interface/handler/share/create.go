@@ -0,0 +1,49 @@
+package share
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 共有リンクの作成
+// @Description アカウントのない相手にモデルを見せるための共有リンクを作成します。有効期限・パスワード・閲覧できる回数の上限を設定できます
+// @Description トークンはこのレスポンスでのみ返します
+// @Tags Share
+// @Accept json
+// @Produce json
+// @Param modelId path string true "モデルのID"
+// @Param request body domain.ShareRequest false "有効期間・パスワード・閲覧できる回数の上限"
+// @Success 201 {object} domain.CreatedShare
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId}/shares [post]
+func (h *ShareHandler) CreateShare(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ShareRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	share, err := h.shareUseCase.CreateShare(r.Context(), mux.Vars(r)["modelId"], &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(share)
+}
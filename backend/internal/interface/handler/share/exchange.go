@@ -0,0 +1,42 @@
+package share
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 共有リンクとViewer用トークンの引き換え
+// @Description 共有リンクのトークン（とパスワード）を、共有したモデルの派生ファイルのプロキシでのみ使える短命のViewer用トークンと引き換えます。認証は不要です
+// @Description 引き換えるたびに閲覧を1回と数えます。期限切れ・取り消し済み・閲覧の上限に達した共有リンクは410を返します
+// @Tags Share
+// @Accept json
+// @Produce json
+// @Param request body domain.ShareExchangeRequest true "共有リンクのトークンとパスワード"
+// @Success 200 {object} domain.ShareViewerToken
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 410 {object} problem.Details
+// @Failure 429 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Router /api/v1/public/shares/exchange [post]
+func (h *ShareHandler) ExchangeShare(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ShareExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	token, err := h.shareUseCase.ExchangeShare(r.Context(), &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(token)
+}
@@ -0,0 +1,34 @@
+package share
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 共有リンクの一覧
+// @Description モデルの共有リンクを新しい順に返します。取り消したものと期限切れのものも含みます
+// @Tags Share
+// @Produce json
+// @Param modelId path string true "モデルのID"
+// @Success 200 {array} domain.Share
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId}/shares [get]
+func (h *ShareHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	shares, err := h.shareUseCase.ListShares(r.Context(), mux.Vars(r)["modelId"])
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shares)
+}
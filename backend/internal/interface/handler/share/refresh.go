@@ -0,0 +1,41 @@
+package share
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary Viewer用トークンの更新
+// @Description 有効期限内のViewer用トークンを新しいものと交換します。認証は不要です
+// @Description 閲覧の回数には数えません。トークンが不正または期限切れの場合は401、共有リンクが期限切れ・取り消し済みの場合は410を返します
+// @Tags Share
+// @Accept json
+// @Produce json
+// @Param request body domain.ShareRefreshRequest true "有効期限内のViewer用トークン"
+// @Success 200 {object} domain.ShareViewerToken
+// @Failure 400 {object} problem.Details
+// @Failure 401 {object} problem.Details
+// @Failure 410 {object} problem.Details
+// @Failure 429 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Router /api/v1/public/shares/refresh [post]
+func (h *ShareHandler) RefreshShare(w http.ResponseWriter, r *http.Request) {
+	var reqBody domain.ShareRefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	token, err := h.shareUseCase.RefreshShareToken(r.Context(), &reqBody)
+	if err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(token)
+}
@@ -0,0 +1,31 @@
+package share
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// @Summary 共有リンクの取り消し
+// @Description 共有リンクを取り消します。引き換え済みのViewer用トークンも使えなくなります
+// @Tags Share
+// @Param modelId path string true "モデルのID"
+// @Param shareId path string true "共有リンクのID"
+// @Success 204
+// @Failure 401 {object} problem.Details
+// @Failure 403 {object} problem.Details
+// @Failure 404 {object} problem.Details
+// @Failure 500 {object} problem.Details
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/models/{modelId}/shares/{shareId} [delete]
+func (h *ShareHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.shareUseCase.RevokeShare(r.Context(), vars["modelId"], vars["shareId"]); err != nil {
+		problem.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
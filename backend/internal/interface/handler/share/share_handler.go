@@ -0,0 +1,17 @@
+package share
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ShareHandler はモデルの共有リンクのハンドラ
+type ShareHandler struct {
+	shareUseCase domain.ShareUseCase
+}
+
+// NewShareHandler は新しいShareHandlerを作成します
+func NewShareHandler(shareUseCase domain.ShareUseCase) *ShareHandler {
+	return &ShareHandler{
+		shareUseCase: shareUseCase,
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// 頻繁に呼ばれるため、デバッグレベルで記録するパス
+var quietPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// AccessLog はリクエストごとにメソッド・パス・ステータス・所要時間を記録するミドルウェア
+// 5xxはerror、4xxはwarn、それ以外はinfoで記録します
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := newStatusRecorder(w)
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietPaths[r.URL.Path]:
+			level = slog.LevelDebug
+		}
+		slog.Log(r.Context(), level, "http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", recorder.bytes,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote", r.RemoteAddr,
+		)
+	})
+}
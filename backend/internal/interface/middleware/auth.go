@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// Authenticate はリクエストを認証し、呼び出し元をコンテキストに設定するミドルウェア
+// publicPathsで始まるパス（生存確認やAPIドキュメント）は認証しません
+// authenticatorがnilの場合（開発用に認証を無効にした場合）は認証を行わず、すべてのリクエストを開発用の呼び出し元として扱います
+func Authenticate(authenticator auth.Authenticator, publicPaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authenticator == nil {
+				next.ServeHTTP(w, r.WithContext(domain.WithPrincipal(r.Context(), domain.DevelopmentPrincipal)))
+				return
+			}
+			for _, path := range publicPaths {
+				if strings.HasPrefix(r.URL.Path, path) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			principal, err := authenticator.Authenticate(r)
+			if err != nil {
+				writeUnauthorized(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(domain.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// writeUnauthorized は401を返します。検証に失敗した理由はレスポンスに含めず、ログにのみ記録します
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	challenge := `Bearer realm="aps-viewer"`
+	detail := "authentication required"
+	if !errors.Is(err, auth.ErrNoCredentials) {
+		challenge += `, error="invalid_token"`
+		detail = "invalid credentials"
+		slog.InfoContext(r.Context(), "authentication failed", "path", r.URL.Path, "error", err)
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	problem.Write(w, r, http.StatusUnauthorized, detail)
+}
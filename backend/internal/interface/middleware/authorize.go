@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// Authorizer はルートのハンドラを、操作の権限を確認してから呼び出すハンドラで包みます
+type Authorizer func(action domain.Action, next http.HandlerFunc) http.Handler
+
+// BucketResolver はルートの変数から操作の対象のバケットキーを求めます。特定できない場合は空文字列を返します
+type BucketResolver func(r *http.Request) string
+
+// URNResolver はルートから参照するモデルのURNを求めます。特定できない場合は空文字列を返します
+type URNResolver func(r *http.Request) string
+
+// Authorize は呼び出し元のロールと権限の付与で操作を認可するAuthorizerを返します
+// 対象のバケットはルートのbucketKey・urn・objectIdから決め、決まらない場合はresolversを順に試します。許可されない場合は403を返します
+// 共有リンクのトークンの呼び出し元は、ShareScopeで許可したルート以外では常に拒否し、監査ログに記録します
+func Authorize(access domain.AccessUseCase, audit domain.AuditLogger, resolvers ...BucketResolver) Authorizer {
+	return func(action domain.Action, next http.HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal, ok := domain.PrincipalFromContext(r.Context()); ok && principal.URN != "" {
+				denyShare(w, r, audit, action, "", principal)
+				return
+			}
+			bucketKey := bucketKeyOf(r)
+			for _, resolve := range resolvers {
+				if bucketKey != "" {
+					break
+				}
+				bucketKey = resolve(r)
+			}
+			if err := access.Authorize(r.Context(), action, bucketKey); err != nil {
+				problem.WriteError(w, r, err)
+				return
+			}
+			next(w, r)
+		})
+	}
+}
+
+// ShareScope は共有リンクのトークンの呼び出し元に、resolveで求めたURNがトークンのURNと一致する参照のみを許可するAuthorizerを返します
+// それ以外の呼び出し元はallowで認可します。共有リンクのトークンの呼び出し元を拒否した場合は監査ログに記録します
+func ShareScope(allow Authorizer, resolve URNResolver, audit domain.AuditLogger) Authorizer {
+	return func(action domain.Action, next http.HandlerFunc) http.Handler {
+		authorized := allow(action, next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := domain.PrincipalFromContext(r.Context())
+			if !ok || principal.URN == "" {
+				authorized.ServeHTTP(w, r)
+				return
+			}
+			if urn := resolve(r); action != domain.ActionObjectRead || !sameURN(urn, principal.URN) {
+				denyShare(w, r, audit, action, urn, principal)
+				return
+			}
+			next(w, r)
+		})
+	}
+}
+
+// denyShare は共有リンクのトークンの呼び出し元を拒否し、監査ログに記録して403を返します
+// urnは参照しようとしたモデルのURNで、特定できない場合は空文字列です
+func denyShare(w http.ResponseWriter, r *http.Request, audit domain.AuditLogger, action domain.Action, urn string, principal *domain.Principal) {
+	slog.WarnContext(r.Context(), "access denied",
+		"action", string(action),
+		"urn", urn,
+		"share_urn", principal.URN,
+	)
+	target := ""
+	err := fmt.Errorf("%w: share token cannot be used for %s", domain.ErrAccessDenied, action)
+	if urn != "" {
+		target = domain.ManifestTarget(urn)
+		err = fmt.Errorf("%w: share token cannot be used for %s on %s", domain.ErrAccessDenied, action, urn)
+	}
+	audit.Record(r.Context(), action, target, err)
+	problem.WriteError(w, r, err)
+}
+
+// Public は権限を確認しないAuthorizer。認証なしで呼び出せる公開のルートに、レート制限だけを加えるために使います
+func Public(_ domain.Action, next http.HandlerFunc) http.Handler {
+	return next
+}
+
+// ModelBucket はルートのmodelIdのモデルが置かれたバケットを求めるBucketResolverを返します
+func ModelBucket(models domain.ModelUseCase) BucketResolver {
+	return func(r *http.Request) string {
+		id := mux.Vars(r)["modelId"]
+		if id == "" {
+			return ""
+		}
+		model, err := models.GetModel(r.Context(), id)
+		if err != nil {
+			return ""
+		}
+		return model.BucketKey
+	}
+}
+
+// sameURN はBase64のパディングの有無を区別せずにURNを比較します
+func sameURN(a string, b string) bool {
+	a, b = strings.TrimRight(a, "="), strings.TrimRight(b, "=")
+	return a != "" && a == b
+}
+
+// bucketKeyOf はルートの変数から操作の対象のバケットキーを返します。特定できない場合は空文字列を返します
+func bucketKeyOf(r *http.Request) string {
+	vars := mux.Vars(r)
+	if bucketKey := vars["bucketKey"]; bucketKey != "" {
+		return bucketKey
+	}
+	if urn := vars["urn"]; urn != "" {
+		bucketKey, _ := domain.BucketKeyOfURN(urn)
+		return bucketKey
+	}
+	if objectID := vars["objectId"]; objectID != "" {
+		bucketKey, _ := domain.BucketKeyOfObjectID(objectID)
+		return bucketKey
+	}
+	return ""
+}
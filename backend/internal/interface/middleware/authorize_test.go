@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// allowAll はすべての操作を許可するAccessUseCase
+type allowAll struct {
+	domain.AccessUseCase
+}
+
+func (allowAll) Authorize(ctx context.Context, action domain.Action, bucketKey string) error {
+	return nil
+}
+
+// recordingAudit は監査ログに記録した操作と結果を保持します
+type recordingAudit struct {
+	actions []domain.Action
+	errs    []error
+}
+
+func (a *recordingAudit) Record(ctx context.Context, action domain.Action, target string, err error) {
+	a.actions = append(a.actions, action)
+	a.errs = append(a.errs, err)
+}
+
+// allActions はルートの認可に使う操作
+var allActions = []domain.Action{
+	domain.ActionBucketRead, domain.ActionBucketCreate, domain.ActionBucketDelete,
+	domain.ActionObjectRead, domain.ActionObjectUpload, domain.ActionObjectTranslate, domain.ActionObjectDelete,
+	domain.ActionManifestDelete, domain.ActionExportCreate, domain.ActionTokenCreate, domain.ActionMeshProcess,
+	domain.ActionGrantManage, domain.ActionAuditRead, domain.ActionModelUpdate, domain.ActionModelDelete,
+	domain.ActionModelMove, domain.ActionProjectCreate, domain.ActionProjectUpdate, domain.ActionProjectDelete,
+	domain.ActionFolderCreate, domain.ActionFolderUpdate, domain.ActionFolderDelete,
+	domain.ActionShareCreate, domain.ActionShareRevoke, domain.ActionShareView,
+}
+
+const sharedURN = "dXJuOmFkc2sub2JqZWN0czpvcy5vYmplY3Q6dGVhbWEtbW9kZWxzL2EucnZ0"
+
+// urnHeader はテスト用に、X-Test-URNヘッダーのURNを参照するモデルとして扱います
+func urnHeader(r *http.Request) string {
+	return r.Header.Get("X-Test-URN")
+}
+
+func serve(t *testing.T, authorizer Authorizer, action domain.Action, principal *domain.Principal, urn string) (int, bool) {
+	t.Helper()
+	called := false
+	handler := authorizer(action, func(w http.ResponseWriter, r *http.Request) { called = true })
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/aps/viewer/proxy/modelderivative", nil)
+	r.Header.Set("X-Test-URN", urn)
+	r = r.WithContext(domain.WithPrincipal(r.Context(), principal))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w.Code, called
+}
+
+func TestShareScope(t *testing.T) {
+	sharePrincipal := &domain.Principal{ID: "share:s1", Method: domain.AuthMethodShare, Roles: []string{domain.RoleViewer}, URN: sharedURN}
+	admin := &domain.Principal{ID: "alice", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleAdmin}}
+
+	type testCase struct {
+		name      string
+		principal *domain.Principal
+		action    domain.Action
+		urn       string
+		wantAllow bool
+	}
+	tests := []testCase{
+		{name: "shared urn", principal: sharePrincipal, action: domain.ActionObjectRead, urn: sharedURN, wantAllow: true},
+		{name: "shared urn without padding", principal: sharePrincipal, action: domain.ActionObjectRead, urn: sharedURN + "==", wantAllow: true},
+		{name: "another urn", principal: sharePrincipal, action: domain.ActionObjectRead, urn: "dXJuOmFkc2sub2JqZWN0czpvcy5vYmplY3Q6dGVhbWEtbW9kZWxzL2IucnZ0"},
+		{name: "no urn", principal: sharePrincipal, action: domain.ActionObjectRead, urn: ""},
+		{name: "other principal", principal: admin, action: domain.ActionBucketDelete, urn: sharedURN, wantAllow: true},
+	}
+	for _, action := range allActions {
+		if action != domain.ActionObjectRead {
+			tests = append(tests, testCase{name: string(action) + " on the shared urn", principal: sharePrincipal, action: action, urn: sharedURN})
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &recordingAudit{}
+			scope := ShareScope(Authorize(allowAll{}, audit), urnHeader, audit)
+
+			code, called := serve(t, scope, tt.action, tt.principal, tt.urn)
+			if called != tt.wantAllow {
+				t.Fatalf("handler called = %v, want %v (status %d)", called, tt.wantAllow, code)
+			}
+			if tt.wantAllow {
+				return
+			}
+			if code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403", code)
+			}
+			if len(audit.actions) != 1 || audit.actions[0] != tt.action || !errors.Is(audit.errs[0], domain.ErrAccessDenied) {
+				t.Errorf("audit = %v %v, want one denied %s", audit.actions, audit.errs, tt.action)
+			}
+		})
+	}
+}
+
+func TestAuthorizeDeniesSharePrincipal(t *testing.T) {
+	sharePrincipal := &domain.Principal{ID: "share:s1", Method: domain.AuthMethodShare, Roles: []string{domain.RoleViewer}, URN: sharedURN}
+	for _, action := range allActions {
+		t.Run(string(action), func(t *testing.T) {
+			audit := &recordingAudit{}
+			code, called := serve(t, Authorize(allowAll{}, audit), action, sharePrincipal, sharedURN)
+			if called || code != http.StatusForbidden {
+				t.Fatalf("handler called = %v, status = %d, want 403", called, code)
+			}
+			if len(audit.actions) != 1 || !errors.Is(audit.errs[0], domain.ErrAccessDenied) {
+				t.Errorf("audit = %v %v, want one denial", audit.actions, audit.errs)
+			}
+		})
+	}
+}
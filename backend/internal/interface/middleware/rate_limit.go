@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/metrics"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/ratelimit"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/problem"
+)
+
+// RateLimit はallowで保護するルートに、呼び出し元とルートの種類ごとのレート制限を加えるAuthorizerを返します
+// 制限を超えた場合は権限を確認する前に429とRetry-Afterを返します。limiterがnilの場合はallowをそのまま返します
+func RateLimit(limiter *ratelimit.Limiter, allow Authorizer) Authorizer {
+	if limiter == nil {
+		return allow
+	}
+	return func(action domain.Action, next http.HandlerFunc) http.Handler {
+		class := ratelimit.ClassOf(action)
+		limit := limiter.Limit(class)
+		authorized := allow(action, next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decision, err := limiter.Allow(r.Context(), class, limiter.ClientKey(r))
+			if err != nil {
+				// 共有の保存先に障害があってもAPIを止めないよう、制限せずに通す
+				slog.WarnContext(r.Context(), "rate limit check failed", "class", class, "error", err)
+				authorized.ServeHTTP(w, r)
+				return
+			}
+			if limit.Enabled() {
+				w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			}
+			if !decision.Allowed {
+				metrics.RateLimitedRequests.WithLabelValues(string(class)).Inc()
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+				problem.Write(w, r, http.StatusTooManyRequests, "rate limit exceeded for "+string(class)+" requests")
+				return
+			}
+			authorized.ServeHTTP(w, r)
+		})
+	}
+}
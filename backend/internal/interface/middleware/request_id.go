@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/logging"
+)
+
+// RequestIDHeader はリクエストIDのヘッダー
+const RequestIDHeader = "X-Request-ID"
+
+// 受け付けるリクエストID。ログを汚さないよう、長さと使える文字を制限します
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:/+=-]{1,128}$`)
+
+// RequestID はリクエストIDをコンテキストとレスポンスヘッダーに設定するミドルウェア
+// 呼び出し元やロードバランサーがX-Request-IDを付けていればそれを引き継ぎ、なければ生成します
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+// ルート変数のうちスパンの属性として記録するもの
+var routeVarAttributes = map[string]attribute.Key{
+	"bucketKey": tracing.BucketKey,
+	"objectId":  tracing.ObjectID,
+	"objectKey": tracing.ObjectKey,
+	"urn":       tracing.URN,
+}
+
+// Tracing はリクエストごとにサーバースパンを作成するミドルウェアを返します
+// W3C Trace Context（traceparent・tracestate）を受け取った場合は、そのトレースの子スパンにします
+// スパン名はrouterで照合したルートのテンプレート（例: POST /api/v1/aps/buckets/{bucketKey}/objects/upload）にします
+// アクセスログにもトレースIDを付けられるよう、ルーターの外側で使います
+func Tracing(router *mux.Router) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			route := "unmatched"
+			attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(r.Method)}
+			var match mux.RouteMatch
+			if router.Match(r, &match) && match.Route != nil {
+				if template, err := match.Route.GetPathTemplate(); err == nil {
+					route = template
+					attrs = append(attrs, semconv.HTTPRoute(route))
+				}
+				for name, value := range match.Vars {
+					if key, ok := routeVarAttributes[name]; ok {
+						attrs = append(attrs, key.String(value))
+					}
+				}
+			}
+
+			ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrs...))
+			defer span.End()
+
+			recorder := newStatusRecorder(w)
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			status := recorder.Status()
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
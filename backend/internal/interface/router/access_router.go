@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/access"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetAccessRoutes は呼び出し元の確認と権限の付与の管理のルートを設定します
+func SetAccessRoutes(router *mux.Router, handler *access.AccessHandler, allow middleware.Authorizer) {
+	router.HandleFunc("/api/v1/auth/me", handler.WhoAmI).Methods("GET")
+
+	router.Handle("/api/v1/admin/grants", allow(domain.ActionGrantManage, handler.ListGrants)).Methods("GET")
+	router.Handle("/api/v1/admin/grants", allow(domain.ActionGrantManage, handler.CreateGrant)).Methods("POST")
+	router.Handle("/api/v1/admin/grants/{grantId}", allow(domain.ActionGrantManage, handler.DeleteGrant)).Methods("DELETE")
+}
@@ -2,12 +2,15 @@ package router
 
 import (
     "github.com/gorilla/mux"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_bucket"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
 )
 
-func RegisterAPSBucketRoutes(r *mux.Router, h *aps_bucket.APSBucketHandler) {
-    r.HandleFunc("/api/v1/aps/buckets", h.CreateBucket).Methods("POST")
-    r.HandleFunc("/api/v1/aps/buckets", h.GetBuckets).Methods("GET")
-    r.HandleFunc("/api/v1/aps/buckets/{bucketKey}/details", h.GetBucketDetail).Methods("GET")
-    r.HandleFunc("/api/v1/aps/buckets/{bucketKey}", h.DeleteBucket).Methods("DELETE")
+// RegisterAPSBucketRoutes はAPSバケット関連のルートを設定します。作成・削除はadminロールが必要です
+func RegisterAPSBucketRoutes(r *mux.Router, h *aps_bucket.APSBucketHandler, allow middleware.Authorizer) {
+    r.Handle("/api/v1/aps/buckets", allow(domain.ActionBucketCreate, h.CreateBucket)).Methods("POST")
+    r.Handle("/api/v1/aps/buckets", allow(domain.ActionBucketRead, h.GetBuckets)).Methods("GET")
+    r.Handle("/api/v1/aps/buckets/{bucketKey}/details", allow(domain.ActionBucketRead, h.GetBucketDetail)).Methods("GET")
+    r.Handle("/api/v1/aps/buckets/{bucketKey}", allow(domain.ActionBucketDelete, h.DeleteBucket)).Methods("DELETE")
 }
\ No newline at end of file
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_derivative"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetAPSDerivativeRoutes は派生ファイル関連のルートを設定します
+// sharedは共有リンクのトークンにも、共有したモデルの派生ファイルに限って参照を許可するAuthorizerです
+func SetAPSDerivativeRoutes(router *mux.Router, handler *aps_derivative.APSDerivativeHandler, allow middleware.Authorizer, shared middleware.Authorizer) {
+	// オフライン閲覧用バンドルのエクスポート
+	router.Handle("/api/v1/aps/objects/{urn}/bundle", allow(domain.ActionObjectRead, handler.ExportBundle)).Methods("GET")
+
+	// OBJ派生ファイルからGLBへの変換
+	router.Handle("/api/v1/aps/objects/{urn}/glb", allow(domain.ActionExportCreate, handler.ConvertToGLB)).Methods("POST")
+	router.Handle("/api/v1/aps/objects/{urn}/glb", allow(domain.ActionObjectRead, handler.GetGLB)).Methods("GET")
+
+	// Viewer用の派生ファイルプロキシ（キャッシュ付き）
+	// パスからバケットを特定しないため、すべてのバケットに対するviewerロールまたは共有リンクのトークンが必要
+	router.PathPrefix("/api/v1/aps/proxy/").Handler(shared(domain.ActionObjectRead, handler.ProxyDerivative)).Methods("GET", "HEAD")
+}
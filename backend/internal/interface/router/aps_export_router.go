@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_export"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetAPSExportRoutes は選択した要素のエクスポート関連のルートを設定します
+func SetAPSExportRoutes(router *mux.Router, handler *aps_export.APSExportHandler, allow middleware.Authorizer) {
+	router.Handle("/api/v1/aps/objects/{urn}/exports", allow(domain.ActionExportCreate, handler.CreateExport)).Methods("POST")
+	router.Handle("/api/v1/aps/objects/{urn}/exports", allow(domain.ActionObjectRead, handler.ListExports)).Methods("GET")
+	router.Handle("/api/v1/aps/objects/{urn}/exports/{exportId}", allow(domain.ActionObjectRead, handler.GetExport)).Methods("GET")
+	router.Handle("/api/v1/aps/objects/{urn}/exports/{exportId}/download", allow(domain.ActionObjectRead, handler.DownloadExport)).Methods("GET")
+}
@@ -3,7 +3,9 @@ package router
 import (
     "github.com/gorilla/mux"
     "github.com/swaggo/http-swagger"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_token"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
 )
 
 // @title APS Viewer API
@@ -11,7 +13,7 @@ import (
 // @description APS (Autodesk Platform Services) トークン管理API
 // @host localhost:8080
 
-func RegisterAPSTokenRoutes(r *mux.Router, h *aps_token.APSTokenHandler) {
+func RegisterAPSTokenRoutes(r *mux.Router, h *aps_token.APSTokenHandler, allow middleware.Authorizer) {
     // @Summary APSトークン取得
     // @Description 2-legged認証でAPSアクセストークンを取得
     // @Tags token
@@ -21,7 +23,7 @@ func RegisterAPSTokenRoutes(r *mux.Router, h *aps_token.APSTokenHandler) {
     // @Failure 400 {object} string "不正なリクエスト"
     // @Failure 500 {object} string "サーバーエラー"
     // @Router /api/v1/aps/token [post]
-    r.HandleFunc("/api/v1/aps/token", h.GetToken).Methods("POST")
+    r.Handle("/api/v1/aps/token", allow(domain.ActionTokenCreate, h.GetToken)).Methods("POST")
     
     // Swagger routes
     r.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
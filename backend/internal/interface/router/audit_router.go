@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/audit"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetAuditRoutes は監査ログの検索と検証のルートを設定します
+func SetAuditRoutes(router *mux.Router, handler *audit.AuditHandler, allow middleware.Authorizer) {
+	router.Handle("/api/v1/admin/audit", allow(domain.ActionAuditRead, handler.QueryAudit)).Methods("GET")
+	router.Handle("/api/v1/admin/audit/verify", allow(domain.ActionAuditRead, handler.VerifyAudit)).Methods("GET")
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"expvar"
+
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetDebugRoutes は運用確認用のルートを設定します
+// /debug/varsではAPS呼び出しのリトライ回数（aps_client）などのexpvarを確認できます
+func SetDebugRoutes(router *mux.Router, allow middleware.Authorizer) {
+	router.Handle("/debug/vars", allow(domain.ActionAuditRead, expvar.Handler().ServeHTTP)).Methods("GET")
+}
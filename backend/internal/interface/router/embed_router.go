@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/embed"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetEmbedRoutes は共有リンクの埋め込みページとoEmbedのルートを設定します
+// いずれも認証なしで呼び出せるため、publicにはレート制限のみを加えるAuthorizerを渡します
+func SetEmbedRoutes(router *mux.Router, handler *embed.EmbedHandler, public middleware.Authorizer) {
+	router.Handle("/oembed", public(domain.ActionShareView, handler.GetOEmbed)).Methods("GET")
+	router.Handle("/embed/{token}", public(domain.ActionShareView, handler.GetEmbedPage)).Methods("GET")
+	router.Handle("/embed/{token}/thumbnail", public(domain.ActionShareView, handler.GetThumbnail)).Methods("GET")
+}
@@ -0,0 +1,12 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/health"
+)
+
+// SetHealthRoutes はロードバランサーや監視から使う生存確認・準備状態の確認のルートを設定します
+func SetHealthRoutes(router *mux.Router, handler *health.HealthHandler) {
+	router.HandleFunc("/healthz", handler.Healthz).Methods("GET", "HEAD")
+	router.HandleFunc("/readyz", handler.Readyz).Methods("GET", "HEAD")
+}
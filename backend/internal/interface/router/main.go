@@ -1,44 +1,255 @@
 package router
 
 import (
-    "net/http"
+    "context"
+    "log"
+    "log/slog"
+    "path/filepath"
+
     "github.com/gorilla/mux"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/config"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
     aps_token_repo "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_token"
     aps_bucket_repo "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_bucket"
     aps_object_repo "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_object"
+    aps_derivative_repo "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_derivative"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_cassette"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_client"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_fake"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/cache/derivative_cache"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/metrics"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/ratelimit"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/store/export_history"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/store/grant_store"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/store/audit_log"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/store/catalog"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/access"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/audit"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/model"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/project"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/search"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/embed"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/share"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_token"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_bucket"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_object"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_derivative"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/aps_export"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/mesh_processing"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/health"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
     token_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/aps_token"
     bucket_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/aps_bucket"
     object_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/aps_object"
+    derivative_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/aps_derivative"
+    export_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/aps_export"
+    mesh_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/mesh_processing"
+    health_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/health"
+    access_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/access"
+    audit_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/audit"
+    model_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/model"
+    project_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/project"
+    search_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/search"
+    share_usecase "github.com/maixhashi/nextgo-aps-viewer/backend/internal/usecase/share"
 )
 
-func NewRouter() *mux.Router {
+// 認証せずに呼び出せるパス（ロードバランサーの生存確認、APIドキュメント、共有リンクの引き換え）
+var publicPaths = []string{"/healthz", "/readyz", "/swagger/", "/api/v1/public/", "/embed/", "/oembed"}
+
+// NewRouter はAPIのルーターと管理用エンドポイントのルーターを返します
+// 管理用のアドレス（cfg.Server.AdminAddr）を指定しない場合、管理用エンドポイントはAPIのルーターに登録し、adminはnilになります
+func NewRouter(cfg *config.Config) (api *mux.Router, admin *mux.Router) {
     r := mux.NewRouter()
-    
-    // Initialize HTTP client
-    httpClient := &http.Client{}
-    
+    admin = r
+    if cfg.Server.AdminAddr != "" {
+        admin = mux.NewRouter()
+    }
+
+    // APSのベースURL
+    apsEndpoints := cfg.APS.Endpoints()
+
+    // 偽のAPSサーバーを使う場合は起動し、すべてのAPS呼び出しをそちらへ向ける
+    if cfg.APS.Fake {
+        fakeServer, err := aps_fake.Start(aps_fake.Options{Script: aps_fake.DefaultScript(cfg.APS.FakeTranslationTime)})
+        if err != nil {
+            log.Fatalf("failed to start fake APS server: %v", err)
+        }
+        apsEndpoints = aps_endpoint.ForHost(fakeServer.URL)
+        slog.Info("using fake APS server", "url", fakeServer.URL)
+    }
+
+    // APS呼び出しの記録・再生
+    apsTransport, err := aps_cassette.New(aps_cassette.Mode(cfg.APS.CassetteMode), cfg.APS.Cassette)
+    if err != nil {
+        log.Fatalf("failed to initialize APS cassette: %v", err)
+    }
+
     // Initialize repositories
-    apsTokenRepo := aps_token_repo.NewAPSTokenRepository()
-    apsBucketRepo := aps_bucket_repo.NewAPSBucketRepository(httpClient)
-    apsObjectRepo := aps_object_repo.NewAPSObjectRepository(httpClient, apsTokenRepo)
+    // トークンの取得にはトークンを取り直さないクライアントを、それ以外には401でトークンを取り直すクライアントを使う
+    apsCredentials := aps_token_repo.Credentials{ClientID: cfg.APS.ClientID, ClientSecret: cfg.APS.ClientSecret}
+    apsTimeouts := cfg.APS.Timeouts
+    apsTokenRepo := aps_token_repo.NewAPSTokenRepository(aps_client.NewClient(apsTransport, cfg.APS.Retry, nil), apsCredentials, apsEndpoints, apsTimeouts, aps_token_repo.InternalScope)
+    // POST /api/v1/aps/tokenで発行するトークンは、閲覧のみのスコープで別に取得する
+    apsViewerTokenRepo := aps_token_repo.NewAPSTokenRepository(aps_client.NewClient(apsTransport, cfg.APS.Retry, nil), apsCredentials, apsEndpoints, apsTimeouts, aps_token_repo.ViewerScope)
+    httpClient := aps_client.NewClient(apsTransport, cfg.APS.Retry, apsTokenRepo)
+    apsBucketRepo := aps_bucket_repo.NewAPSBucketRepository(httpClient, apsEndpoints, apsTimeouts)
+    apsObjectRepo := aps_object_repo.NewAPSObjectRepository(httpClient, apsTokenRepo, apsEndpoints, apsTimeouts)
+    apsDerivativeRepo := aps_derivative_repo.NewAPSDerivativeRepository(httpClient, apsTokenRepo, apsEndpoints, apsTimeouts)
+    derivativeCache, err := derivative_cache.NewDerivativeCache(cfg.Storage.ProxyCacheDir, cfg.Storage.ProxyCacheMaxBytes)
+    if err != nil {
+        log.Fatalf("failed to initialize derivative cache: %v", err)
+    }
+
+    // ローカルに保存するデータ
+    exportRepo, err := export_history.NewExportHistoryRepository(filepath.Join(cfg.Storage.DataDir, "exports"))
+    if err != nil {
+        log.Fatalf("failed to initialize export history: %v", err)
+    }
+    grantRepo, err := grant_store.NewGrantStore(filepath.Join(cfg.Storage.DataDir, "grants.json"))
+    if err != nil {
+        log.Fatalf("failed to initialize grants: %v", err)
+    }
+    auditRepo, err := audit_log.NewAuditLog(filepath.Join(cfg.Storage.DataDir, "audit", "audit.jsonl"))
+    if err != nil {
+        log.Fatalf("failed to initialize audit log: %v", err)
+    }
+    catalogDB, err := catalog.Open(cfg.Storage.CatalogFile())
+    if err != nil {
+        log.Fatalf("failed to initialize model catalog: %v", err)
+    }
+    modelRepo := catalog.NewModelRepository(catalogDB)
+    projectRepo := catalog.NewProjectRepository(catalogDB)
+    searchIndex := catalog.NewSearchIndex(catalogDB)
+    shareRepo := catalog.NewShareRepository(catalogDB)
+    shareTokens, err := auth.NewShareTokens(cfg.Share.TokenSecret, shareRepo)
+    if err != nil {
+        log.Fatalf("failed to initialize share tokens: %v", err)
+    }
     
     // Initialize use cases
-    apsTokenUseCase := token_usecase.NewAPSTokenUseCase(apsTokenRepo)
-    apsBucketUseCase := bucket_usecase.NewAPSBucketUseCase(apsBucketRepo, apsTokenUseCase)
-    apsObjectUseCase := object_usecase.NewAPSObjectUseCase(apsObjectRepo)
+    auditUseCase := audit_usecase.NewAuditUseCase(auditRepo)
+    apsTokenUseCase := token_usecase.NewAPSTokenUseCase(apsTokenRepo, apsViewerTokenRepo, auditUseCase)
+    apsBucketUseCase := bucket_usecase.NewAPSBucketUseCase(apsBucketRepo, apsTokenUseCase, auditUseCase)
+    searchUseCase := search_usecase.NewSearchUseCase(searchIndex, apsDerivativeRepo, cfg.Search.IndexProperties, cfg.Search.MaxPropertyBytes)
+    modelUseCase := model_usecase.NewModelUseCase(modelRepo, auditUseCase, searchUseCase)
+    projectUseCase := project_usecase.NewProjectUseCase(projectRepo, modelRepo, apsObjectRepo, apsDerivativeRepo, auditUseCase)
+    apsObjectUseCase := object_usecase.NewAPSObjectUseCase(apsObjectRepo, metrics.NewTranslationTracker(), auditUseCase, modelUseCase)
+    apsDerivativeUseCase := derivative_usecase.NewAPSDerivativeUseCase(apsDerivativeRepo, apsObjectRepo, derivativeCache, cfg.Storage.BundleWorkDir, cfg.Storage.BundleConcurrency)
+    shareUseCase := share_usecase.NewShareUseCase(shareRepo, modelRepo, shareTokens, apsDerivativeUseCase, auditUseCase, cfg.Share.Policy())
+    apsExportUseCase := export_usecase.NewAPSExportUseCase(apsDerivativeRepo, apsObjectRepo, exportRepo)
+    meshProcessingUseCase := mesh_usecase.NewMeshProcessingUseCase(cfg.Mesh.Limits())
+    accessUseCase := access_usecase.NewAccessUseCase(grantRepo, auditUseCase)
+    healthUseCase := health_usecase.NewHealthUseCase(
+        domain.HealthCheck{
+            Name:     "aps_token",
+            CacheTTL: cfg.APS.HealthCacheTTL,
+            Check: func(ctx context.Context) error {
+                _, err := apsTokenRepo.GetToken(ctx)
+                return err
+            },
+        },
+        domain.HealthCheck{
+            Name:     "aps_oss",
+            CacheTTL: cfg.APS.HealthCacheTTL,
+            Check: func(ctx context.Context) error {
+                token, err := apsTokenRepo.GetToken(ctx)
+                if err != nil {
+                    return err
+                }
+                _, err = apsBucketRepo.GetBuckets(ctx, token.AccessToken)
+                return err
+            },
+        },
+        domain.HealthCheck{
+            Name:  "export_store",
+            Check: func(context.Context) error { return exportRepo.CheckWritable() },
+        },
+        domain.HealthCheck{
+            Name:  "derivative_cache",
+            Check: func(context.Context) error { return derivativeCache.CheckWritable() },
+        },
+        domain.HealthCheck{
+            Name:  "model_catalog",
+            Check: func(ctx context.Context) error { return catalog.Check(ctx, catalogDB) },
+        },
+    )
     
     // Initialize handlers
     apsTokenHandler := aps_token.NewAPSTokenHandler(apsTokenUseCase)
     apsBucketHandler := aps_bucket.NewAPSBucketHandler(apsBucketUseCase)
-    apsObjectHandler := aps_object.NewAPSObjectHandler(apsObjectUseCase)
+    apsObjectHandler := aps_object.NewAPSObjectHandler(apsObjectUseCase, aps_object.UploadLimits{
+        MaxBytes:     cfg.Upload.MaxBytes,
+        MaxPartBytes: cfg.Upload.MaxPartBytes,
+    })
+    apsDerivativeHandler := aps_derivative.NewAPSDerivativeHandler(apsDerivativeUseCase)
+    apsExportHandler := aps_export.NewAPSExportHandler(apsExportUseCase)
+    meshProcessingHandler := mesh_processing.NewMeshProcessingHandler(meshProcessingUseCase)
+    healthHandler := health.NewHealthHandler(healthUseCase)
+    accessHandler := access.NewAccessHandler(accessUseCase)
+    auditHandler := audit.NewAuditHandler(auditUseCase)
+    modelHandler := model.NewModelHandler(modelUseCase)
+    projectHandler := project.NewProjectHandler(projectUseCase)
+    searchHandler := search.NewSearchHandler(searchUseCase)
+    shareHandler := share.NewShareHandler(shareUseCase)
+    embedHandler := embed.NewEmbedHandler(shareUseCase, embed.Options{
+        PublicURL: cfg.Share.PublicURL,
+        Domains:   cfg.Share.EmbedDomains,
+        Width:     cfg.Share.EmbedWidth,
+        Height:    cfg.Share.EmbedHeight,
+    })
     
+    // 認証（APIキー・JWT）。開発用に認証を無効にした場合のみ、すべてのリクエストを開発用の呼び出し元として扱う
+    authenticator, err := auth.New(cfg.Auth)
+    if err != nil {
+        log.Fatalf("failed to initialize authentication: %v", err)
+    }
+    if authenticator == nil {
+        if !cfg.Auth.Disabled {
+            log.Fatalf("authentication is not configured; configure auth.apiKeys or auth.jwt, or set auth.disabled for local development")
+        }
+        slog.Warn("authentication is disabled for development; every request is treated as admin")
+    } else {
+        // 共有リンクのViewer用トークンは、共有したモデルの派生ファイルの参照のみに使える
+        authenticator = auth.Chain{shareTokens, authenticator}
+    }
+    r.Use(middleware.Authenticate(authenticator, publicPaths...))
+    // 呼び出し元ごとのレート制限と、ロールと権限の付与による認可
+    limiter, err := ratelimit.New(cfg.RateLimit)
+    if err != nil {
+        log.Fatalf("failed to initialize rate limiting: %v", err)
+    }
+    authorize := middleware.Authorize(accessUseCase, auditUseCase, middleware.ModelBucket(modelUseCase))
+    allow := middleware.RateLimit(limiter, authorize)
+    shared := middleware.RateLimit(limiter, middleware.ShareScope(authorize, aps_derivative.ProxyURN, auditUseCase))
+    public := middleware.RateLimit(limiter, middleware.Public)
+    // 管理用エンドポイントは、管理用のアドレスを分けない場合のみadminロールに限る（レート制限はしない）
+    operate := middleware.Public
+    if admin == r {
+        operate = authorize
+    }
+
     // Register routes using modular router files
-    RegisterAPSTokenRoutes(r, apsTokenHandler)
-    RegisterAPSBucketRoutes(r, apsBucketHandler)
-    SetAPSObjectRoutes(r, apsObjectHandler)
+    RegisterAPSTokenRoutes(r, apsTokenHandler, allow)
+    RegisterAPSBucketRoutes(r, apsBucketHandler, allow)
+    SetAPSObjectRoutes(r, apsObjectHandler, allow)
+    SetAPSDerivativeRoutes(r, apsDerivativeHandler, allow, shared)
+    SetAPSExportRoutes(r, apsExportHandler, allow)
+    SetMeshProcessingRoutes(r, meshProcessingHandler, allow)
+    SetAccessRoutes(r, accessHandler, allow)
+    SetAuditRoutes(r, auditHandler, allow)
+    SetModelRoutes(r, modelHandler, allow)
+    SetProjectRoutes(r, projectHandler, allow)
+    SetSearchRoutes(r, searchHandler, allow)
+    SetShareRoutes(r, shareHandler, allow, public)
+    SetEmbedRoutes(r, embedHandler, public)
+    SetHealthRoutes(r, healthHandler)
+    SetDebugRoutes(admin, operate)
+    SetMetricsRoutes(admin, operate)
     
-    return r
+    if admin == r {
+        return r, nil
+    }
+    return r, admin
 }
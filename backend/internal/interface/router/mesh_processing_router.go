@@ -0,0 +1,13 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/mesh_processing"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetMeshProcessingRoutes は抽出したメッシュの後処理関連のルートを設定します
+func SetMeshProcessingRoutes(router *mux.Router, handler *mesh_processing.MeshProcessingHandler, allow middleware.Authorizer) {
+	router.Handle("/api/v1/meshes/glb", allow(domain.ActionMeshProcess, handler.ProcessToGLB)).Methods("POST")
+}
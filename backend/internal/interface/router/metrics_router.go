@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// SetMetricsRoutes はPrometheusのメトリクスのルートを設定します
+// APS呼び出しの所要時間、アップロードしたバイト数、翻訳ジョブの所要時間などをテキスト形式で返します
+func SetMetricsRoutes(router *mux.Router, allow middleware.Authorizer) {
+	router.Handle("/metrics", allow(domain.ActionAuditRead, promhttp.Handler().ServeHTTP)).Methods("GET")
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/model"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetModelRoutes はモデルのカタログのルートを設定します
+// 一覧はバケットを特定しないため、すべてのバケットに対するviewerロールが必要です
+func SetModelRoutes(router *mux.Router, handler *model.ModelHandler, allow middleware.Authorizer) {
+	router.Handle("/api/v1/models", allow(domain.ActionObjectRead, handler.ListModels)).Methods("GET")
+	router.Handle("/api/v1/models/{modelId}", allow(domain.ActionObjectRead, handler.GetModel)).Methods("GET")
+	router.Handle("/api/v1/models/{modelId}", allow(domain.ActionObjectUpload, handler.UpdateModel)).Methods("PATCH")
+	router.Handle("/api/v1/models/{modelId}", allow(domain.ActionObjectUpload, handler.DeleteModel)).Methods("DELETE")
+}
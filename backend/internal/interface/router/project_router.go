@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/project"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetProjectRoutes はプロジェクトとフォルダのルートを設定します
+// プロジェクトはバケットを特定しないため、すべてのバケットに対するロールが必要です
+// OSSのオブジェクトも削除できるプロジェクトの削除にはadminロールが必要です
+func SetProjectRoutes(router *mux.Router, handler *project.ProjectHandler, allow middleware.Authorizer) {
+	router.Handle("/api/v1/projects", allow(domain.ActionObjectRead, handler.ListProjects)).Methods("GET")
+	router.Handle("/api/v1/projects", allow(domain.ActionObjectUpload, handler.CreateProject)).Methods("POST")
+	router.Handle("/api/v1/projects/{projectId}", allow(domain.ActionObjectRead, handler.GetProject)).Methods("GET")
+	router.Handle("/api/v1/projects/{projectId}", allow(domain.ActionObjectUpload, handler.UpdateProject)).Methods("PATCH")
+	router.Handle("/api/v1/projects/{projectId}", allow(domain.ActionBucketDelete, handler.DeleteProject)).Methods("DELETE")
+
+	router.Handle("/api/v1/projects/{projectId}/folders", allow(domain.ActionObjectRead, handler.ListFolders)).Methods("GET")
+	router.Handle("/api/v1/projects/{projectId}/folders", allow(domain.ActionObjectUpload, handler.CreateFolder)).Methods("POST")
+	router.Handle("/api/v1/projects/{projectId}/folders/{folderId}", allow(domain.ActionObjectUpload, handler.UpdateFolder)).Methods("PATCH")
+	router.Handle("/api/v1/projects/{projectId}/folders/{folderId}", allow(domain.ActionObjectUpload, handler.DeleteFolder)).Methods("DELETE")
+	router.Handle("/api/v1/projects/{projectId}/folders/{folderId}/contents", allow(domain.ActionObjectRead, handler.GetFolderContents)).Methods("GET")
+
+	// 移動するモデルのバケットに対するuploaderロールが必要
+	router.Handle("/api/v1/models/{modelId}/move", allow(domain.ActionObjectUpload, handler.MoveModel)).Methods("POST")
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/search"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetSearchRoutes は全文検索のルートを設定します
+// 検索はすべてのバケットのモデルを対象にするため、すべてのバケットに対するロールが必要です
+func SetSearchRoutes(router *mux.Router, handler *search.SearchHandler, allow middleware.Authorizer) {
+	router.Handle("/api/v1/search", allow(domain.ActionObjectRead, handler.Search)).Methods("GET")
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/handler/share"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/interface/middleware"
+)
+
+// SetShareRoutes は共有リンクのルートを設定します
+// 共有リンクの作成と取り消しにはモデルのバケットに対するuploaderロールが必要です
+// 引き換えとViewer用トークンの更新は認証なしで呼び出せるため、publicにはレート制限のみを加えるAuthorizerを渡します
+func SetShareRoutes(router *mux.Router, handler *share.ShareHandler, allow middleware.Authorizer, public middleware.Authorizer) {
+	router.Handle("/api/v1/models/{modelId}/shares", allow(domain.ActionObjectRead, handler.ListShares)).Methods("GET")
+	router.Handle("/api/v1/models/{modelId}/shares", allow(domain.ActionObjectUpload, handler.CreateShare)).Methods("POST")
+	router.Handle("/api/v1/models/{modelId}/shares/{shareId}", allow(domain.ActionObjectUpload, handler.RevokeShare)).Methods("DELETE")
+
+	router.Handle("/api/v1/public/shares/exchange", public(domain.ActionShareView, handler.ExchangeShare)).Methods("POST")
+	router.Handle("/api/v1/public/shares/refresh", public(domain.ActionShareView, handler.RefreshShare)).Methods("POST")
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/config"
+)
+
+// Run はAPIサーバーと管理用サーバー（adminがnilでない場合）を起動し、ctxがキャンセルされるまで待ち受けます
+// 停止時は新しい接続の受け付けをやめ、アップロードや変換ジョブの登録など処理中のリクエストの完了をShutdownTimeoutまで待ちます
+// 期限を過ぎても終わらないリクエストは、コンテキストをキャンセルしてから接続を閉じます
+func Run(ctx context.Context, cfg config.Server, api http.Handler, admin http.Handler) error {
+	// 停止の期限を過ぎたときに処理中のリクエストのコンテキスト（APS呼び出しなど）をまとめてキャンセルする
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	apiServer := newServer(cfg, ":"+cfg.Port, api, baseCtx)
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return err
+		}
+		apiServer.TLSConfig = tlsConfig
+	}
+	servers := []*http.Server{apiServer}
+
+	errCh := make(chan error, 2)
+	go func() {
+		if cfg.TLS.Enabled() {
+			slog.Info("server starting", "port", cfg.Port, "tls", true, "client_cert_required", cfg.TLS.ClientCAFile != "")
+			errCh <- apiServer.ListenAndServeTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+			return
+		}
+		slog.Info("server starting", "port", cfg.Port, "tls", false)
+		errCh <- apiServer.ListenAndServe()
+	}()
+
+	if admin != nil {
+		adminServer := newServer(cfg, cfg.AdminAddr, admin, baseCtx)
+		servers = append(servers, adminServer)
+		go func() {
+			slog.Info("admin server starting", "addr", cfg.AdminAddr)
+			errCh <- adminServer.ListenAndServe()
+		}()
+	}
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down, waiting for in-flight requests", "timeout", cfg.ShutdownTimeout.String())
+	case err := <-errCh:
+		// 待ち受けに失敗した場合も、もう一方のサーバーを停止してから返す
+		serveErr = fmt.Errorf("server stopped: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("shutdown deadline exceeded, cancelling remaining requests", "addr", server.Addr, "error", err)
+			cancelRequests()
+			server.Close()
+		}
+	}
+	if serveErr == nil {
+		slog.Info("server stopped")
+	}
+	return serveErr
+}
+
+// newServer はタイムアウトを設定したhttp.Serverを返します
+func newServer(cfg config.Server, addr string, handler http.Handler, baseCtx context.Context) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+}
+
+// newTLSConfig はTLSの設定を返します。クライアントのCA証明書を指定した場合はクライアント証明書を必須にします
+func newTLSConfig(cfg config.TLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.ClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in client CA file " + cfg.ClientCAFile)
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
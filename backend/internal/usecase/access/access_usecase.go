@@ -0,0 +1,26 @@
+package access
+
+import (
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// AccessUseCase はロールと権限の付与による認可のユースケース実装
+type AccessUseCase struct {
+	grantRepo domain.GrantRepository
+	audit     domain.AuditLogger
+	now       func() time.Time
+}
+
+// NewAccessUseCase は新しいAccessUseCaseを作成します
+func NewAccessUseCase(grantRepo domain.GrantRepository, audit domain.AuditLogger) *AccessUseCase {
+	return &AccessUseCase{
+		grantRepo: grantRepo,
+		audit:     audit,
+		now:       time.Now,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.AccessUseCase = (*AccessUseCase)(nil)
@@ -0,0 +1,67 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Authorize は呼び出し元のロールと権限の付与から、バケットに対して操作できるか確認します
+// 呼び出し元のすべてのバケットに対するロールと、当てはまる付与のうち最も強いロールで判定します
+// 拒否した場合は監査ログに記録します
+func (u *AccessUseCase) Authorize(ctx context.Context, action domain.Action, bucketKey string) error {
+	required, ok := domain.RequiredRoles[action]
+	if !ok {
+		return fmt.Errorf("%w: unknown action %s", domain.ErrAccessDenied, action)
+	}
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: not authenticated", domain.ErrAccessDenied)
+	}
+
+	role, err := u.effectiveRole(principal, bucketKey)
+	if err != nil {
+		return err
+	}
+	if domain.RoleRank(role) >= domain.RoleRank(required) {
+		return nil
+	}
+
+	slog.WarnContext(ctx, "access denied",
+		"action", string(action),
+		"bucket_key", bucketKey,
+		"required_role", required,
+		"role", role,
+	)
+	target := ""
+	err = fmt.Errorf("%w: %s requires the %s role", domain.ErrAccessDenied, action, required)
+	if bucketKey != "" {
+		target = domain.BucketTarget(bucketKey)
+		err = fmt.Errorf("%w: %s on bucket %s requires the %s role", domain.ErrAccessDenied, action, bucketKey, required)
+	}
+	u.audit.Record(ctx, action, target, err)
+	return err
+}
+
+// effectiveRole は呼び出し元のバケットに対する最も強いロールを返します。ロールがない場合は空文字列を返します
+func (u *AccessUseCase) effectiveRole(principal *domain.Principal, bucketKey string) (string, error) {
+	role := ""
+	for _, r := range principal.Roles {
+		if domain.RoleRank(r) > domain.RoleRank(role) {
+			role = r
+		}
+	}
+
+	grants, err := u.grantRepo.List()
+	if err != nil {
+		return "", fmt.Errorf("failed to load grants: %w", err)
+	}
+	for i := range grants {
+		if grants[i].Matches(principal, bucketKey) && domain.RoleRank(grants[i].Role) > domain.RoleRank(role) {
+			role = grants[i].Role
+		}
+	}
+	return role, nil
+}
@@ -0,0 +1,111 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// memoryGrantRepo はメモリ上の権限の付与
+type memoryGrantRepo struct {
+	grants []domain.Grant
+}
+
+func (r *memoryGrantRepo) List() ([]domain.Grant, error) { return r.grants, nil }
+func (r *memoryGrantRepo) Save(grant *domain.Grant) error {
+	r.grants = append(r.grants, *grant)
+	return nil
+}
+func (r *memoryGrantRepo) Delete(id string) error { return nil }
+
+// recordingAudit は監査ログに記録した操作と結果を保持します
+type recordingAudit struct {
+	actions []domain.Action
+	errs    []error
+}
+
+func (a *recordingAudit) Record(ctx context.Context, action domain.Action, target string, err error) {
+	a.actions = append(a.actions, action)
+	a.errs = append(a.errs, err)
+}
+
+func TestAuthorizeEffectiveRole(t *testing.T) {
+	grants := []domain.Grant{
+		{ID: "1", Subject: "alice", Role: domain.RoleUploader, BucketPrefix: "teama-"},
+		{ID: "2", Subject: "group:ops", Role: domain.RoleAdmin, BucketPrefix: "ops-"},
+		{ID: "3", Subject: "carol", Role: domain.RoleUploader},
+	}
+	alice := &domain.Principal{ID: "alice", Method: domain.AuthMethodJWT, Roles: []string{domain.RoleViewer}}
+	bob := &domain.Principal{ID: "bob", Method: domain.AuthMethodJWT, Groups: []string{"ops"}}
+	carol := &domain.Principal{ID: "carol", Method: domain.AuthMethodAPIKey}
+	unknownRole := &domain.Principal{ID: "dave", Method: domain.AuthMethodJWT, Roles: []string{"superuser"}}
+
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		action    domain.Action
+		bucketKey string
+		wantErr   bool
+	}{
+		{name: "global role allows reading any bucket", principal: alice, action: domain.ActionObjectRead, bucketKey: "teamb-models"},
+		{name: "global role does not allow uploads", principal: alice, action: domain.ActionObjectUpload, bucketKey: "teamb-models", wantErr: true},
+		{name: "prefix grant allows uploads to a matching bucket", principal: alice, action: domain.ActionObjectUpload, bucketKey: "teama-models"},
+		{name: "prefix grant is case sensitive", principal: alice, action: domain.ActionObjectUpload, bucketKey: "TEAMA-models", wantErr: true},
+		{name: "prefix grant does not match another bucket", principal: alice, action: domain.ActionObjectUpload, bucketKey: "teamb-teama-models", wantErr: true},
+		// バケットを特定しない操作には、接頭辞のない付与のみ当てはまる
+		{name: "prefix grant does not apply without a bucket", principal: alice, action: domain.ActionObjectUpload, bucketKey: "", wantErr: true},
+		{name: "group grant allows bucket deletion", principal: bob, action: domain.ActionBucketDelete, bucketKey: "ops-archive"},
+		{name: "group grant does not apply without a bucket", principal: bob, action: domain.ActionBucketCreate, bucketKey: "", wantErr: true},
+		{name: "group grant does not match another bucket", principal: bob, action: domain.ActionObjectRead, bucketKey: "teama-models", wantErr: true},
+		{name: "grant without prefix applies without a bucket", principal: carol, action: domain.ActionObjectUpload, bucketKey: ""},
+		{name: "grant without prefix applies to every bucket", principal: carol, action: domain.ActionObjectUpload, bucketKey: "teamb-models"},
+		{name: "grant without prefix does not exceed its role", principal: carol, action: domain.ActionBucketDelete, bucketKey: "teamb-models", wantErr: true},
+		{name: "unknown role has no access", principal: unknownRole, action: domain.ActionObjectRead, bucketKey: "teama-models", wantErr: true},
+		{name: "anonymous has no access", principal: domain.AnonymousPrincipal, action: domain.ActionObjectRead, bucketKey: "teama-models", wantErr: true},
+		{name: "unknown action is denied", principal: domain.DevelopmentPrincipal, action: domain.Action("bucket:purge"), bucketKey: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &recordingAudit{}
+			u := NewAccessUseCase(&memoryGrantRepo{grants: grants}, audit)
+			ctx := domain.WithPrincipal(context.Background(), tt.principal)
+
+			err := u.Authorize(ctx, tt.action, tt.bucketKey)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Authorize() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrAccessDenied) {
+				t.Fatalf("Authorize() = %v, want ErrAccessDenied", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeAuditsDenials(t *testing.T) {
+	audit := &recordingAudit{}
+	u := NewAccessUseCase(&memoryGrantRepo{}, audit)
+	viewer := &domain.Principal{ID: "alice", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleViewer}}
+	ctx := domain.WithPrincipal(context.Background(), viewer)
+
+	if err := u.Authorize(ctx, domain.ActionObjectRead, "teama-models"); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Authorize(ctx, domain.ActionBucketDelete, "teama-models"); !errors.Is(err, domain.ErrAccessDenied) {
+		t.Fatalf("Authorize() = %v, want ErrAccessDenied", err)
+	}
+	if len(audit.actions) != 1 || audit.actions[0] != domain.ActionBucketDelete || !errors.Is(audit.errs[0], domain.ErrAccessDenied) {
+		t.Errorf("audit = %v %v, want one denied bucket:delete", audit.actions, audit.errs)
+	}
+}
+
+func TestAuthorizeWithoutPrincipal(t *testing.T) {
+	u := NewAccessUseCase(&memoryGrantRepo{}, &recordingAudit{})
+	if err := u.Authorize(context.Background(), domain.ActionObjectRead, "teama-models"); !errors.Is(err, domain.ErrAccessDenied) {
+		t.Fatalf("Authorize() = %v, want ErrAccessDenied", err)
+	}
+}
@@ -0,0 +1,51 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ListGrants は権限の付与を作成順に返します
+func (u *AccessUseCase) ListGrants(ctx context.Context) ([]domain.Grant, error) {
+	return u.grantRepo.List()
+}
+
+// CreateGrant は呼び出し元またはグループに、バケットの接頭辞に対するロールを付与します
+func (u *AccessUseCase) CreateGrant(ctx context.Context, req *domain.GrantRequest) (*domain.Grant, error) {
+	subject := strings.TrimSpace(req.Subject)
+	if subject == "" || subject == domain.GroupSubjectPrefix {
+		return nil, fmt.Errorf("%w: subject is required", domain.ErrInvalidGrant)
+	}
+	if domain.RoleRank(req.Role) == 0 {
+		return nil, fmt.Errorf("%w: role must be viewer, uploader or admin", domain.ErrInvalidGrant)
+	}
+
+	grant := &domain.Grant{
+		ID:           uuid.NewString(),
+		Subject:      subject,
+		Role:         req.Role,
+		BucketPrefix: strings.TrimSpace(req.BucketPrefix),
+		CreatedAt:    u.now().UTC(),
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		grant.CreatedBy = principal.ID
+	}
+	err := u.grantRepo.Save(grant)
+	u.audit.Record(ctx, domain.ActionGrantCreate, domain.GrantTarget(grant.ID), err)
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// DeleteGrant は権限の付与を取り消します
+func (u *AccessUseCase) DeleteGrant(ctx context.Context, id string) error {
+	err := u.grantRepo.Delete(id)
+	u.audit.Record(ctx, domain.ActionGrantDelete, domain.GrantTarget(id), err)
+	return err
+}
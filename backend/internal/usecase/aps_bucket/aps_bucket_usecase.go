@@ -8,11 +8,13 @@ import (
 type APSBucketUseCase struct {
     bucketRepo domain.APSBucketRepository
     tokenUseCase *aps_token.APSTokenUseCase
+    audit domain.AuditLogger
 }
 
-func NewAPSBucketUseCase(bucketRepo domain.APSBucketRepository, tokenUseCase *aps_token.APSTokenUseCase) *APSBucketUseCase {
+func NewAPSBucketUseCase(bucketRepo domain.APSBucketRepository, tokenUseCase *aps_token.APSTokenUseCase, audit domain.AuditLogger) *APSBucketUseCase {
     return &APSBucketUseCase{
         bucketRepo: bucketRepo,
         tokenUseCase: tokenUseCase,
+        audit: audit,
     }
 }
\ No newline at end of file
@@ -1,14 +1,28 @@
 package aps_bucket
 
 import (
+    "context"
+
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
 )
 
-func (u *APSBucketUseCase) CreateBucket() (*domain.APSBucket, error) {
-    token, err := u.tokenUseCase.GetToken()
+func (u *APSBucketUseCase) CreateBucket(ctx context.Context) (bucket *domain.APSBucket, err error) {
+    ctx, span := tracing.Start(ctx, "APSBucketUseCase.CreateBucket")
+    defer func() {
+        bucketKey := ""
+        if err == nil {
+            bucketKey = bucket.BucketKey
+            span.SetAttributes(tracing.BucketKey.String(bucketKey))
+        }
+        u.audit.Record(ctx, domain.ActionBucketCreate, domain.BucketTarget(bucketKey), err)
+        tracing.End(span, err)
+    }()
+
+    token, err := u.tokenUseCase.GetToken(ctx)
     if err != nil {
         return nil, err
     }
     
-    return u.bucketRepo.CreateBucket(token.AccessToken)
+    return u.bucketRepo.CreateBucket(ctx, token.AccessToken)
 }
\ No newline at end of file
@@ -1,10 +1,23 @@
 package aps_bucket
 
-func (u *APSBucketUseCase) DeleteBucket(bucketKey string) error {
-    token, err := u.tokenUseCase.GetToken()
+import (
+    "context"
+
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+func (u *APSBucketUseCase) DeleteBucket(ctx context.Context, bucketKey string) (err error) {
+    ctx, span := tracing.Start(ctx, "APSBucketUseCase.DeleteBucket", tracing.BucketKey.String(bucketKey))
+    defer func() {
+        u.audit.Record(ctx, domain.ActionBucketDelete, domain.BucketTarget(bucketKey), err)
+        tracing.End(span, err)
+    }()
+
+    token, err := u.tokenUseCase.GetToken(ctx)
     if err != nil {
         return err
     }
 
-    return u.bucketRepo.DeleteBucket(token.AccessToken, bucketKey)
+    return u.bucketRepo.DeleteBucket(ctx, token.AccessToken, bucketKey)
 }
\ No newline at end of file
@@ -2,14 +2,19 @@ package aps_bucket
 
 import (
     "context"
+
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
 )
 
-func (u *APSBucketUseCase) GetBuckets(ctx context.Context) (*domain.BucketsResponse, error) {
-    token, err := u.tokenUseCase.GetToken()
+func (u *APSBucketUseCase) GetBuckets(ctx context.Context) (buckets *domain.BucketsResponse, err error) {
+    ctx, span := tracing.Start(ctx, "APSBucketUseCase.GetBuckets")
+    defer func() { tracing.End(span, err) }()
+
+    token, err := u.tokenUseCase.GetToken(ctx)
     if err != nil {
         return nil, err
     }
     
-    return u.bucketRepo.GetBuckets(token.AccessToken)
+    return u.bucketRepo.GetBuckets(ctx, token.AccessToken)
 }
\ No newline at end of file
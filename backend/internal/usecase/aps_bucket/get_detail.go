@@ -1,12 +1,20 @@
 package aps_bucket
 
-import "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+import (
+    "context"
 
-func (u *APSBucketUseCase) GetBucketDetail(bucketKey string) (*domain.APSBucketDetail, error) {
-    token, err := u.tokenUseCase.GetToken()
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+func (u *APSBucketUseCase) GetBucketDetail(ctx context.Context, bucketKey string) (detail *domain.APSBucketDetail, err error) {
+    ctx, span := tracing.Start(ctx, "APSBucketUseCase.GetBucketDetail", tracing.BucketKey.String(bucketKey))
+    defer func() { tracing.End(span, err) }()
+
+    token, err := u.tokenUseCase.GetToken(ctx)
     if err != nil {
         return nil, err
     }
 
-    return u.bucketRepo.GetBucketDetail(token.AccessToken, bucketKey)
+    return u.bucketRepo.GetBucketDetail(ctx, token.AccessToken, bucketKey)
 }
\ No newline at end of file
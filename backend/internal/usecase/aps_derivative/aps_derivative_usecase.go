@@ -0,0 +1,75 @@
+package aps_derivative
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 同時ダウンロード数の既定値
+const defaultBundleConcurrency = 4
+
+// APSDerivativeUseCase はModel Derivativeの派生ファイルを扱うユースケース実装
+type APSDerivativeUseCase struct {
+	derivativeRepo domain.APSDerivativeRepository
+	objectRepo     domain.APSObjectRepository
+	cacheRepo      domain.DerivativeCacheRepository
+	workDir        string
+	concurrency    int
+
+	// GLB変換の重複実行とOBJジョブの重複送信を防ぐための状態
+	glbLocks     sync.Map
+	glbMu        sync.Mutex
+	glbSubmitted map[string]time.Time
+	// 同じURNのバンドル作成が作業ディレクトリへ並行して書き込まないようにするためのロック
+	bundleLocks sync.Map
+	// 書き出し中のバンドルの作業ディレクトリごとの数。書き出し中のバージョンは削除しない
+	bundleMu      sync.Mutex
+	bundleReaders map[string]int
+}
+
+// NewAPSDerivativeUseCase は新しいAPSDerivativeUseCaseを作成します
+// cacheRepoがnilの場合、プロキシはキャッシュせずにそのまま転送します
+// workDirはダウンロード途中のファイルを保持し、再実行時に続きから取得するために使用します
+func NewAPSDerivativeUseCase(derivativeRepo domain.APSDerivativeRepository, objectRepo domain.APSObjectRepository, cacheRepo domain.DerivativeCacheRepository, workDir string, concurrency int) *APSDerivativeUseCase {
+	if workDir == "" {
+		workDir = filepath.Join(os.TempDir(), "aps-bundles")
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBundleConcurrency
+	}
+
+	return &APSDerivativeUseCase{
+		derivativeRepo: derivativeRepo,
+		objectRepo:     objectRepo,
+		cacheRepo:      cacheRepo,
+		workDir:        workDir,
+		concurrency:    concurrency,
+		glbSubmitted:   map[string]time.Time{},
+		bundleReaders:  map[string]int{},
+	}
+}
+
+// viewableVersion はSVF/SVF2の派生ファイルの内容から翻訳のバージョンを作成します
+// 同じURNで再翻訳した場合に、以前の翻訳から作成したバンドルやGLBを使わないようにするために使用します
+// OBJなど追加で作成した派生ファイルではバージョンが変わらないよう、SVF/SVF2のみを対象にします
+func viewableVersion(manifest *domain.TranslationStatus) string {
+	var viewables []domain.Derivative
+	for _, derivative := range manifest.Derivatives {
+		if derivative.OutputType == "svf" || derivative.OutputType == "svf2" {
+			viewables = append(viewables, derivative)
+		}
+	}
+	data, _ := json.Marshal(viewables)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// インターフェースの実装を確認
+var _ domain.APSDerivativeUseCase = (*APSDerivativeUseCase)(nil)
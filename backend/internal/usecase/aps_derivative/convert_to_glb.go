@@ -0,0 +1,223 @@
+package aps_derivative
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/mesh"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+const (
+	// 変換処理を変更した場合はこの値を上げて、古いGLBのキャッシュを使わないようにする
+	glbConverterVersion = "1"
+	// OBJのジョブを送信してからマニフェストに現れるまで、再送信しない期間
+	glbSubmitCooldown = 15 * time.Minute
+)
+
+// ConvertToGLB はOBJ派生ファイルをバイナリglTFに変換します
+// OBJがまだなければジョブを送信し、作成中であれば進捗を返します
+// 変換したGLBはURN・翻訳のバージョン・modelGuid・objectIds・変換処理のバージョンをキーにキャッシュします
+// objectIdsはモデルビューごとの番号のため、指定する場合はmodelGuidも必要です
+func (u *APSDerivativeUseCase) ConvertToGLB(ctx context.Context, urn string, modelGUID string, objectIDs []int) (*domain.GLBConversion, error) {
+	ctx, span := tracing.Start(ctx, "APSDerivativeUseCase.ConvertToGLB", tracing.URN.String(urn), tracing.ModelGUID.String(modelGUID))
+	conversion, err := u.convertToGLB(ctx, urn, modelGUID, objectIDs)
+	tracing.End(span, err)
+	return conversion, err
+}
+
+// convertToGLB はConvertToGLBの本体
+func (u *APSDerivativeUseCase) convertToGLB(ctx context.Context, urn string, modelGUID string, objectIDs []int) (*domain.GLBConversion, error) {
+	ids := slices.Clone(objectIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	if len(ids) > 0 && modelGUID == "" {
+		return nil, fmt.Errorf("%w: objectIds requires modelGuid", domain.ErrInvalidGLBRequest)
+	}
+
+	// 再翻訳した場合に以前の翻訳から変換したGLBを使わないよう、キーに翻訳のバージョンを含める
+	manifest, err := u.objectRepo.TrackTranslationJobStatus(ctx, urn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+
+	conversion := &domain.GLBConversion{
+		URN:       urn,
+		ModelGUID: modelGUID,
+		ObjectIDs: ids,
+		Key:       glbKey(urn, viewableVersion(manifest), modelGUID, ids),
+	}
+
+	// 同じキーの変換が並行して走らないようにする
+	lock, _ := u.glbLocks.LoadOrStore(conversion.Key, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	if info, err := os.Stat(u.glbPath(conversion.Key)); err == nil {
+		conversion.Status = "success"
+		conversion.Size = info.Size()
+		return conversion, nil
+	}
+
+	objFile, mtlFile := manifest.FindOBJResources(modelGUID, ids)
+	if objFile == nil {
+		if u.recentlySubmitted(conversion.Key) {
+			conversion.Status = "pending"
+			return conversion, nil
+		}
+
+		advanced := map[string]any{}
+		if modelGUID != "" {
+			advanced["modelGuid"] = modelGUID
+		}
+		if len(ids) > 0 {
+			advanced["objectIds"] = ids
+		}
+		formats := []domain.DerivativeOutputFormat{{Type: "obj", Advanced: advanced}}
+		if _, err := u.derivativeRepo.SubmitDerivativeJob(ctx, urn, formats); err != nil {
+			return nil, fmt.Errorf("failed to submit obj job: %w", err)
+		}
+
+		u.markSubmitted(conversion.Key)
+		conversion.Status = "pending"
+		return conversion, nil
+	}
+
+	switch objFile.Status {
+	case "success":
+	case "failed", "timeout":
+		return nil, fmt.Errorf("obj derivative %s: status=%s", objFile.URN, objFile.Status)
+	default:
+		conversion.Status = objFile.Status
+		conversion.Progress = objFile.Progress
+		return conversion, nil
+	}
+
+	size, err := u.convertOBJ(ctx, urn, objFile, mtlFile, conversion)
+	if err != nil {
+		return nil, err
+	}
+
+	u.clearSubmitted(conversion.Key)
+	conversion.Status = "success"
+	conversion.Size = size
+	return conversion, nil
+}
+
+// OpenGLB は変換済みのGLBを開きます
+func (u *APSDerivativeUseCase) OpenGLB(conversion *domain.GLBConversion) (io.ReadSeekCloser, error) {
+	return os.Open(u.glbPath(conversion.Key))
+}
+
+// convertOBJ はOBJとMTLをダウンロードしてGLBに変換し、キャッシュに保存します
+func (u *APSDerivativeUseCase) convertOBJ(ctx context.Context, urn string, objFile *domain.Children, mtlFile *domain.Children, conversion *domain.GLBConversion) (int64, error) {
+	var materials map[string]mesh.Material
+	if mtlFile != nil {
+		body, err := u.openDerivative(ctx, urn, mtlFile.URN)
+		if err != nil {
+			return 0, fmt.Errorf("failed to download mtl: %w", err)
+		}
+		materials, err = mesh.ParseMTL(body)
+		body.Close()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse mtl: %w", err)
+		}
+	}
+
+	body, err := u.openDerivative(ctx, urn, objFile.URN)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download obj: %w", err)
+	}
+	scene, err := mesh.ParseOBJ(body, materials)
+	body.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse obj: %w", err)
+	}
+
+	dst := u.glbPath(conversion.Key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return 0, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "glb-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	extras := map[string]any{
+		"urn":       conversion.URN,
+		"modelGuid": conversion.ModelGUID,
+		"objectIds": conversion.ObjectIDs,
+	}
+	if err := mesh.EncodeGLB(tmp, scene, extras); err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("failed to encode glb: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// openDerivative は派生ファイルを署名付きCookieでダウンロードします
+func (u *APSDerivativeUseCase) openDerivative(ctx context.Context, urn string, derivativeURN string) (io.ReadCloser, error) {
+	download, err := u.derivativeRepo.GetDerivativeDownload(ctx, urn, derivativeURN)
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := u.derivativeRepo.DownloadDerivative(ctx, download, 0)
+	return body, err
+}
+
+// recentlySubmitted はOBJのジョブを最近送信したか確認します
+func (u *APSDerivativeUseCase) recentlySubmitted(key string) bool {
+	u.glbMu.Lock()
+	defer u.glbMu.Unlock()
+
+	submittedAt, ok := u.glbSubmitted[key]
+	return ok && time.Since(submittedAt) < glbSubmitCooldown
+}
+
+// markSubmitted はOBJのジョブを送信した日時を記録します
+func (u *APSDerivativeUseCase) markSubmitted(key string) {
+	u.glbMu.Lock()
+	defer u.glbMu.Unlock()
+
+	u.glbSubmitted[key] = time.Now()
+}
+
+// clearSubmitted は送信記録を削除します
+func (u *APSDerivativeUseCase) clearSubmitted(key string) {
+	u.glbMu.Lock()
+	defer u.glbMu.Unlock()
+
+	delete(u.glbSubmitted, key)
+}
+
+// glbPath はGLBのキャッシュファイルのパスを返します
+func (u *APSDerivativeUseCase) glbPath(key string) string {
+	return filepath.Join(u.workDir, "glb", key+".glb")
+}
+
+// glbKey はGLBのキャッシュキーを作成します
+func glbKey(urn string, version string, modelGUID string, objectIDs []int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s\n%v\n%s", urn, version, modelGUID, objectIDs, glbConverterVersion)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,406 @@
+package aps_derivative
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+const mimeSVF = "application/autodesk-svf"
+
+// bundleEntry はダウンロード対象のファイルと、検証に使う期待サイズ
+type bundleEntry struct {
+	file  domain.BundleFile
+	sizes []int64
+}
+
+// svfManifest は.svfパッケージ内のmanifest.jsonのうちバンドル作成に必要な部分
+type svfManifest struct {
+	Assets []struct {
+		ID    string `json:"id"`
+		URI   string `json:"URI"`
+		Size  int64  `json:"size"`
+		Usize int64  `json:"usize"`
+	} `json:"assets"`
+}
+
+// PrepareBundle はマニフェストを辿ってSVFの派生ファイルをすべてダウンロードし、検証済みのバンドルを返します
+// ダウンロード済みのファイルは翻訳のバージョンごとの作業ディレクトリに残るため、途中で失敗しても再実行すると続きから取得します
+// 返したバンドルはWriteBundleZipで書き出し終えるまで、再翻訳後のPrepareBundleでも削除しません
+// SVF2はアセットを複数のURNで共有し、Viewerのローカルモードでも読み込めないため、SVF2のみのモデルはErrBundleNotSupportedを返します
+func (u *APSDerivativeUseCase) PrepareBundle(ctx context.Context, urn string) (*domain.DerivativeBundle, error) {
+	ctx, span := tracing.Start(ctx, "APSDerivativeUseCase.PrepareBundle", tracing.URN.String(urn))
+	bundle, err := u.prepareBundle(ctx, urn)
+	tracing.End(span, err)
+	return bundle, err
+}
+
+// prepareBundle はPrepareBundleの本体
+func (u *APSDerivativeUseCase) prepareBundle(ctx context.Context, urn string) (*domain.DerivativeBundle, error) {
+	manifest, err := u.objectRepo.TrackTranslationJobStatus(ctx, urn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	if manifest.Status != "success" {
+		return nil, fmt.Errorf("translation is not complete: status=%s, progress=%s", manifest.Status, manifest.Progress)
+	}
+
+	// 同じURNのバンドル作成が同じ.partファイルに並行して書き込まないようにする
+	lock, _ := u.bundleLocks.LoadOrStore(urn, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// 再翻訳した場合に以前の翻訳のファイルを使わないよう、作業ディレクトリを翻訳のバージョンで分ける
+	version := viewableVersion(manifest)
+	dir := filepath.Join(u.workDir, urn, version)
+	bundle := &domain.DerivativeBundle{URN: urn, Dir: dir}
+
+	// ステップ1: マニフェストに記載された派生ファイルを集める
+	entries := map[string]*bundleEntry{}
+	var order []string
+	add := func(derivativeURN string) error {
+		p, err := bundlePath(urn, derivativeURN)
+		if err != nil {
+			return err
+		}
+		if _, ok := entries[p]; ok {
+			return nil
+		}
+		entries[p] = &bundleEntry{
+			file: domain.BundleFile{DerivativeURN: derivativeURN, Path: p},
+		}
+		order = append(order, p)
+		return nil
+	}
+
+	hasSVF2 := false
+	for _, derivative := range manifest.Derivatives {
+		if derivative.OutputType == "svf2" {
+			hasSVF2 = true
+		}
+		if derivative.OutputType != "svf" {
+			continue
+		}
+		for _, child := range derivative.Children {
+			if child.URN != "" {
+				if err := add(child.URN); err != nil {
+					return nil, err
+				}
+			}
+			for _, resource := range child.Children {
+				if resource.URN == "" {
+					continue
+				}
+				if err := add(resource.URN); err != nil {
+					return nil, err
+				}
+				if resource.Mime == mimeSVF {
+					p, _ := bundlePath(urn, resource.URN)
+					bundle.Viewables = append(bundle.Viewables, domain.BundleViewable{
+						GUID: child.GUID,
+						Name: child.Name,
+						Role: child.Role,
+						Mime: resource.Mime,
+						Path: p,
+					})
+				}
+			}
+		}
+	}
+	if len(order) == 0 {
+		if hasSVF2 {
+			return nil, fmt.Errorf("%w: SVF2 derivatives cannot be bundled, translate the model to SVF", domain.ErrBundleNotSupported)
+		}
+		return nil, fmt.Errorf("%w: manifest has no SVF derivatives", domain.ErrBundleNotSupported)
+	}
+
+	if err := u.downloadAll(ctx, urn, dir, entries, order); err != nil {
+		return nil, err
+	}
+
+	// ステップ2: .svfパッケージ内のmanifest.jsonが参照するファイルを集める
+	var assetOrder []string
+	for _, p := range order {
+		if path.Ext(p) != ".svf" {
+			continue
+		}
+		assets, err := readSVFManifest(filepath.Join(dir, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		for _, asset := range assets.Assets {
+			// embed:/ はパッケージ内に埋め込まれているためダウンロード不要
+			if asset.URI == "" || strings.HasPrefix(asset.URI, "embed:") {
+				continue
+			}
+			assetPath := path.Join(path.Dir(p), asset.URI)
+			if assetPath == ".." || strings.HasPrefix(assetPath, "../") {
+				continue
+			}
+			if entry, ok := entries[assetPath]; ok {
+				entry.sizes = append(entry.sizes, asset.Size, asset.Usize)
+				continue
+			}
+			entries[assetPath] = &bundleEntry{
+				file:  domain.BundleFile{DerivativeURN: derivativePrefix(urn) + assetPath, Path: assetPath},
+				sizes: []int64{asset.Size, asset.Usize},
+			}
+			assetOrder = append(assetOrder, assetPath)
+		}
+	}
+
+	if err := u.downloadAll(ctx, urn, dir, entries, assetOrder); err != nil {
+		return nil, err
+	}
+
+	// ステップ3: マニフェストと突き合わせて検証する
+	if err := validateBundle(dir, entries); err != nil {
+		return nil, err
+	}
+
+	for _, p := range append(order, assetOrder...) {
+		bundle.Files = append(bundle.Files, entries[p].file)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), manifestJSON, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	u.acquireBundle(dir)
+	u.removeStaleBundles(ctx, urn, version)
+
+	return bundle, nil
+}
+
+// acquireBundle はバンドルを書き出し中として数えます
+func (u *APSDerivativeUseCase) acquireBundle(dir string) {
+	u.bundleMu.Lock()
+	defer u.bundleMu.Unlock()
+
+	u.bundleReaders[dir]++
+}
+
+// releaseBundle はバンドルの書き出しを終えたことを記録します
+func (u *APSDerivativeUseCase) releaseBundle(dir string) {
+	u.bundleMu.Lock()
+	defer u.bundleMu.Unlock()
+
+	if u.bundleReaders[dir] <= 1 {
+		delete(u.bundleReaders, dir)
+		return
+	}
+	u.bundleReaders[dir]--
+}
+
+// removeStaleBundles は以前の翻訳のバージョンの作業ディレクトリを削除します
+// 書き出し中のバージョンは残し、以降のPrepareBundleで削除します。呼び出し側でURNのロックを保持してください
+func (u *APSDerivativeUseCase) removeStaleBundles(ctx context.Context, urn string, version string) {
+	root := filepath.Join(u.workDir, urn)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+
+	u.bundleMu.Lock()
+	defer u.bundleMu.Unlock()
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		if entry.Name() == version || u.bundleReaders[dir] > 0 {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			slog.WarnContext(ctx, "failed to remove stale bundle", "urn", urn, "path", entry.Name(), "error", err)
+		}
+	}
+}
+
+// downloadAll は指定されたファイルを並行してダウンロードします
+func (u *APSDerivativeUseCase) downloadAll(ctx context.Context, urn string, dir string, entries map[string]*bundleEntry, paths []string) error {
+	sem := make(chan struct{}, u.concurrency)
+	errs := make([]error, len(paths))
+
+	var wg sync.WaitGroup
+	for i, p := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			entry := entries[p]
+			size, err := u.downloadFile(ctx, urn, dir, entry.file)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to download %s: %w", p, err)
+				return
+			}
+			entry.file.Size = size
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// downloadFile は派生ファイルを1つダウンロードします
+// 途中まで取得済みの.partファイルがあればRangeリクエストで続きから取得します
+// .partを最後まで取得していた場合は416が返るため、サイズがAPSの示すサイズと一致すれば取得済みとして扱います
+func (u *APSDerivativeUseCase) downloadFile(ctx context.Context, urn string, dir string, file domain.BundleFile) (int64, error) {
+	dst := filepath.Join(dir, filepath.FromSlash(file.Path))
+	if info, err := os.Stat(dst); err == nil {
+		return info.Size(), nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return 0, err
+	}
+
+	partPath := dst + ".part"
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	download, err := u.derivativeRepo.GetDerivativeDownload(ctx, urn, file.DerivativeURN)
+	if err != nil {
+		return 0, err
+	}
+
+	body, resumed, err := u.derivativeRepo.DownloadDerivative(ctx, download, offset)
+	var apsErr *domain.APSError
+	if offset > 0 && errors.As(err, &apsErr) && apsErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		if download.Size > 0 && offset == download.Size {
+			return finishDownload(partPath, dst)
+		}
+		// サイズが一致しない.partは壊れているため最初から取得し直す
+		body, resumed, err = u.derivativeRepo.DownloadDerivative(ctx, download, 0)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resumed {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(partPath, flags, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+
+	return finishDownload(partPath, dst)
+}
+
+// finishDownload は取得を終えた.partファイルを本来のパスへ移動し、サイズを返します
+func finishDownload(partPath string, dst string) (int64, error) {
+	if err := os.Rename(partPath, dst); err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// validateBundle はすべてのファイルが揃っていて、サイズがマニフェストと一致するか確認します
+func validateBundle(dir string, entries map[string]*bundleEntry) error {
+	var errs []error
+	for p, entry := range entries {
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p)))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("missing file %s", p))
+			continue
+		}
+		if !sizeMatches(info.Size(), entry.sizes) {
+			errs = append(errs, fmt.Errorf("size mismatch for %s: got %d bytes, expected one of %v", p, info.Size(), entry.sizes))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sizeMatches は圧縮後・展開後どちらかのサイズと一致するか判定します
+func sizeMatches(size int64, expected []int64) bool {
+	known := false
+	for _, s := range expected {
+		if s <= 0 {
+			continue
+		}
+		known = true
+		if s == size {
+			return true
+		}
+	}
+	return !known
+}
+
+// readSVFManifest は.svfパッケージ（zip）からmanifest.jsonを読み込みます
+func readSVFManifest(svfPath string) (*svfManifest, error) {
+	zr, err := zip.OpenReader(svfPath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != "manifest.json" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		var manifest svfManifest
+		if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+			return nil, err
+		}
+		return &manifest, nil
+	}
+
+	return nil, fmt.Errorf("manifest.json not found in svf package")
+}
+
+// derivativePrefix はURNに対応する派生URNの共通接頭辞を返します
+func derivativePrefix(urn string) string {
+	return "urn:adsk.viewing:fs.file:" + urn + "/"
+}
+
+// bundlePath は派生URNをバンドル内の相対パスに変換します
+// Viewerのローカルモードは.svfからの相対パスで他のファイルを参照するため、派生URNの階層をそのまま残します
+func bundlePath(urn string, derivativeURN string) (string, error) {
+	rel, ok := strings.CutPrefix(derivativeURN, derivativePrefix(urn))
+	if !ok {
+		return "", fmt.Errorf("unexpected derivative urn: %s", derivativeURN)
+	}
+	rel = path.Clean(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") || strings.HasPrefix(rel, "/") {
+		return "", fmt.Errorf("invalid derivative path: %s", derivativeURN)
+	}
+	return rel, nil
+}
@@ -0,0 +1,46 @@
+package aps_derivative
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+func TestRemoveStaleBundlesKeepsBundlesBeingWritten(t *testing.T) {
+	ctx := context.Background()
+	u := NewAPSDerivativeUseCase(nil, nil, nil, t.TempDir(), 1)
+
+	urn := "dXJu"
+	for _, version := range []string{"old", "streaming", "current"} {
+		if err := os.MkdirAll(filepath.Join(u.workDir, urn, version), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	streaming := &domain.DerivativeBundle{URN: urn, Dir: filepath.Join(u.workDir, urn, "streaming")}
+	u.acquireBundle(streaming.Dir)
+
+	u.removeStaleBundles(ctx, urn, "current")
+	assertExists(t, filepath.Join(u.workDir, urn, "old"), false)
+	assertExists(t, filepath.Join(u.workDir, urn, "streaming"), true)
+	assertExists(t, filepath.Join(u.workDir, urn, "current"), true)
+
+	// 書き出しを終えたバージョンは次のPrepareBundleで削除する
+	if err := u.WriteBundleZip(streaming, io.Discard); err == nil {
+		t.Fatal("expected an error for a bundle without manifest.json")
+	}
+	u.removeStaleBundles(ctx, urn, "current")
+	assertExists(t, filepath.Join(u.workDir, urn, "streaming"), false)
+	assertExists(t, filepath.Join(u.workDir, urn, "current"), true)
+}
+
+func assertExists(t *testing.T, path string, want bool) {
+	t.Helper()
+	_, err := os.Stat(path)
+	if got := err == nil; got != want {
+		t.Errorf("%s exists = %v, want %v", path, got, want)
+	}
+}
@@ -0,0 +1,187 @@
+package aps_derivative
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Viewerへ返し、キャッシュにも保存するレスポンスヘッダー
+var proxiedHeaders = []string{
+	"Content-Type",
+	"Content-Encoding",
+	"Content-Length",
+	"Content-Range",
+	"Accept-Ranges",
+	"ETag",
+	"Last-Modified",
+	"Cache-Control",
+}
+
+// ProxyDerivative はViewerの派生ファイルリクエストをサーバーのトークンでAPSへ転送します
+// 派生ファイルはディスクにキャッシュし、マニフェストが変わったURNのキャッシュは無効化します
+// Rangeリクエストはキャッシュにヒットすればそこから返し、ミスの場合はキャッシュせずそのまま転送します
+func (u *APSDerivativeUseCase) ProxyDerivative(ctx context.Context, req *domain.DerivativeProxyRequest) (*domain.DerivativeProxyResponse, error) {
+	urn, isManifest, err := domain.ParseDerivativePath(req.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := "identity"
+	if strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") {
+		encoding = "gzip"
+	}
+	// マニフェストはバージョン判定に使うため、エンコーディングによらず同じバイト列を受け取る
+	if isManifest {
+		encoding = "identity"
+	}
+
+	cacheable := u.cacheRepo != nil && req.Method == http.MethodGet && !isManifest
+	key := cacheKey(req.Path, req.RawQuery, encoding)
+
+	if cacheable {
+		entry, content, err := u.cacheRepo.Get(key)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to read derivative cache", "key", key, "error", err)
+		}
+		if content != nil {
+			header := http.Header{}
+			for name, value := range entry.Header {
+				header.Set(name, value)
+			}
+			header.Del("Content-Length")
+			header.Del("Content-Range")
+			return &domain.DerivativeProxyResponse{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Content:    content,
+				ModTime:    entry.StoredAt,
+			}, nil
+		}
+	}
+
+	// 透過的なgzip展開を避けるため、Accept-Encodingは明示的に指定する
+	upstreamHeader := http.Header{}
+	upstreamHeader.Set("Accept-Encoding", encoding)
+	for _, name := range []string{"Range", "If-Range", "If-None-Match", "If-Modified-Since"} {
+		if v := req.Header.Get(name); v != "" {
+			upstreamHeader.Set(name, v)
+		}
+	}
+
+	resource, err := u.derivativeRepo.OpenDerivativeResource(ctx, req.Method, req.Path, req.RawQuery, upstreamHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	for _, name := range proxiedHeaders {
+		if v := resource.Header.Get(name); v != "" {
+			header.Set(name, v)
+		}
+	}
+
+	body := resource.Body
+	switch {
+	case u.cacheRepo != nil && isManifest && resource.StatusCode == http.StatusOK:
+		body = &manifestBody{ctx: ctx, body: body, hash: sha256.New(), urn: urn, cacheRepo: u.cacheRepo}
+	case cacheable && resource.StatusCode == http.StatusOK && req.Header.Get("Range") == "":
+		entry := &domain.DerivativeCacheEntry{
+			Key:             key,
+			URN:             urn,
+			ManifestVersion: u.cacheRepo.ManifestVersion(urn),
+			Header:          map[string]string{},
+		}
+		for _, name := range proxiedHeaders {
+			if v := header.Get(name); v != "" {
+				entry.Header[name] = v
+			}
+		}
+		writer, err := u.cacheRepo.NewWriter(entry)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to open derivative cache writer", "key", key, "error", err)
+			break
+		}
+		body = &cachingBody{ctx: ctx, body: body, writer: writer}
+	}
+
+	return &domain.DerivativeProxyResponse{
+		StatusCode: resource.StatusCode,
+		Header:     header,
+		Body:       body,
+	}, nil
+}
+
+// cachingBody はレスポンスを読み進めながらキャッシュに書き込み、最後まで読めた場合のみ確定します
+type cachingBody struct {
+	// ログに使うリクエストのコンテキスト
+	ctx    context.Context
+	body   io.ReadCloser
+	writer domain.DerivativeCacheWriter
+	done   bool
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if n > 0 && !b.done {
+		if _, werr := b.writer.Write(p[:n]); werr != nil {
+			slog.WarnContext(b.ctx, "failed to write derivative cache", "error", werr)
+			b.writer.Abort()
+			b.done = true
+		}
+	}
+	if err == io.EOF && !b.done {
+		if cerr := b.writer.Commit(); cerr != nil {
+			slog.WarnContext(b.ctx, "failed to commit derivative cache", "error", cerr)
+		}
+		b.done = true
+	}
+	return n, err
+}
+
+func (b *cachingBody) Close() error {
+	if !b.done {
+		b.writer.Abort()
+		b.done = true
+	}
+	return b.body.Close()
+}
+
+// manifestBody はマニフェストを読み進めながらハッシュを計算し、最後まで読めたらバージョンとして記録します
+type manifestBody struct {
+	// ログに使うリクエストのコンテキスト
+	ctx       context.Context
+	body      io.ReadCloser
+	hash      hash.Hash
+	urn       string
+	cacheRepo domain.DerivativeCacheRepository
+}
+
+func (b *manifestBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.hash.Write(p[:n])
+	if err == io.EOF {
+		version := hex.EncodeToString(b.hash.Sum(nil))
+		if serr := b.cacheRepo.SetManifestVersion(b.urn, version); serr != nil {
+			slog.WarnContext(b.ctx, "failed to update manifest version", "urn", b.urn, "error", serr)
+		}
+	}
+	return n, err
+}
+
+func (b *manifestBody) Close() error {
+	return b.body.Close()
+}
+
+// cacheKey はリクエストのパス、クエリ、エンコーディングからキャッシュキーを作成します
+func cacheKey(path string, rawQuery string, encoding string) string {
+	sum := sha256.Sum256([]byte(path + "?" + rawQuery + "\n" + encoding))
+	return hex.EncodeToString(sum[:])
+}
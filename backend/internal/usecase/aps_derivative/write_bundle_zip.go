@@ -0,0 +1,60 @@
+package aps_derivative
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// WriteBundleZip はダウンロード済みのバンドルをzipとして書き出します
+// bundle.jsonにはViewerのローカルモード（env: 'Local'）で読み込む.svfのパスを記載します
+// 書き出しに失敗した場合も、PrepareBundleで書き出し中として数えたバンドルを解放します
+func (u *APSDerivativeUseCase) WriteBundleZip(bundle *domain.DerivativeBundle, w io.Writer) error {
+	defer u.releaseBundle(bundle.Dir)
+
+	zw := zip.NewWriter(w)
+
+	index, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	fw, err := zw.Create("bundle.json")
+	if err != nil {
+		return err
+	}
+	if _, err := fw.Write(index); err != nil {
+		return err
+	}
+
+	if err := addFileToZip(zw, filepath.Join(bundle.Dir, "manifest.json"), "manifest.json"); err != nil {
+		return err
+	}
+	for _, file := range bundle.Files {
+		if err := addFileToZip(zw, filepath.Join(bundle.Dir, filepath.FromSlash(file.Path)), file.Path); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// addFileToZip はファイルをzipに追加します
+func addFileToZip(zw *zip.Writer, src string, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, f)
+	return err
+}
@@ -0,0 +1,29 @@
+package aps_export
+
+import (
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// APSExportUseCase は選択した要素のエクスポートのユースケース実装
+type APSExportUseCase struct {
+	derivativeRepo domain.APSDerivativeRepository
+	objectRepo     domain.APSObjectRepository
+	exportRepo     domain.ExportRepository
+
+	// 同じエクスポートの出力ファイルを並行して作成しないようにするためのロック
+	locks sync.Map
+}
+
+// NewAPSExportUseCase は新しいAPSExportUseCaseを作成します
+func NewAPSExportUseCase(derivativeRepo domain.APSDerivativeRepository, objectRepo domain.APSObjectRepository, exportRepo domain.ExportRepository) *APSExportUseCase {
+	return &APSExportUseCase{
+		derivativeRepo: derivativeRepo,
+		objectRepo:     objectRepo,
+		exportRepo:     exportRepo,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.APSExportUseCase = (*APSExportUseCase)(nil)
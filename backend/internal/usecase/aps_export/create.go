@@ -0,0 +1,87 @@
+package aps_export
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
+// CreateExport は選択した要素のエクスポートを作成します
+// Model DerivativeでobjectIdsを指定できるのはOBJ出力のみのため、STLの場合もOBJのジョブを送信し、完了後にSTLへ変換します
+func (u *APSExportUseCase) CreateExport(ctx context.Context, urn string, req *domain.ExportRequest) (*domain.ExportRecord, error) {
+	ctx, span := tracing.Start(ctx, "APSExportUseCase.CreateExport", tracing.URN.String(urn), tracing.ModelGUID.String(req.ModelGUID))
+	record, err := u.createExport(ctx, urn, req)
+	tracing.End(span, err)
+	return record, err
+}
+
+// createExport はCreateExportの本体
+func (u *APSExportUseCase) createExport(ctx context.Context, urn string, req *domain.ExportRequest) (*domain.ExportRecord, error) {
+	if req.ModelGUID == "" {
+		return nil, fmt.Errorf("%w: modelGuid is required", domain.ErrInvalidExportRequest)
+	}
+	if len(req.ObjectIDs) == 0 {
+		return nil, fmt.Errorf("%w: objectIds is required", domain.ErrInvalidExportRequest)
+	}
+
+	encoding := ""
+	switch req.Format {
+	case "obj":
+	case "stl":
+		encoding = req.Encoding
+		if encoding == "" {
+			encoding = "binary"
+		}
+		if encoding != "ascii" && encoding != "binary" {
+			return nil, fmt.Errorf("%w: unsupported stl encoding: %s", domain.ErrInvalidExportRequest, req.Encoding)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported format: %s", domain.ErrInvalidExportRequest, req.Format)
+	}
+
+	ids := slices.Clone(req.ObjectIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	now := time.Now()
+	record := &domain.ExportRecord{
+		ID:        uuid.New().String(),
+		URN:       urn,
+		ModelGUID: req.ModelGUID,
+		ObjectIDs: ids,
+		Format:    req.Format,
+		Encoding:  encoding,
+		Status:    "pending",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// 同じ要素のOBJが既にあればジョブを送信せずに使う
+	manifest, err := u.objectRepo.TrackTranslationJobStatus(ctx, urn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	if objFile, _ := manifest.FindOBJResources(record.ModelGUID, record.ObjectIDs); objFile == nil {
+		formats := []domain.DerivativeOutputFormat{{
+			Type: "obj",
+			Advanced: map[string]any{
+				"modelGuid": record.ModelGUID,
+				"objectIds": record.ObjectIDs,
+			},
+		}}
+		if _, err := u.derivativeRepo.SubmitDerivativeJob(ctx, urn, formats); err != nil {
+			return nil, fmt.Errorf("failed to submit obj job: %w", err)
+		}
+	}
+
+	if err := u.exportRepo.Save(record); err != nil {
+		return nil, fmt.Errorf("failed to save export: %w", err)
+	}
+
+	return u.refresh(ctx, record, manifest)
+}
@@ -0,0 +1,76 @@
+package aps_export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ListExports はURNのエクスポート履歴を返します。完了していないものは状況を更新してから返します
+func (u *APSExportUseCase) ListExports(ctx context.Context, urn string) ([]domain.ExportRecord, error) {
+	records, err := u.exportRepo.ListByURN(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest *domain.TranslationStatus
+	for i := range records {
+		if isTerminal(records[i].Status) {
+			continue
+		}
+		if manifest == nil {
+			manifest, err = u.objectRepo.TrackTranslationJobStatus(ctx, urn)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get manifest: %w", err)
+			}
+		}
+		updated, err := u.refresh(ctx, &records[i], manifest)
+		if err != nil {
+			return nil, err
+		}
+		records[i] = *updated
+	}
+
+	return records, nil
+}
+
+// GetExport はエクスポートを1件返します。完了していない場合は状況を更新してから返します
+func (u *APSExportUseCase) GetExport(ctx context.Context, urn string, id string) (*domain.ExportRecord, error) {
+	record, err := u.exportRepo.Get(urn, id)
+	if err != nil {
+		return nil, err
+	}
+	if isTerminal(record.Status) {
+		return record, nil
+	}
+
+	manifest, err := u.objectRepo.TrackTranslationJobStatus(ctx, urn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	return u.refresh(ctx, record, manifest)
+}
+
+// OpenExportFile は完了したエクスポートのファイルを開きます
+func (u *APSExportUseCase) OpenExportFile(ctx context.Context, urn string, id string) (*domain.ExportRecord, io.ReadSeekCloser, error) {
+	record, err := u.GetExport(ctx, urn, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if record.Status != "success" {
+		return nil, nil, domain.ErrExportNotReady
+	}
+
+	f, err := u.exportRepo.OpenFile(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, f, nil
+}
+
+// isTerminal は状況がこれ以上変わらないか判定します
+func isTerminal(status string) bool {
+	return status == "success" || status == "failed"
+}
@@ -0,0 +1,148 @@
+package aps_export
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/mesh"
+)
+
+// refresh はマニフェストからOBJの作成状況を確認し、完了していれば出力ファイルを作成して履歴を更新します
+// 失敗として記録するのはOBJの作成自体が失敗した場合のみで、ダウンロードや変換の失敗は次回の取得時に作り直します
+func (u *APSExportUseCase) refresh(ctx context.Context, record *domain.ExportRecord, manifest *domain.TranslationStatus) (*domain.ExportRecord, error) {
+	// 同じエクスポートを並行して取得した場合に、出力ファイルを重複して作成しないようにする
+	lock, _ := u.locks.LoadOrStore(record.ID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// ロックを待つ間に他のリクエストが更新している場合がある
+	current, err := u.exportRepo.Get(record.URN, record.ID)
+	if err != nil {
+		return nil, err
+	}
+	if isTerminal(current.Status) {
+		return current, nil
+	}
+	record = current
+
+	objFile, mtlFile := manifest.FindOBJResources(record.ModelGUID, record.ObjectIDs)
+	if objFile == nil {
+		// ジョブがまだマニフェストに反映されていない
+		return record, nil
+	}
+
+	switch objFile.Status {
+	case "success":
+		// 失敗した場合に途中のファイル名やサイズを返さないよう、コピーに書き込む
+		written := *record
+		if err := u.writeExportFile(ctx, &written, objFile, mtlFile); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			// 一時的な失敗の可能性があるため履歴は更新せず、次回の取得時に作り直す
+			slog.WarnContext(ctx, "failed to write export file", "urn", record.URN, "id", record.ID, "error", err)
+			return record, nil
+		}
+		record = &written
+		record.Status = "success"
+		record.Progress = "complete"
+		now := time.Now()
+		record.CompletedAt = &now
+	case "failed", "timeout":
+		record.Status = "failed"
+		record.Error = fmt.Sprintf("obj derivative status: %s", objFile.Status)
+		now := time.Now()
+		record.CompletedAt = &now
+	default:
+		if record.Status == objFile.Status && record.Progress == objFile.Progress {
+			return record, nil
+		}
+		record.Status = objFile.Status
+		record.Progress = objFile.Progress
+	}
+
+	record.UpdatedAt = time.Now()
+	if err := u.exportRepo.Save(record); err != nil {
+		return nil, fmt.Errorf("failed to save export: %w", err)
+	}
+	return record, nil
+}
+
+// writeExportFile はOBJをダウンロードして要求された形式で保存します
+// OBJはMTLと一緒に参照できるようzipにまとめ、STLはOBJを読み込んで変換します
+func (u *APSExportUseCase) writeExportFile(ctx context.Context, record *domain.ExportRecord, objFile *domain.Children, mtlFile *domain.Children) error {
+	baseName := fmt.Sprintf("%s-%s", record.ModelGUID, record.ID[:8])
+
+	switch record.Format {
+	case "obj":
+		record.FileName = baseName + ".zip"
+		size, err := u.exportRepo.SaveFile(record, func(w io.Writer) error {
+			zw := zip.NewWriter(w)
+			files := []*domain.Children{objFile}
+			if mtlFile != nil {
+				files = append(files, mtlFile)
+			}
+			for _, file := range files {
+				if err := u.copyDerivative(ctx, zw, record.URN, file.URN); err != nil {
+					return err
+				}
+			}
+			return zw.Close()
+		})
+		record.Size = size
+		return err
+
+	case "stl":
+		body, err := u.openDerivative(ctx, record.URN, objFile.URN)
+		if err != nil {
+			return fmt.Errorf("failed to download obj: %w", err)
+		}
+		scene, err := mesh.ParseOBJ(body, nil)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse obj: %w", err)
+		}
+
+		record.FileName = baseName + ".stl"
+		size, err := u.exportRepo.SaveFile(record, func(w io.Writer) error {
+			return mesh.EncodeSTL(w, scene, baseName, record.Encoding == "binary")
+		})
+		record.Size = size
+		return err
+	}
+
+	return fmt.Errorf("unsupported format: %s", record.Format)
+}
+
+// copyDerivative は派生ファイルをダウンロードしてzipに追加します
+func (u *APSExportUseCase) copyDerivative(ctx context.Context, zw *zip.Writer, urn string, derivativeURN string) error {
+	body, err := u.openDerivative(ctx, urn, derivativeURN)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", path.Base(derivativeURN), err)
+	}
+	defer body.Close()
+
+	fw, err := zw.Create(path.Base(derivativeURN))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, body)
+	return err
+}
+
+// openDerivative は派生ファイルを署名付きCookieでダウンロードします
+func (u *APSExportUseCase) openDerivative(ctx context.Context, urn string, derivativeURN string) (io.ReadCloser, error) {
+	download, err := u.derivativeRepo.GetDerivativeDownload(ctx, urn, derivativeURN)
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := u.derivativeRepo.DownloadDerivative(ctx, download, 0)
+	return body, err
+}
@@ -6,13 +6,19 @@ import (
 
 // APSObjectUseCase はAPSオブジェクトのユースケース実装
 type APSObjectUseCase struct {
-	objectRepo domain.APSObjectRepository
+	objectRepo         domain.APSObjectRepository
+	translationMetrics domain.TranslationMetrics
+	audit              domain.AuditLogger
+	catalog            domain.ModelCatalog
 }
 
 // NewAPSObjectUseCase は新しいAPSObjectUseCaseを作成します
-func NewAPSObjectUseCase(objectRepo domain.APSObjectRepository) *APSObjectUseCase {
+func NewAPSObjectUseCase(objectRepo domain.APSObjectRepository, translationMetrics domain.TranslationMetrics, audit domain.AuditLogger, catalog domain.ModelCatalog) *APSObjectUseCase {
 	return &APSObjectUseCase{
-		objectRepo: objectRepo,
+		objectRepo:         objectRepo,
+		translationMetrics: translationMetrics,
+		audit:              audit,
+		catalog:            catalog,
 	}
 }
 
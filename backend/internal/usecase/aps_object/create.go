@@ -1,9 +1,25 @@
 package aps_object
 
 import (
+    "context"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
 )
 
-func (u *APSObjectUseCase) CreateObject(bucketKey, objectKey, uploadKey string) (*domain.APSObject, error) {
-    return u.objectRepo.CreateObject(bucketKey, objectKey, uploadKey)
+func (u *APSObjectUseCase) CreateObject(ctx context.Context, bucketKey, objectKey, uploadKey string) (*domain.APSObject, error) {
+    ctx, span := tracing.Start(ctx, "APSObjectUseCase.CreateObject", tracing.BucketKey.String(bucketKey), tracing.ObjectKey.String(objectKey))
+    object, err := u.objectRepo.CreateObject(ctx, bucketKey, objectKey, uploadKey)
+    if err == nil {
+        span.SetAttributes(tracing.ObjectID.String(object.ObjectId))
+    }
+    u.audit.Record(ctx, domain.ActionObjectUpload, domain.ObjectTarget(bucketKey, objectKey), err)
+    tracing.End(span, err)
+    if err != nil {
+        return nil, err
+    }
+    // アップロードしたファイルをカタログに登録する
+    if urn, err := u.objectRepo.GenerateBase64EncodedURN(object.ObjectId); err == nil {
+        u.catalog.ObjectUploaded(ctx, object, urn)
+    }
+    return object, nil
 }
\ No newline at end of file
@@ -1,11 +1,18 @@
 package aps_object
 
 import (
+	"context"
+
 	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
 )
 
 // GetS3SignedURLs はS3署名付きURLを取得します
-func (u *APSObjectUseCase) GetS3SignedURLs(bucketKey string, objectKey string, parts int) (*domain.APSObject, error) {
+func (u *APSObjectUseCase) GetS3SignedURLs(ctx context.Context, bucketKey string, objectKey string, parts int) (*domain.APSObject, error) {
+	ctx, span := tracing.Start(ctx, "APSObjectUseCase.GetS3SignedURLs",
+		tracing.BucketKey.String(bucketKey), tracing.ObjectKey.String(objectKey), tracing.Parts.Int(parts))
 	// リポジトリ層に処理を委譲
-	return u.objectRepo.GetS3SignedURLs(bucketKey, objectKey, parts)
+	object, err := u.objectRepo.GetS3SignedURLs(ctx, bucketKey, objectKey, parts)
+	tracing.End(span, err)
+	return object, err
 }
@@ -1,7 +1,17 @@
 package aps_object
 
+import (
+	"context"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
+)
+
 // PutS3SignedURLs はS3署名付きURLを使用してオブジェクトをアップロードします
-func (u *APSObjectUseCase) PutS3SignedURLs(signedURL string, fileContent []byte) error {
+func (u *APSObjectUseCase) PutS3SignedURLs(ctx context.Context, signedURL string, fileContent []byte) error {
+	ctx, span := tracing.Start(ctx, "APSObjectUseCase.PutS3SignedURLs")
+	span.SetAttributes(tracing.PartBytes.Int(len(fileContent)))
 	// リポジトリ層に処理を委譲
-	return u.objectRepo.PutS3SignedURLs(signedURL, fileContent)
+	err := u.objectRepo.PutS3SignedURLs(ctx, signedURL, fileContent)
+	tracing.End(span, err)
+	return err
 }
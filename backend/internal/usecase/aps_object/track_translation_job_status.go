@@ -1,9 +1,23 @@
 package aps_object
 
 import (
+    "context"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
 )
 
-func (u *APSObjectUseCase) TrackTranslationJobStatus(urn string) (*domain.TranslationStatus, error) {
-    return u.objectRepo.TrackTranslationJobStatus(urn)
+// TrackTranslationJobStatus は翻訳ジョブの状態を取得し、終了していれば所要時間を記録します
+func (u *APSObjectUseCase) TrackTranslationJobStatus(ctx context.Context, urn string) (*domain.TranslationStatus, error) {
+    ctx, span := tracing.Start(ctx, "APSObjectUseCase.TrackTranslationJobStatus", tracing.URN.String(urn))
+    status, err := u.objectRepo.TrackTranslationJobStatus(ctx, urn)
+    if err == nil {
+        span.SetAttributes(tracing.TranslationStatus.String(status.Status))
+    }
+    tracing.End(span, err)
+    if err != nil {
+        return nil, err
+    }
+    u.translationMetrics.JobStatus(urn, status.Status)
+    u.catalog.TranslationChecked(ctx, urn, status)
+    return status, nil
 }
\ No newline at end of file
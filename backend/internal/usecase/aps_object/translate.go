@@ -1,11 +1,23 @@
 package aps_object
 
 import (
+    "context"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+    "github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/tracing"
 )
 
 // TranslateObject はオブジェクトの翻訳ジョブを作成します
-func (u *APSObjectUseCase) TranslateObject(base64URN string, objectKey string) (*domain.TranslateJobResponse, error) {
+func (u *APSObjectUseCase) TranslateObject(ctx context.Context, base64URN string, objectKey string) (*domain.TranslateJobResponse, error) {
+    ctx, span := tracing.Start(ctx, "APSObjectUseCase.TranslateObject", tracing.URN.String(base64URN), tracing.ObjectKey.String(objectKey))
     // リポジトリ層に処理を委譲
-    return u.objectRepo.TranslateObject(base64URN, objectKey)
+    response, err := u.objectRepo.TranslateObject(ctx, base64URN, objectKey)
+    bucketKey, _ := domain.BucketKeyOfURN(base64URN)
+    u.audit.Record(ctx, domain.ActionObjectTranslate, domain.ObjectTarget(bucketKey, objectKey), err)
+    tracing.End(span, err)
+    if err != nil {
+        return nil, err
+    }
+    u.translationMetrics.JobSubmitted(base64URN, objectKey)
+    u.catalog.TranslationSubmitted(ctx, base64URN, objectKey, response)
+    return response, nil
 }
\ No newline at end of file
@@ -6,10 +6,16 @@ import (
 
 type APSTokenUseCase struct {
     tokenRepo domain.APSTokenRepository
+    viewerTokenRepo domain.APSTokenRepository
+    audit domain.AuditLogger
 }
 
-func NewAPSTokenUseCase(tokenRepo domain.APSTokenRepository) *APSTokenUseCase {
+// NewAPSTokenUseCase は新しいAPSTokenUseCaseを作成します
+// tokenRepoはサーバー内でAPSを操作するためのトークン、viewerTokenRepoは呼び出し元へ発行する閲覧のみのトークンを取得します
+func NewAPSTokenUseCase(tokenRepo domain.APSTokenRepository, viewerTokenRepo domain.APSTokenRepository, audit domain.AuditLogger) *APSTokenUseCase {
     return &APSTokenUseCase{
         tokenRepo: tokenRepo,
+        viewerTokenRepo: viewerTokenRepo,
+        audit: audit,
     }
 }
\ No newline at end of file
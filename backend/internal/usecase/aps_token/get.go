@@ -1,9 +1,19 @@
 package aps_token
 
 import (
+    "context"
     "github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
 )
 
-func (u *APSTokenUseCase) GetToken() (*domain.APSToken, error) {
-    return u.tokenRepo.GetToken()
+func (u *APSTokenUseCase) GetToken(ctx context.Context) (*domain.APSToken, error) {
+    return u.tokenRepo.GetToken(ctx)
+}
+
+// IssueToken は呼び出し元へViewer用のトークンを発行し、監査ログに記録します
+// 呼び出し元がAPSを直接操作できないよう、派生ファイルの閲覧のみを許可するトークンを返します
+// ユースケース内部でAPSを呼び出すためのトークンの取得にはGetTokenを使います
+func (u *APSTokenUseCase) IssueToken(ctx context.Context) (*domain.APSToken, error) {
+    token, err := u.viewerTokenRepo.GetToken(ctx)
+    u.audit.Record(ctx, domain.ActionTokenCreate, domain.AuditTargetAPSToken, err)
+    return token, err
 }
\ No newline at end of file
@@ -0,0 +1,76 @@
+package aps_token
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_endpoint"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_timeout"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/aps/aps_token"
+)
+
+type nopAudit struct{}
+
+func (nopAudit) Record(ctx context.Context, action domain.Action, target string, err error) {}
+
+// scopeEchoServer はリクエストされたスコープをアクセストークンとして返す認証APIです
+func scopeEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(domain.APSToken{
+			AccessToken: r.PostFormValue("scope"),
+			TokenType:   "Bearer",
+			ExpiresIn:   3600,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestIssueTokenHasViewerScopeOnly(t *testing.T) {
+	srv := scopeEchoServer(t)
+	endpoints := aps_endpoint.Endpoints{Auth: srv.URL}
+	credentials := aps_token.Credentials{ClientID: "id", ClientSecret: "secret"}
+	internal := aps_token.NewAPSTokenRepository(srv.Client(), credentials, endpoints, aps_timeout.Timeouts{}, aps_token.InternalScope)
+	viewer := aps_token.NewAPSTokenRepository(srv.Client(), credentials, endpoints, aps_timeout.Timeouts{}, aps_token.ViewerScope)
+	u := NewAPSTokenUseCase(internal, viewer, nopAudit{})
+	ctx := context.Background()
+
+	// サーバー内で使うトークンを先にキャッシュしても、呼び出し元には渡さない
+	if _, err := u.GetToken(ctx); err != nil {
+		t.Fatal(err)
+	}
+	issued, err := u.IssueToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scopes := strings.Fields(issued.AccessToken)
+	if len(scopes) == 0 {
+		t.Fatal("issued token has no scope")
+	}
+	for _, scope := range scopes {
+		if scope != "viewables:read" {
+			t.Errorf("issued token has scope %q, want viewables:read only", scope)
+		}
+	}
+	for _, forbidden := range []string{"data:write", "data:create", "bucket:create", "bucket:delete"} {
+		if strings.Contains(issued.AccessToken, forbidden) {
+			t.Errorf("issued token has %s", forbidden)
+		}
+	}
+
+	token, err := u.GetToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != aps_token.InternalScope {
+		t.Errorf("internal token scope = %q, want %q", token.AccessToken, aps_token.InternalScope)
+	}
+}
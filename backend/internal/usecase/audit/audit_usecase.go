@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 検索で返す件数の既定値と上限
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+// AuditUseCase は監査ログの記録・検索・検証のユースケース実装
+type AuditUseCase struct {
+	auditRepo domain.AuditRepository
+	now       func() time.Time
+}
+
+// NewAuditUseCase は新しいAuditUseCaseを作成します
+func NewAuditUseCase(auditRepo domain.AuditRepository) *AuditUseCase {
+	return &AuditUseCase{
+		auditRepo: auditRepo,
+		now:       time.Now,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.AuditUseCase = (*AuditUseCase)(nil)
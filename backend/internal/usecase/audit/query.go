@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Query は条件に合う監査ログを新しい順に返します。件数の上限は既定で100件、最大1000件です
+func (u *AuditUseCase) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultQueryLimit
+	}
+	filter.Limit = min(filter.Limit, maxQueryLimit)
+	return u.auditRepo.Query(filter)
+}
+
+// Verify は監査ログのハッシュチェーンを検証します
+func (u *AuditUseCase) Verify(ctx context.Context) (*domain.AuditVerification, error) {
+	return u.auditRepo.Verify()
+}
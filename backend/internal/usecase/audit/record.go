@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/logging"
+)
+
+// Record は操作の結果を監査ログに記録します
+// 記録に失敗しても操作自体は失敗させず、エラーログに残します
+func (u *AuditUseCase) Record(ctx context.Context, action domain.Action, target string, err error) {
+	entry := &domain.AuditEntry{
+		Time:      u.now().UTC(),
+		Action:    action,
+		Target:    target,
+		Outcome:   domain.AuditOutcomeSuccess,
+		RequestID: logging.RequestID(ctx),
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		entry.Principal = principal.ID
+		entry.PrincipalMethod = principal.Method
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrAccessDenied):
+		entry.Outcome = domain.AuditOutcomeDenied
+		entry.Detail = err.Error()
+	default:
+		entry.Outcome = domain.AuditOutcomeFailure
+		// エラーには署名付きURLなどが含まれることがあるため伏せ字にする
+		entry.Detail = logging.Redact(err.Error())
+	}
+
+	if aerr := u.auditRepo.Append(entry); aerr != nil {
+		slog.ErrorContext(ctx, "failed to write audit log", "action", string(action), "target", target, "error", aerr)
+	}
+}
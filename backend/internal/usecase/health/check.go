@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"sync"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Live はプロセスが動作していることを返します。外部への接続は確認しません
+func (u *HealthUseCase) Live() *domain.HealthReport {
+	return &domain.HealthReport{Status: domain.HealthStatusOK}
+}
+
+// Ready はすべての確認項目を並行して実行し、結果をまとめて返します
+// CacheTTL内の結果がある項目は確認せずにその結果を返します
+func (u *HealthUseCase) Ready(ctx context.Context) *domain.HealthReport {
+	report := &domain.HealthReport{
+		Status: domain.HealthStatusOK,
+		Checks: make([]domain.HealthCheckResult, len(u.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range u.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = u.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != domain.HealthStatusOK {
+			report.Status = domain.HealthStatusError
+		}
+	}
+	return report
+}
+
+// run は確認項目を実行します。キャッシュした結果が有効な場合はそれを返します
+func (u *HealthUseCase) run(ctx context.Context, check *cachedCheck) domain.HealthCheckResult {
+	check.mu.Lock()
+	defer check.mu.Unlock()
+
+	if check.result != nil && check.CacheTTL > 0 && u.now().Sub(check.result.CheckedAt) < check.CacheTTL {
+		cached := *check.result
+		cached.Cached = true
+		return cached
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := u.now()
+	err := check.Check(checkCtx)
+	result := domain.HealthCheckResult{
+		Name:      check.Name,
+		Status:    domain.HealthStatusOK,
+		LatencyMs: float64(u.now().Sub(start).Microseconds()) / 1000,
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = domain.HealthStatusError
+		result.Error = err.Error()
+	}
+	// プローブ自体が切断された場合の結果は、APSやストアの状態を表さないためキャッシュしない
+	if ctx.Err() == nil || err == nil {
+		check.result = &result
+	}
+	return result
+}
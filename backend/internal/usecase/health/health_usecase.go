@@ -0,0 +1,38 @@
+package health
+
+import (
+	"sync"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 確認項目1つあたりのタイムアウト
+const checkTimeout = 10 * time.Second
+
+// HealthUseCase は生存確認・準備状態の確認のユースケース実装
+type HealthUseCase struct {
+	checks []*cachedCheck
+	now    func() time.Time
+}
+
+// cachedCheck は確認項目と直近の結果
+// 同時に届いたプローブで同じ確認を重複して実行しないよう、確認中はロックを保持します
+type cachedCheck struct {
+	domain.HealthCheck
+
+	mu     sync.Mutex
+	result *domain.HealthCheckResult
+}
+
+// NewHealthUseCase は新しいHealthUseCaseを作成します
+func NewHealthUseCase(checks ...domain.HealthCheck) *HealthUseCase {
+	u := &HealthUseCase{now: time.Now}
+	for _, check := range checks {
+		u.checks = append(u.checks, &cachedCheck{HealthCheck: check})
+	}
+	return u
+}
+
+// インターフェースの実装を確認
+var _ domain.HealthUseCase = (*HealthUseCase)(nil)
@@ -0,0 +1,49 @@
+package mesh_processing
+
+import (
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 上限の既定値
+const (
+	defaultMaxRequestBytes        = 256 << 20
+	defaultMaxVertices            = 5_000_000
+	defaultMaxTriangles           = 5_000_000
+	defaultDefaultTargetTriangles = 500_000
+	defaultWeldTolerance          = 1e-4
+)
+
+// MeshProcessingUseCase はViewerから抽出したメッシュの後処理のユースケース実装
+type MeshProcessingUseCase struct {
+	limits domain.MeshLimits
+}
+
+// NewMeshProcessingUseCase は新しいMeshProcessingUseCaseを作成します
+// 0以下の項目には既定値を使います
+func NewMeshProcessingUseCase(limits domain.MeshLimits) *MeshProcessingUseCase {
+	if limits.MaxRequestBytes <= 0 {
+		limits.MaxRequestBytes = defaultMaxRequestBytes
+	}
+	if limits.MaxVertices <= 0 {
+		limits.MaxVertices = defaultMaxVertices
+	}
+	if limits.MaxTriangles <= 0 {
+		limits.MaxTriangles = defaultMaxTriangles
+	}
+	if limits.DefaultTargetTriangles <= 0 {
+		limits.DefaultTargetTriangles = defaultDefaultTargetTriangles
+	}
+	limits.DefaultTargetTriangles = min(limits.DefaultTargetTriangles, limits.MaxTriangles)
+	if limits.DefaultWeldTolerance <= 0 {
+		limits.DefaultWeldTolerance = defaultWeldTolerance
+	}
+	return &MeshProcessingUseCase{limits: limits}
+}
+
+// Limits は受け付けるデータ量の上限と既定値を返します
+func (u *MeshProcessingUseCase) Limits() domain.MeshLimits {
+	return u.limits
+}
+
+// インターフェースの実装を確認
+var _ domain.MeshProcessingUseCase = (*MeshProcessingUseCase)(nil)
@@ -0,0 +1,274 @@
+package mesh_processing
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/mesh"
+)
+
+// ProcessToGLB は抽出したメッシュをワールド座標に変換し、溶接・間引き・マテリアルごとの結合を行ってGLBに書き出します
+func (u *MeshProcessingUseCase) ProcessToGLB(ctx context.Context, req *domain.MeshProcessRequest, w io.Writer) (*domain.MeshProcessResult, error) {
+	scene, err := u.buildScene(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.MeshProcessResult{
+		InputVertices:  scene.VertexCount(),
+		InputTriangles: scene.TriangleCount(),
+	}
+
+	tolerance := u.limits.DefaultWeldTolerance
+	if req.Options.WeldTolerance != nil {
+		tolerance = *req.Options.WeldTolerance
+	}
+	if tolerance < 0 || math.IsNaN(tolerance) || math.IsInf(tolerance, 0) {
+		return nil, fmt.Errorf("%w: weldTolerance must be a non-negative number", domain.ErrInvalidMeshRequest)
+	}
+
+	target := u.limits.DefaultTargetTriangles
+	if req.Options.TargetTriangles > 0 {
+		target = min(req.Options.TargetTriangles, u.limits.MaxTriangles)
+	}
+
+	for _, node := range scene.Nodes {
+		for _, p := range node.Primitives {
+			p.Weld(tolerance)
+		}
+	}
+	// 大きなメッシュでは処理に時間がかかるため、段階ごとにリクエストの中断を確認する
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result.TargetTriangles = target
+	result.UnmetDbIDs = decimate(scene, target)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	mergeByMaterial := true
+	if req.Options.MergeByMaterial != nil {
+		mergeByMaterial = *req.Options.MergeByMaterial
+	}
+	if mergeByMaterial {
+		scene.MergeByMaterial()
+	} else {
+		for _, node := range scene.Nodes {
+			for _, p := range node.Primitives {
+				p.ComputeNormals()
+			}
+		}
+	}
+
+	result.OutputVertices = scene.VertexCount()
+	result.OutputTriangles = scene.TriangleCount()
+	if bounds, ok := scene.Bounds(); ok {
+		result.Bounds = &domain.MeshBounds{
+			Min:    bounds.Min,
+			Max:    bounds.Max,
+			Center: bounds.Center,
+			Size:   bounds.Size,
+		}
+	}
+
+	extras := map[string]any{
+		"inputTriangles":  result.InputTriangles,
+		"outputTriangles": result.OutputTriangles,
+		"targetTriangles": result.TargetTriangles,
+	}
+	if len(result.UnmetDbIDs) > 0 {
+		extras["unmetDbIds"] = result.UnmetDbIDs
+	}
+	if result.Bounds != nil {
+		extras["bounds"] = result.Bounds
+	}
+	if err := mesh.EncodeGLB(w, scene, extras); err != nil {
+		return nil, fmt.Errorf("failed to encode glb: %w", err)
+	}
+	return result, nil
+}
+
+// buildScene はリクエストのメッシュを検証し、ワールド座標のシーンに変換します
+// 同じdbIdのフラグメントは1つのノードにまとめます
+func (u *MeshProcessingUseCase) buildScene(req *domain.MeshProcessRequest) (*mesh.Scene, error) {
+	if len(req.Meshes) == 0 {
+		return nil, fmt.Errorf("%w: meshes is empty", domain.ErrInvalidMeshRequest)
+	}
+
+	scene := &mesh.Scene{Materials: []mesh.Material{mesh.DefaultMaterial}}
+	materials := map[mesh.Material]int{mesh.DefaultMaterial: 0}
+	nodes := map[int]*mesh.Node{}
+	vertices, triangles := 0, 0
+
+	for i, input := range req.Meshes {
+		geometry := input.GeometryData
+		if len(geometry.Vertices) == 0 || len(geometry.Vertices)%3 != 0 {
+			return nil, fmt.Errorf("%w: meshes[%d].geometryData.vertices must be a non-empty multiple of 3", domain.ErrInvalidMeshRequest, i)
+		}
+		vertexCount := len(geometry.Vertices) / 3
+
+		indices := geometry.Indices
+		if len(indices) == 0 {
+			// インデックスがない場合は3頂点ごとに1つの三角形とみなします
+			indices = make([]uint32, vertexCount-vertexCount%3)
+			for j := range indices {
+				indices[j] = uint32(j)
+			}
+		}
+		if len(indices)%3 != 0 {
+			return nil, fmt.Errorf("%w: meshes[%d].geometryData.indices must be a multiple of 3", domain.ErrInvalidMeshRequest, i)
+		}
+		for _, idx := range indices {
+			if int(idx) >= vertexCount {
+				return nil, fmt.Errorf("%w: meshes[%d].geometryData.indices out of range", domain.ErrInvalidMeshRequest, i)
+			}
+		}
+
+		vertices += vertexCount
+		triangles += len(indices) / 3
+		if vertices > u.limits.MaxVertices {
+			return nil, fmt.Errorf("%w: more than %d vertices", domain.ErrMeshLimitExceeded, u.limits.MaxVertices)
+		}
+		if triangles > u.limits.MaxTriangles {
+			return nil, fmt.Errorf("%w: more than %d triangles", domain.ErrMeshLimitExceeded, u.limits.MaxTriangles)
+		}
+
+		p := &mesh.Primitive{
+			Positions: geometry.Vertices,
+			Indices:   indices,
+		}
+		if len(input.MatrixWorld) > 0 {
+			if len(input.MatrixWorld) != 16 {
+				return nil, fmt.Errorf("%w: meshes[%d].matrixWorld must have 16 elements", domain.ErrInvalidMeshRequest, i)
+			}
+			p.ApplyMatrix([16]float64(input.MatrixWorld))
+		}
+
+		material, err := toMaterial(input.Material)
+		if err != nil {
+			return nil, fmt.Errorf("%w: meshes[%d].material: %v", domain.ErrInvalidMeshRequest, i, err)
+		}
+		idx, ok := materials[material]
+		if !ok {
+			idx = len(scene.Materials)
+			materials[material] = idx
+			scene.Materials = append(scene.Materials, material)
+		}
+		p.Material = idx
+
+		node, ok := nodes[input.DbID]
+		if !ok {
+			dbID := input.DbID
+			node = &mesh.Node{Name: fmt.Sprint(dbID), DbID: &dbID}
+			nodes[dbID] = node
+			scene.Nodes = append(scene.Nodes, node)
+		}
+		node.Primitives = append(node.Primitives, p)
+	}
+
+	return scene, nil
+}
+
+// toMaterial はリクエストのマテリアルをシーンのマテリアルに変換します
+func toMaterial(input *domain.MeshMaterialInput) (mesh.Material, error) {
+	if input == nil || (len(input.Color) == 0 && input.Opacity == nil) {
+		return mesh.DefaultMaterial, nil
+	}
+
+	material := mesh.Material{Name: input.Name, Color: mesh.DefaultMaterial.Color}
+	switch len(input.Color) {
+	case 0:
+	case 3, 4:
+		copy(material.Color[:], input.Color)
+	default:
+		return mesh.Material{}, fmt.Errorf("color must have 3 or 4 elements")
+	}
+	if input.Opacity != nil {
+		material.Color[3] = *input.Opacity
+	}
+	for _, c := range material.Color {
+		if c < 0 || c > 1 {
+			return mesh.Material{}, fmt.Errorf("color components must be between 0 and 1")
+		}
+	}
+	if material.Name == "" {
+		material.Name = fmt.Sprintf("color_%02x%02x%02x", int(material.Color[0]*255), int(material.Color[1]*255), int(material.Color[2]*255))
+	}
+	return material, nil
+}
+
+// decimate はシーン全体の三角形数がtarget以下になるよう、各プリミティブに予算を配分して間引きます
+// 小さな要素が消えないよう各プリミティブに最低1つの三角形を割り当て、残りを三角形数に比例して配分します
+// 予算まで減らせないプリミティブや、間引くと三角形がなくなるプリミティブは間引く前の形状のまま残し、そのdbIdを返します
+func decimate(scene *mesh.Scene, target int) []int {
+	total := scene.TriangleCount()
+	if target <= 0 || total <= target {
+		return nil
+	}
+
+	type allocation struct {
+		node      *mesh.Node
+		primitive *mesh.Primitive
+		budget    int
+		remainder float64
+	}
+	var allocations []*allocation
+	extra := 0
+	for _, node := range scene.Nodes {
+		for _, p := range node.Primitives {
+			if p.TriangleCount() == 0 {
+				continue
+			}
+			allocations = append(allocations, &allocation{node: node, primitive: p, budget: 1})
+			extra += p.TriangleCount() - 1
+		}
+	}
+
+	// 最低限の1つを除いた予算を比例配分し、切り捨てで余った分は端数の大きいものから1つずつ配る
+	if spare := target - len(allocations); spare > 0 && extra > 0 {
+		remaining := spare
+		for _, a := range allocations {
+			exact := float64(a.primitive.TriangleCount()-1) * float64(spare) / float64(extra)
+			a.budget += int(exact)
+			a.remainder = exact - math.Floor(exact)
+			remaining -= int(exact)
+		}
+		byRemainder := slices.Clone(allocations)
+		slices.SortStableFunc(byRemainder, func(a, b *allocation) int {
+			return cmp.Compare(b.remainder, a.remainder)
+		})
+		for i := 0; i < remaining && i < len(byRemainder); i++ {
+			byRemainder[i].budget++
+		}
+	}
+
+	unmet := map[int]bool{}
+	for _, a := range allocations {
+		p := a.primitive
+		if p.TriangleCount() <= a.budget {
+			continue
+		}
+		positions, indices, normals := p.Positions, p.Indices, p.Normals
+		p.Decimate(a.budget)
+		if p.TriangleCount() > 0 && p.TriangleCount() <= a.budget {
+			continue
+		}
+		p.Positions, p.Indices, p.Normals = positions, indices, normals
+		if a.node.DbID != nil {
+			unmet[*a.node.DbID] = true
+		}
+	}
+
+	dbIDs := make([]int, 0, len(unmet))
+	for dbID := range unmet {
+		dbIDs = append(dbIDs, dbID)
+	}
+	slices.Sort(dbIDs)
+	return dbIDs
+}
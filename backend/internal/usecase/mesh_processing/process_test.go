@@ -0,0 +1,107 @@
+package mesh_processing
+
+import (
+	"context"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/mesh"
+)
+
+// gridMesh はsize四方をn×nに分割した平面のメッシュ（2n²個の三角形）を返します
+func gridMesh(dbID int, n int, size float32, offset float32) domain.MeshInput {
+	var vertices []float32
+	for y := 0; y <= n; y++ {
+		for x := 0; x <= n; x++ {
+			vertices = append(vertices, offset+size*float32(x)/float32(n), offset+size*float32(y)/float32(n), 0)
+		}
+	}
+	var indices []uint32
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			i := uint32(y*(n+1) + x)
+			indices = append(indices, i, i+1, i+uint32(n)+1, i+1, i+uint32(n)+2, i+uint32(n)+1)
+		}
+	}
+	return domain.MeshInput{DbID: dbID, GeometryData: domain.MeshGeometryInput{Vertices: vertices, Indices: indices}}
+}
+
+func nodeTriangles(node *mesh.Node) int {
+	n := 0
+	for _, p := range node.Primitives {
+		n += p.TriangleCount()
+	}
+	return n
+}
+
+func TestDecimateKeepsSmallElements(t *testing.T) {
+	u := NewMeshProcessingUseCase(domain.MeshLimits{})
+	req := &domain.MeshProcessRequest{
+		Meshes: []domain.MeshInput{
+			gridMesh(1, 40, 10, 0),
+			gridMesh(2, 2, 0.01, 20),
+			gridMesh(3, 2, 0.01, 30),
+			gridMesh(4, 2, 0.01, 40),
+		},
+	}
+	scene, err := u.buildScene(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large := scene.Nodes[0].Primitives[0]
+
+	target := 300
+	unmet := decimate(scene, target)
+
+	met := 0
+	for _, node := range scene.Nodes {
+		triangles := nodeTriangles(node)
+		if triangles == 0 {
+			t.Errorf("dbId %d has no triangles after decimation", *node.DbID)
+		}
+		if slices.Contains(unmet, *node.DbID) {
+			// 間引けなかった要素は元の形状のまま残す
+			if triangles != 8 {
+				t.Errorf("unmet dbId %d has %d triangles, want the original 8", *node.DbID, triangles)
+			}
+			continue
+		}
+		met += triangles
+	}
+	if met > target {
+		t.Errorf("decimated elements have %d triangles, want at most %d", met, target)
+	}
+	if large.TriangleCount() == 3200 {
+		t.Error("large element was not decimated")
+	}
+}
+
+func TestProcessToGLBReportsUnmetElements(t *testing.T) {
+	u := NewMeshProcessingUseCase(domain.MeshLimits{})
+	mergeByMaterial := false
+	req := &domain.MeshProcessRequest{
+		Meshes: []domain.MeshInput{
+			gridMesh(1, 40, 10, 0),
+			gridMesh(2, 1, 0.01, 20),
+			gridMesh(3, 1, 0.01, 30),
+		},
+		// 要素数より少ない目標は満たせない
+		Options: domain.MeshProcessOptions{TargetTriangles: 2, MergeByMaterial: &mergeByMaterial},
+	}
+
+	result, err := u.ProcessToGLB(context.Background(), req, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TargetTriangles != 2 {
+		t.Errorf("TargetTriangles = %d, want 2", result.TargetTriangles)
+	}
+	if result.OutputTriangles <= result.TargetTriangles {
+		t.Fatalf("OutputTriangles = %d, expected the target to be unmet", result.OutputTriangles)
+	}
+	if len(result.UnmetDbIDs) == 0 {
+		t.Error("UnmetDbIDs is empty although the target was not met")
+	}
+}
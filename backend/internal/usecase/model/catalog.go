@@ -0,0 +1,142 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ObjectUploaded はアップロードを完了したオブジェクトをカタログに登録します
+// 同じオブジェクトキーに上書きした場合は、名前・説明・タグを残して翻訳前の状態に戻します
+func (u *ModelUseCase) ObjectUploaded(ctx context.Context, object *domain.APSObject, urn string) {
+	objectID := object.ObjectId
+	if !strings.HasPrefix(objectID, domain.ObjectIDPrefix) {
+		objectID = domain.ObjectIDPrefix + objectID
+	}
+	now := u.now().UTC()
+
+	model, err := u.modelRepo.GetByObjectID(ctx, objectID)
+	if errors.Is(err, domain.ErrModelNotFound) {
+		model, err = u.newModel(object.BucketKey, object.ObjectKey), nil
+	}
+	if err != nil {
+		logCatalogError(ctx, urn, err)
+		return
+	}
+
+	model.Size = object.Size
+	model.ObjectID = objectID
+	model.URN = urn
+	model.TranslationProfile = ""
+	model.Status = domain.ModelStatusUploaded
+	model.Progress = ""
+	model.HasThumbnail = false
+	model.UploadedBy = ""
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		model.UploadedBy = principal.ID
+	}
+	model.UpdatedAt = now
+	if err := u.modelRepo.Save(ctx, model); err != nil {
+		logCatalogError(ctx, urn, err)
+	}
+}
+
+// TranslationSubmitted は翻訳ジョブの出力形式を記録し、状態をpendingにします
+// カタログにないオブジェクト（カタログを使う前にアップロードしたもの）の場合は登録します
+func (u *ModelUseCase) TranslationSubmitted(ctx context.Context, urn string, objectKey string, job *domain.TranslateJobResponse) {
+	model, err := u.modelRepo.GetByURN(ctx, urn)
+	if errors.Is(err, domain.ErrModelNotFound) {
+		bucketKey, ok := domain.BucketKeyOfURN(urn)
+		if !ok {
+			return
+		}
+		model, err = u.newModel(bucketKey, objectKey), nil
+		model.ObjectID = domain.ObjectIDPrefix + bucketKey + "/" + objectKey
+		model.URN = urn
+	}
+	if err != nil {
+		logCatalogError(ctx, urn, err)
+		return
+	}
+
+	model.TranslationProfile = translationProfile(job)
+	model.Status = domain.ModelStatusPending
+	model.Progress = ""
+	model.UpdatedAt = u.now().UTC()
+	if err := u.modelRepo.Save(ctx, model); err != nil {
+		logCatalogError(ctx, urn, err)
+	}
+}
+
+// TranslationChecked はマニフェストで確認した翻訳の状態を記録します。カタログにないURNと、変化のない場合は何もしません
+func (u *ModelUseCase) TranslationChecked(ctx context.Context, urn string, status *domain.TranslationStatus) {
+	model, err := u.modelRepo.GetByURN(ctx, urn)
+	if errors.Is(err, domain.ErrModelNotFound) {
+		return
+	}
+	if err != nil {
+		logCatalogError(ctx, urn, err)
+		return
+	}
+
+	hasThumbnail := status.HasThumbnail == "true"
+	if model.Status == status.Status && model.Progress == status.Progress && model.HasThumbnail == hasThumbnail {
+		return
+	}
+	completed := status.Status == domain.ModelStatusSuccess && model.Status != domain.ModelStatusSuccess
+	model.Status = status.Status
+	model.Progress = status.Progress
+	model.HasThumbnail = hasThumbnail
+	model.UpdatedAt = u.now().UTC()
+	if err := u.modelRepo.Save(ctx, model); err != nil {
+		logCatalogError(ctx, urn, err)
+		return
+	}
+	if completed {
+		u.indexer.TranslationCompleted(ctx, model)
+	}
+}
+
+// newModel はオブジェクトの新しいカタログの項目を返します
+func (u *ModelUseCase) newModel(bucketKey string, objectKey string) *domain.Model {
+	now := u.now().UTC()
+	return &domain.Model{
+		ID:        uuid.NewString(),
+		Name:      objectKey,
+		FileName:  objectKey,
+		FileType:  strings.TrimPrefix(strings.ToLower(path.Ext(objectKey)), "."),
+		BucketKey: bucketKey,
+		ObjectKey: objectKey,
+		Status:    domain.ModelStatusUploaded,
+		Tags:      []string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// translationProfile は翻訳ジョブが受け付けた出力形式を"svf:2d,3d"の形式で返します
+func translationProfile(job *domain.TranslateJobResponse) string {
+	if job == nil {
+		return ""
+	}
+	var formats []string
+	for _, format := range job.AcceptedJobs.Output.Formats {
+		profile := format.Type
+		if len(format.Views) > 0 {
+			profile += ":" + strings.Join(format.Views, ",")
+		}
+		formats = append(formats, profile)
+	}
+	return strings.Join(formats, ";")
+}
+
+// logCatalogError はカタログの更新の失敗を記録します。アップロードや翻訳は止めません
+func logCatalogError(ctx context.Context, urn string, err error) {
+	slog.ErrorContext(ctx, "failed to update model catalog", "urn", urn, "error", err)
+}
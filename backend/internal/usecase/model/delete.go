@@ -0,0 +1,14 @@
+package model
+
+import (
+	"context"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// DeleteModel はカタログから項目を削除します
+func (u *ModelUseCase) DeleteModel(ctx context.Context, id string) error {
+	err := u.modelRepo.Delete(ctx, id)
+	u.audit.Record(ctx, domain.ActionModelDelete, domain.ModelTarget(id), err)
+	return err
+}
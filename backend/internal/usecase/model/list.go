@@ -0,0 +1,27 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ListModels は条件に一致するモデルを1ページ分返します
+func (u *ModelUseCase) ListModels(ctx context.Context, filter domain.ModelFilter) (*domain.ModelList, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", domain.ErrInvalidModelRequest)
+	}
+	return u.modelRepo.List(ctx, filter)
+}
+
+// GetModel はIDのモデルを返します
+func (u *ModelUseCase) GetModel(ctx context.Context, id string) (*domain.Model, error) {
+	return u.modelRepo.Get(ctx, id)
+}
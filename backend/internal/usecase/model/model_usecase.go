@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 一覧で返す件数の既定値と上限
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// タグの数と長さの上限
+const (
+	maxTags      = 50
+	maxTagLength = 64
+)
+
+// ModelUseCase はカタログのユースケース実装
+type ModelUseCase struct {
+	modelRepo domain.ModelRepository
+	audit     domain.AuditLogger
+	indexer   domain.ModelIndexer
+	now       func() time.Time
+}
+
+// NewModelUseCase は新しいModelUseCaseを作成します
+// indexerには翻訳が完了したモデルを知らせ、要素のプロパティを検索の索引に登録させます
+func NewModelUseCase(modelRepo domain.ModelRepository, audit domain.AuditLogger, indexer domain.ModelIndexer) *ModelUseCase {
+	return &ModelUseCase{
+		modelRepo: modelRepo,
+		audit:     audit,
+		indexer:   indexer,
+		now:       time.Now,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.ModelUseCase = (*ModelUseCase)(nil)
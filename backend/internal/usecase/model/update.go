@@ -0,0 +1,64 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// UpdateModel はモデルの名前・説明・タグを更新します
+func (u *ModelUseCase) UpdateModel(ctx context.Context, id string, update *domain.ModelUpdate) (*domain.Model, error) {
+	model, err := u.modelRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", domain.ErrInvalidModelRequest)
+		}
+		model.Name = name
+	}
+	if update.Description != nil {
+		model.Description = strings.TrimSpace(*update.Description)
+	}
+	if update.Tags != nil {
+		tags, err := normalizeTags(*update.Tags)
+		if err != nil {
+			return nil, err
+		}
+		model.Tags = tags
+	}
+	model.UpdatedAt = u.now().UTC()
+
+	err = u.modelRepo.Save(ctx, model)
+	u.audit.Record(ctx, domain.ActionModelUpdate, domain.ModelTarget(id), err)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// normalizeTags は前後の空白を除き、空と重複を取り除いたタグを返します
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return nil, fmt.Errorf("%w: tags must be at most %d characters", domain.ErrInvalidModelRequest, maxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, fmt.Errorf("%w: at most %d tags are allowed", domain.ErrInvalidModelRequest, maxTags)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,95 @@
+package project
+
+import (
+	"context"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// DeleteProject はプロジェクトとフォルダを削除します
+// Purgeの場合はプロジェクトのモデルについて、翻訳の派生ファイル、OSSのオブジェクト、カタログの項目の順に削除します
+// 途中で失敗した場合はプロジェクトを残してエラーを返します。削除済みのものは飛ばすため、同じ指定でやり直せます
+func (u *ProjectUseCase) DeleteProject(ctx context.Context, id string, opts domain.ProjectDeleteOptions) (*domain.ProjectDeletion, error) {
+	project, err := u.projectRepo.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := u.projectRepo.ListFolders(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	models, err := u.projectModels(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion := &domain.ProjectDeletion{
+		Project: *project,
+		DryRun:  opts.DryRun,
+		Purge:   opts.Purge,
+		Folders: len(folders),
+		Models:  []domain.DeletedModel{},
+	}
+	for _, m := range models {
+		deletion.Models = append(deletion.Models, domain.DeletedModel{
+			ID:        m.ID,
+			Name:      m.Name,
+			BucketKey: m.BucketKey,
+			ObjectKey: m.ObjectKey,
+			URN:       m.URN,
+		})
+	}
+	if opts.DryRun {
+		return deletion, nil
+	}
+
+	if opts.Purge {
+		for _, m := range models {
+			if err := u.purgeModel(ctx, &m); err != nil {
+				u.audit.Record(ctx, domain.ActionProjectDelete, domain.ProjectTarget(id), err)
+				return nil, err
+			}
+		}
+	}
+
+	err = u.projectRepo.DeleteProject(ctx, id)
+	u.audit.Record(ctx, domain.ActionProjectDelete, domain.ProjectTarget(id), err)
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// projectModels はプロジェクトのすべてのモデルを返します
+func (u *ProjectUseCase) projectModels(ctx context.Context, projectID string) ([]domain.Model, error) {
+	var models []domain.Model
+	for offset := 0; ; offset += maxListLimit {
+		page, err := u.modelRepo.List(ctx, domain.ModelFilter{ProjectID: projectID, Sort: "name", Limit: maxListLimit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, page.Items...)
+		if offset+len(page.Items) >= page.Total || len(page.Items) == 0 {
+			return models, nil
+		}
+	}
+}
+
+// purgeModel はモデルの派生ファイル、OSSのオブジェクト、カタログの項目を削除し、それぞれを監査ログに記録します
+func (u *ProjectUseCase) purgeModel(ctx context.Context, model *domain.Model) error {
+	err := u.derivativeRepo.DeleteManifest(ctx, model.URN)
+	u.audit.Record(ctx, domain.ActionManifestDelete, domain.ManifestTarget(model.URN), err)
+	if err != nil {
+		return err
+	}
+
+	err = u.objectRepo.DeleteObject(ctx, model.BucketKey, model.ObjectKey)
+	u.audit.Record(ctx, domain.ActionObjectDelete, domain.ObjectTarget(model.BucketKey, model.ObjectKey), err)
+	if err != nil {
+		return err
+	}
+
+	err = u.modelRepo.Delete(ctx, model.ID)
+	u.audit.Record(ctx, domain.ActionModelDelete, domain.ModelTarget(model.ID), err)
+	return err
+}
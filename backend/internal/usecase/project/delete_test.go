@@ -0,0 +1,110 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/store/catalog"
+)
+
+// recordingObjectRepo は削除したOSSのオブジェクトを記録します
+type recordingObjectRepo struct {
+	domain.APSObjectRepository
+	deleted []string
+}
+
+func (r *recordingObjectRepo) DeleteObject(ctx context.Context, bucketKey string, objectKey string) error {
+	r.deleted = append(r.deleted, bucketKey+"/"+objectKey)
+	return nil
+}
+
+// recordingDerivativeRepo は削除したマニフェストを記録します
+type recordingDerivativeRepo struct {
+	domain.APSDerivativeRepository
+	deleted []string
+}
+
+func (r *recordingDerivativeRepo) DeleteManifest(ctx context.Context, urn string) error {
+	r.deleted = append(r.deleted, urn)
+	return nil
+}
+
+type nopAudit struct{}
+
+func (nopAudit) Record(ctx context.Context, action domain.Action, target string, err error) {}
+
+func TestDeleteProjectPurgesOnlyProjectModels(t *testing.T) {
+	ctx := context.Background()
+	db, err := catalog.Open(filepath.Join(t.TempDir(), "catalog.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	projectRepo := catalog.NewProjectRepository(db)
+	modelRepo := catalog.NewModelRepository(db)
+	objects := &recordingObjectRepo{}
+	derivatives := &recordingDerivativeRepo{}
+	u := NewProjectUseCase(projectRepo, modelRepo, objects, derivatives, nopAudit{})
+
+	now := time.Now().UTC()
+	for _, id := range []string{"p1", "p2"} {
+		if err := projectRepo.SaveProject(ctx, &domain.Project{ID: id, Name: id, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range []struct{ id, projectID string }{
+		{"m1", "p1"},
+		{"m2", "p2"},
+		{"m3", ""},
+	} {
+		model := &domain.Model{
+			ID:        m.id,
+			Name:      m.id,
+			BucketKey: "bucket",
+			ObjectKey: m.id + ".rvt",
+			ObjectID:  "urn:adsk.objects:os.object:bucket/" + m.id + ".rvt",
+			URN:       "urn-" + m.id,
+			ProjectID: m.projectID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := modelRepo.Save(ctx, model); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	preview, err := u.DeleteProject(ctx, "p1", domain.ProjectDeleteOptions{Purge: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Models) != 1 || preview.Models[0].ID != "m1" {
+		t.Fatalf("dry run models = %+v, want only m1", preview.Models)
+	}
+	if len(objects.deleted) != 0 || len(derivatives.deleted) != 0 {
+		t.Fatalf("dry run deleted objects %v and manifests %v", objects.deleted, derivatives.deleted)
+	}
+
+	if _, err := u.DeleteProject(ctx, "p1", domain.ProjectDeleteOptions{Purge: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(objects.deleted, []string{"bucket/m1.rvt"}) {
+		t.Errorf("deleted objects = %v, want [bucket/m1.rvt]", objects.deleted)
+	}
+	if !slices.Equal(derivatives.deleted, []string{"urn-m1"}) {
+		t.Errorf("deleted manifests = %v, want [urn-m1]", derivatives.deleted)
+	}
+	if _, err := modelRepo.Get(ctx, "m1"); !errors.Is(err, domain.ErrModelNotFound) {
+		t.Errorf("m1 was not deleted from the catalog: %v", err)
+	}
+	for _, id := range []string{"m2", "m3"} {
+		if _, err := modelRepo.Get(ctx, id); err != nil {
+			t.Errorf("%s was deleted from the catalog: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ListFolders はプロジェクトのすべてのフォルダを返します
+func (u *ProjectUseCase) ListFolders(ctx context.Context, projectID string) ([]domain.Folder, error) {
+	if _, err := u.projectRepo.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return u.projectRepo.ListFolders(ctx, projectID)
+}
+
+// CreateFolder はプロジェクト直下または親フォルダの中にフォルダを作成します
+func (u *ProjectUseCase) CreateFolder(ctx context.Context, projectID string, req *domain.FolderRequest) (*domain.Folder, error) {
+	if _, err := u.projectRepo.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID != "" {
+		if _, err := u.folderOf(ctx, projectID, req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := u.now().UTC()
+	folder := &domain.Folder{
+		ID:        uuid.NewString(),
+		ProjectID: projectID,
+		ParentID:  req.ParentID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = u.projectRepo.SaveFolder(ctx, folder)
+	u.audit.Record(ctx, domain.ActionFolderCreate, domain.FolderTarget(folder.ID), err)
+	if err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// UpdateFolder はフォルダの名前を変更し、別の親フォルダへ移動します
+// フォルダを自分自身やサブフォルダの中へは移動できません
+func (u *ProjectUseCase) UpdateFolder(ctx context.Context, projectID string, id string, update *domain.FolderUpdate) (*domain.Folder, error) {
+	folder, err := u.folderOf(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name, err := normalizeName(*update.Name)
+		if err != nil {
+			return nil, err
+		}
+		folder.Name = name
+	}
+	if update.ParentID != nil && *update.ParentID != folder.ParentID {
+		if err := u.checkParent(ctx, folder, *update.ParentID); err != nil {
+			return nil, err
+		}
+		folder.ParentID = *update.ParentID
+	}
+	folder.UpdatedAt = u.now().UTC()
+
+	err = u.projectRepo.SaveFolder(ctx, folder)
+	u.audit.Record(ctx, domain.ActionFolderUpdate, domain.FolderTarget(id), err)
+	if err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// DeleteFolder は空のフォルダを削除します
+func (u *ProjectUseCase) DeleteFolder(ctx context.Context, projectID string, id string) error {
+	if _, err := u.folderOf(ctx, projectID, id); err != nil {
+		return err
+	}
+
+	folders, err := u.projectRepo.ListFolders(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for _, f := range folders {
+		if f.ParentID == id {
+			return fmt.Errorf("%w: the folder has subfolders", domain.ErrFolderConflict)
+		}
+	}
+	models, err := u.modelRepo.List(ctx, domain.ModelFilter{ProjectID: projectID, FolderID: id, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if models.Total > 0 {
+		return fmt.Errorf("%w: the folder has %d models", domain.ErrFolderConflict, models.Total)
+	}
+
+	err = u.projectRepo.DeleteFolder(ctx, id)
+	u.audit.Record(ctx, domain.ActionFolderDelete, domain.FolderTarget(id), err)
+	return err
+}
+
+// GetFolderContents はフォルダ直下のサブフォルダとモデルを返します。モデルは名前順に1ページ分返します
+func (u *ProjectUseCase) GetFolderContents(ctx context.Context, projectID string, folderID string, limit int, offset int) (*domain.FolderContents, error) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", domain.ErrInvalidProjectRequest)
+	}
+
+	project, err := u.projectRepo.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := u.projectRepo.ListFolders(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.Folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+
+	contents := &domain.FolderContents{Project: *project, Path: []domain.Folder{}, Folders: []domain.Folder{}, Models: []domain.FolderItem{}}
+	parentID := ""
+	if folderID != domain.RootFolderID {
+		folder, ok := byID[folderID]
+		if !ok {
+			return nil, domain.ErrFolderNotFound
+		}
+		contents.Folder = &folder
+		parentID = folder.ID
+		for f, ok := folder, true; ok; f, ok = byID[f.ParentID] {
+			contents.Path = append([]domain.Folder{f}, contents.Path...)
+		}
+	}
+	for _, f := range folders {
+		if f.ParentID == parentID {
+			contents.Folders = append(contents.Folders, f)
+		}
+	}
+
+	models, err := u.modelRepo.List(ctx, domain.ModelFilter{ProjectID: projectID, FolderID: folderID, Sort: "name", Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range models.Items {
+		contents.Models = append(contents.Models, domain.FolderItem{Model: m})
+	}
+	contents.Total, contents.Limit, contents.Offset = models.Total, models.Limit, models.Offset
+	return contents, nil
+}
+
+// folderOf はプロジェクトのフォルダを返します。別のプロジェクトのフォルダの場合はErrFolderNotFoundを返します
+func (u *ProjectUseCase) folderOf(ctx context.Context, projectID string, id string) (*domain.Folder, error) {
+	folder, err := u.projectRepo.GetFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if folder.ProjectID != projectID {
+		return nil, domain.ErrFolderNotFound
+	}
+	return folder, nil
+}
+
+// checkParent はフォルダをparentIDのフォルダへ移動できるか確認します
+func (u *ProjectUseCase) checkParent(ctx context.Context, folder *domain.Folder, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	folders, err := u.projectRepo.ListFolders(ctx, folder.ProjectID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]domain.Folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+	if _, ok := byID[parentID]; !ok {
+		return fmt.Errorf("%w: parent folder not found in the project", domain.ErrInvalidProjectRequest)
+	}
+	// 移動先から親をたどり、移動するフォルダが現れたら循環になる
+	for id := parentID; id != ""; id = byID[id].ParentID {
+		if id == folder.ID {
+			return fmt.Errorf("%w: a folder cannot be moved into itself or its subfolders", domain.ErrInvalidProjectRequest)
+		}
+	}
+	return nil
+}
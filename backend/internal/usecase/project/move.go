@@ -0,0 +1,54 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// MoveModel はモデルを別のプロジェクトやフォルダへ移動します。OSSのオブジェクトはそのままで、カタログの整理先のみ変わります
+func (u *ProjectUseCase) MoveModel(ctx context.Context, modelID string, move *domain.ModelMove) (*domain.Model, error) {
+	model, err := u.modelRepo.Get(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, folderID := move.ProjectID, move.FolderID
+	if folderID == domain.RootFolderID {
+		folderID = ""
+	}
+	switch {
+	case folderID != "":
+		folder, err := u.projectRepo.GetFolder(ctx, folderID)
+		if errors.Is(err, domain.ErrFolderNotFound) {
+			return nil, fmt.Errorf("%w: folder not found", domain.ErrInvalidProjectRequest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if projectID != "" && projectID != folder.ProjectID {
+			return nil, fmt.Errorf("%w: the folder does not belong to the project", domain.ErrInvalidProjectRequest)
+		}
+		projectID = folder.ProjectID
+	case projectID != "":
+		_, err := u.projectRepo.GetProject(ctx, projectID)
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			return nil, fmt.Errorf("%w: project not found", domain.ErrInvalidProjectRequest)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	model.ProjectID = projectID
+	model.FolderID = folderID
+	model.UpdatedAt = u.now().UTC()
+	err = u.modelRepo.Save(ctx, model)
+	u.audit.Record(ctx, domain.ActionModelMove, domain.ModelTarget(modelID), err)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
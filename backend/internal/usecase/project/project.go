@@ -0,0 +1,87 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ListProjects はすべてのプロジェクトを名前順に返します
+func (u *ProjectUseCase) ListProjects(ctx context.Context) ([]domain.Project, error) {
+	return u.projectRepo.ListProjects(ctx)
+}
+
+// GetProject はIDのプロジェクトを返します
+func (u *ProjectUseCase) GetProject(ctx context.Context, id string) (*domain.Project, error) {
+	return u.projectRepo.GetProject(ctx, id)
+}
+
+// CreateProject はプロジェクトを作成します
+func (u *ProjectUseCase) CreateProject(ctx context.Context, req *domain.ProjectRequest) (*domain.Project, error) {
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	project := &domain.Project{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		project.CreatedBy = principal.ID
+	}
+
+	err = u.projectRepo.SaveProject(ctx, project)
+	u.audit.Record(ctx, domain.ActionProjectCreate, domain.ProjectTarget(project.ID), err)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// UpdateProject はプロジェクトの名前と説明を更新します
+func (u *ProjectUseCase) UpdateProject(ctx context.Context, id string, update *domain.ProjectUpdate) (*domain.Project, error) {
+	project, err := u.projectRepo.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name, err := normalizeName(*update.Name)
+		if err != nil {
+			return nil, err
+		}
+		project.Name = name
+	}
+	if update.Description != nil {
+		project.Description = strings.TrimSpace(*update.Description)
+	}
+	project.UpdatedAt = u.now().UTC()
+
+	err = u.projectRepo.SaveProject(ctx, project)
+	u.audit.Record(ctx, domain.ActionProjectUpdate, domain.ProjectTarget(id), err)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// normalizeName は前後の空白を除いた名前を返します。空や長すぎる名前はエラーにします
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name must not be empty", domain.ErrInvalidProjectRequest)
+	}
+	if len([]rune(name)) > maxNameLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", domain.ErrInvalidProjectRequest, maxNameLength)
+	}
+	return name, nil
+}
@@ -0,0 +1,42 @@
+package project
+
+import (
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// フォルダの中身で返すモデルの件数の既定値と上限
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// プロジェクトとフォルダの名前の長さの上限
+const maxNameLength = 200
+
+// ProjectUseCase はプロジェクトとフォルダのユースケース実装
+type ProjectUseCase struct {
+	projectRepo    domain.ProjectRepository
+	modelRepo      domain.ModelRepository
+	objectRepo     domain.APSObjectRepository
+	derivativeRepo domain.APSDerivativeRepository
+	audit          domain.AuditLogger
+	now            func() time.Time
+}
+
+// NewProjectUseCase は新しいProjectUseCaseを作成します
+// objectRepoとderivativeRepoは、プロジェクトの削除でOSSのオブジェクトと派生ファイルも削除する場合に使います
+func NewProjectUseCase(projectRepo domain.ProjectRepository, modelRepo domain.ModelRepository, objectRepo domain.APSObjectRepository, derivativeRepo domain.APSDerivativeRepository, audit domain.AuditLogger) *ProjectUseCase {
+	return &ProjectUseCase{
+		projectRepo:    projectRepo,
+		modelRepo:      modelRepo,
+		objectRepo:     objectRepo,
+		derivativeRepo: derivativeRepo,
+		audit:          audit,
+		now:            time.Now,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.ProjectUseCase = (*ProjectUseCase)(nil)
@@ -0,0 +1,113 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// TranslationCompleted は翻訳が完了したモデルの要素の名前とプロパティの値を、バックグラウンドで索引に登録します
+// プロパティの索引を無効にしている場合は何もしません。失敗はログに記録し、翻訳の状態の確認は止めません
+func (u *SearchUseCase) TranslationCompleted(ctx context.Context, model *domain.Model) {
+	if !u.indexProperties {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	modelID, urn := model.ID, model.URN
+	go func() {
+		u.slots <- struct{}{}
+		defer func() { <-u.slots }()
+
+		if err := u.indexModelProperties(ctx, modelID, urn); err != nil {
+			slog.ErrorContext(ctx, "failed to index model properties", "modelId", modelID, "urn", urn, "error", err)
+		}
+	}()
+}
+
+// indexModelProperties はモデルのすべてのビューのプロパティを取得し、索引に登録します
+func (u *SearchUseCase) indexModelProperties(ctx context.Context, modelID string, urn string) error {
+	views, err := u.derivativeRepo.GetMetadata(ctx, urn)
+	if err != nil {
+		return err
+	}
+
+	var objects []domain.PropertyObject
+	for _, view := range views {
+		viewObjects, err := u.getProperties(ctx, urn, view.GUID)
+		if err != nil {
+			return fmt.Errorf("view %s: %w", view.GUID, err)
+		}
+		objects = append(objects, viewObjects...)
+	}
+
+	text := propertyText(objects)
+	if err := u.index.SetProperties(ctx, modelID, text); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "indexed model properties", "modelId", modelID, "objects", len(objects), "bytes", len(text))
+	return nil
+}
+
+// getProperties はビューのプロパティを取得します。準備中の場合は間隔を空けて取得し直します
+func (u *SearchUseCase) getProperties(ctx context.Context, urn string, guid string) ([]domain.PropertyObject, error) {
+	for _, delay := range propertyRetryDelays {
+		objects, err := u.derivativeRepo.GetProperties(ctx, urn, guid, u.maxPropertyBytes)
+		if !errors.Is(err, domain.ErrPropertiesNotReady) {
+			return objects, err
+		}
+		time.Sleep(delay)
+	}
+	return u.derivativeRepo.GetProperties(ctx, urn, guid, u.maxPropertyBytes)
+}
+
+// propertyText は要素の名前とプロパティの値を、重複を除いて1行ずつ並べた文字列を返します
+// プロパティ名は多くの要素で共通のため索引に含めません
+func propertyText(objects []domain.PropertyObject) string {
+	seen := map[string]bool{}
+	var lines []string
+	add := func(value string) {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			return
+		}
+		seen[value] = true
+		lines = append(lines, value)
+	}
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case string:
+			add(v)
+		case map[string]any:
+			// 結果が毎回同じになるよう、分類とプロパティ名の順に並べる
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		case nil, bool:
+		default:
+			add(fmt.Sprint(v))
+		}
+	}
+
+	for _, object := range objects {
+		add(object.Name)
+		walk(object.Properties)
+	}
+	return strings.Join(lines, "\n")
+}
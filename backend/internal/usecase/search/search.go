@@ -0,0 +1,27 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// Search は検索語に一致するモデルを一致の度合いの順に1ページ分返します
+func (u *SearchUseCase) Search(ctx context.Context, query domain.SearchQuery) (*domain.SearchResult, error) {
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return nil, fmt.Errorf("%w: q is required", domain.ErrInvalidSearchRequest)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+	if query.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", domain.ErrInvalidSearchRequest)
+	}
+	return u.index.Search(ctx, query)
+}
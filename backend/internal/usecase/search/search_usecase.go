@@ -0,0 +1,44 @@
+package search
+
+import (
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// 検索結果で返す件数の既定値と上限
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// 同時にプロパティを取得して索引に登録するモデルの数
+const indexConcurrency = 2
+
+// プロパティが準備中（202）の場合に取得をやり直すまでの待ち時間
+var propertyRetryDelays = []time.Duration{2 * time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second, time.Minute}
+
+// SearchUseCase は全文検索のユースケース実装
+type SearchUseCase struct {
+	index            domain.SearchIndex
+	derivativeRepo   domain.APSDerivativeRepository
+	indexProperties  bool
+	maxPropertyBytes int64
+	// プロパティの登録の同時実行数を制限する
+	slots chan struct{}
+}
+
+// NewSearchUseCase は新しいSearchUseCaseを作成します
+// indexPropertiesがtrueの場合は、翻訳が完了したモデルの要素の名前とプロパティの値も索引に登録します
+func NewSearchUseCase(index domain.SearchIndex, derivativeRepo domain.APSDerivativeRepository, indexProperties bool, maxPropertyBytes int64) *SearchUseCase {
+	return &SearchUseCase{
+		index:            index,
+		derivativeRepo:   derivativeRepo,
+		indexProperties:  indexProperties,
+		maxPropertyBytes: maxPropertyBytes,
+		slots:            make(chan struct{}, indexConcurrency),
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.SearchUseCase = (*SearchUseCase)(nil)
@@ -0,0 +1,43 @@
+package share
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// GetSharedModel はトークンの共有リンクが有効か確認し、共有しているモデルを返します
+func (u *ShareUseCase) GetSharedModel(ctx context.Context, token string) (*domain.SharedModel, error) {
+	share, err := u.shareByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := share.Active(u.now()); err != nil {
+		return nil, err
+	}
+	model, err := u.modelRepo.Get(ctx, share.ModelID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SharedModel{Share: share, Model: model}, nil
+}
+
+// GetShareThumbnail は共有しているモデルのサムネイルを派生ファイルのプロキシから返します
+// パスワードを設定した共有リンクは、パスワードなしで内容が分からないようサムネイルも公開しません
+func (u *ShareUseCase) GetShareThumbnail(ctx context.Context, token string) (*domain.DerivativeProxyResponse, error) {
+	shared, err := u.GetSharedModel(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if shared.Share.HasPassword {
+		return nil, domain.ErrInvalidSharePassword
+	}
+	return u.derivatives.ProxyDerivative(ctx, &domain.DerivativeProxyRequest{
+		Method:   http.MethodGet,
+		Path:     "modelderivative/v2/designdata/" + shared.Share.URN + "/thumbnail",
+		RawQuery: "width=" + strconv.Itoa(domain.ShareThumbnailSize) + "&height=" + strconv.Itoa(domain.ShareThumbnailSize),
+		Header:   http.Header{},
+	})
+}
@@ -0,0 +1,117 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// ExchangeShare は共有リンクのトークンとパスワードを確認し、閲覧を1回と数えてViewer用トークンを発行します
+// Viewer用トークンの有効期限は共有リンクの有効期限を超えません
+func (u *ShareUseCase) ExchangeShare(ctx context.Context, req *domain.ShareExchangeRequest) (*domain.ShareViewerToken, error) {
+	share, err := u.shareByToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	viewerToken, err := u.exchange(ctx, share, req.Password)
+	u.audit.Record(ctx, domain.ActionShareView, domain.ShareTarget(share.ID), err)
+	return viewerToken, err
+}
+
+// shareByToken はトークンの共有リンクを返します
+func (u *ShareUseCase) shareByToken(ctx context.Context, token string) (*domain.Share, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", domain.ErrInvalidShareRequest)
+	}
+	return u.shareRepo.GetShareByTokenHash(ctx, hashToken(token))
+}
+
+func (u *ShareUseCase) exchange(ctx context.Context, share *domain.Share, password string) (*domain.ShareViewerToken, error) {
+	now := u.now().UTC()
+	if err := share.Active(now); err != nil {
+		return nil, err
+	}
+	if share.HasPassword {
+		err := bcrypt.CompareHashAndPassword([]byte(share.PasswordHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, domain.ErrInvalidSharePassword
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify share password: %w", err)
+		}
+	}
+
+	model, err := u.modelRepo.Get(ctx, share.ModelID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.shareRepo.RecordShareView(ctx, share.ID, now); err != nil {
+		return nil, err
+	}
+	return u.issue(share, model, now)
+}
+
+// RefreshShareToken は有効期限内のViewer用トークンを新しいものと交換します
+// 埋め込みページでViewerがトークンを更新するときに使うため、閲覧の回数には数えません
+func (u *ShareUseCase) RefreshShareToken(ctx context.Context, req *domain.ShareRefreshRequest) (*domain.ShareViewerToken, error) {
+	token := strings.TrimSpace(req.AccessToken)
+	if token == "" {
+		return nil, fmt.Errorf("%w: access_token is required", domain.ErrInvalidShareRequest)
+	}
+	claims, err := u.tokens.ParseViewerToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidShareToken, err)
+	}
+
+	share, err := u.shareRepo.GetShare(ctx, claims.ShareID)
+	if errors.Is(err, domain.ErrShareNotFound) {
+		return nil, fmt.Errorf("%w: share not found", domain.ErrInvalidShareToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if share.URN != claims.URN {
+		return nil, fmt.Errorf("%w: urn does not match the share", domain.ErrInvalidShareToken)
+	}
+	now := u.now().UTC()
+	if err := share.Active(now); err != nil {
+		return nil, err
+	}
+
+	model, err := u.modelRepo.Get(ctx, share.ModelID)
+	if err != nil {
+		return nil, err
+	}
+	return u.issue(share, model, now)
+}
+
+// issue は共有リンクのViewer用トークンを発行します
+func (u *ShareUseCase) issue(share *domain.Share, model *domain.Model, now time.Time) (*domain.ShareViewerToken, error) {
+	expiresAt := now.Add(u.policy.ViewerTokenTTL)
+	if share.ExpiresAt.Before(expiresAt) {
+		expiresAt = share.ExpiresAt
+	}
+	accessToken, err := u.tokens.IssueViewerToken(domain.ShareViewerClaims{
+		ShareID:   share.ID,
+		URN:       share.URN,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ShareViewerToken{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(expiresAt.Sub(now).Seconds()),
+		URN:         share.URN,
+		ModelName:   model.Name,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// CreateShare はモデルの共有リンクを作成します。トークンはレスポンスでのみ返します
+func (u *ShareUseCase) CreateShare(ctx context.Context, modelID string, req *domain.ShareRequest) (*domain.CreatedShare, error) {
+	ttl := u.policy.DefaultTTL
+	switch {
+	case req.ExpiresIn < 0:
+		return nil, fmt.Errorf("%w: expiresIn must not be negative", domain.ErrInvalidShareRequest)
+	case req.ExpiresIn > 0:
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+		if ttl > u.policy.MaxTTL {
+			return nil, fmt.Errorf("%w: expiresIn must not exceed %d seconds", domain.ErrInvalidShareRequest, int(u.policy.MaxTTL.Seconds()))
+		}
+	}
+	if req.MaxViews < 0 {
+		return nil, fmt.Errorf("%w: maxViews must not be negative", domain.ErrInvalidShareRequest)
+	}
+	if len(req.Password) > maxPasswordBytes {
+		return nil, fmt.Errorf("%w: password must be at most %d bytes", domain.ErrInvalidShareRequest, maxPasswordBytes)
+	}
+
+	model, err := u.modelRepo.Get(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	now := u.now().UTC()
+	share := &domain.Share{
+		ID:        uuid.NewString(),
+		ModelID:   model.ID,
+		URN:       model.URN,
+		MaxViews:  req.MaxViews,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		TokenHash: hashToken(token),
+	}
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash share password: %w", err)
+		}
+		share.PasswordHash = string(hash)
+		share.HasPassword = true
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		share.CreatedBy = principal.ID
+	}
+
+	err = u.shareRepo.SaveShare(ctx, share)
+	u.audit.Record(ctx, domain.ActionShareCreate, domain.ShareTarget(share.ID), err)
+	if err != nil {
+		return nil, err
+	}
+	created := &domain.CreatedShare{Share: *share, Token: token}
+	if u.policy.PublicURL != "" {
+		created.URL = u.policy.PublicURL + domain.ShareEmbedPath + token
+	}
+	return created, nil
+}
+
+// ListShares はモデルの共有リンクを新しい順に返します。取り消したものと期限切れのものも含みます
+func (u *ShareUseCase) ListShares(ctx context.Context, modelID string) ([]domain.Share, error) {
+	if _, err := u.modelRepo.Get(ctx, modelID); err != nil {
+		return nil, err
+	}
+	return u.shareRepo.ListShares(ctx, modelID)
+}
+
+// RevokeShare は共有リンクを取り消します。発行済みのViewer用トークンも使えなくなります
+// 取り消し済みの場合は何もしません
+func (u *ShareUseCase) RevokeShare(ctx context.Context, modelID string, id string) error {
+	share, err := u.shareRepo.GetShare(ctx, id)
+	if err != nil {
+		return err
+	}
+	if share.ModelID != modelID {
+		return domain.ErrShareNotFound
+	}
+	if share.RevokedAt != nil {
+		return nil
+	}
+
+	now := u.now().UTC()
+	share.RevokedAt = &now
+	err = u.shareRepo.SaveShare(ctx, share)
+	u.audit.Record(ctx, domain.ActionShareRevoke, domain.ShareTarget(share.ID), err)
+	return err
+}
+
+// newToken は推測できない共有リンクのトークンを返します
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken は保存と照合に使うトークンのSHA-256を返します
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,163 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/auth"
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/infrastructure/store/catalog"
+)
+
+type nopAudit struct{}
+
+func (nopAudit) Record(ctx context.Context, action domain.Action, target string, err error) {}
+
+// newTestUseCase はカタログに1つのモデルを登録したShareUseCaseを作成します
+func newTestUseCase(t *testing.T) (*ShareUseCase, *auth.ShareTokens, *catalog.ShareRepository) {
+	t.Helper()
+	db, err := catalog.Open(filepath.Join(t.TempDir(), "catalog.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	models := catalog.NewModelRepository(db)
+	now := time.Now().UTC()
+	if err := models.Save(context.Background(), &domain.Model{
+		ID:        "m1",
+		Name:      "a.rvt",
+		BucketKey: "teama-models",
+		ObjectKey: "a.rvt",
+		ObjectID:  "urn:adsk.objects:os.object:teama-models/a.rvt",
+		URN:       "dXJuOmFkc2sub2JqZWN0czpvcy5vYmplY3Q6dGVhbWEtbW9kZWxzL2EucnZ0",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	shares := catalog.NewShareRepository(db)
+	tokens, err := auth.NewShareTokens("0123456789abcdef0123456789abcdef", shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := NewShareUseCase(shares, models, tokens, nil, nopAudit{}, domain.SharePolicy{
+		DefaultTTL:     time.Hour,
+		MaxTTL:         24 * time.Hour,
+		ViewerTokenTTL: 10 * time.Minute,
+	})
+	return u, tokens, shares
+}
+
+func TestExchangeShareStopsAtMaxViews(t *testing.T) {
+	ctx := context.Background()
+	u, _, shares := newTestUseCase(t)
+	created, err := u.CreateShare(ctx, "m1", &domain.ShareRequest{MaxViews: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := u.ExchangeShare(ctx, &domain.ShareExchangeRequest{Token: created.Token}); err != nil {
+			t.Fatalf("exchange #%d: %v", i+1, err)
+		}
+	}
+	if _, err := u.ExchangeShare(ctx, &domain.ShareExchangeRequest{Token: created.Token}); !errors.Is(err, domain.ErrShareUnavailable) {
+		t.Fatalf("exchange over the limit = %v, want ErrShareUnavailable", err)
+	}
+	share, err := shares.GetShare(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if share.ViewCount != 2 || share.LastViewedAt == nil {
+		t.Errorf("share = %+v, want 2 views", share)
+	}
+}
+
+func TestExchangeShareConcurrentlyStopsAtMaxViews(t *testing.T) {
+	ctx := context.Background()
+	u, _, shares := newTestUseCase(t)
+	created, err := u.CreateShare(ctx, "m1", &domain.ShareRequest{MaxViews: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	exchanged := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := u.ExchangeShare(ctx, &domain.ShareExchangeRequest{Token: created.Token})
+			if err != nil && !errors.Is(err, domain.ErrShareUnavailable) {
+				t.Errorf("ExchangeShare() = %v", err)
+				return
+			}
+			if err == nil {
+				mu.Lock()
+				exchanged++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	share, err := shares.GetShare(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exchanged != 3 || share.ViewCount != 3 {
+		t.Errorf("exchanged %d times with %d views, want 3", exchanged, share.ViewCount)
+	}
+}
+
+func TestRevokeShareInvalidatesViewerTokens(t *testing.T) {
+	ctx := context.Background()
+	u, tokens, _ := newTestUseCase(t)
+	created, err := u.CreateShare(ctx, "m1", &domain.ShareRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	viewerToken, err := u.ExchangeShare(ctx, &domain.ShareExchangeRequest{Token: created.Token})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticate := func() (*domain.Principal, error) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/aps/viewer/proxy/modelderivative", nil)
+		r.Header.Set("Authorization", "Bearer "+viewerToken.AccessToken)
+		return tokens.Authenticate(r)
+	}
+	principal, err := authenticate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.URN != created.URN || principal.Method != domain.AuthMethodShare {
+		t.Fatalf("principal = %+v, want the shared urn", principal)
+	}
+	if _, err := u.RefreshShareToken(ctx, &domain.ShareRefreshRequest{AccessToken: viewerToken.AccessToken}); err != nil {
+		t.Fatalf("RefreshShareToken() before revoking = %v", err)
+	}
+
+	if err := u.RevokeShare(ctx, "m1", created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := authenticate(); !errors.Is(err, auth.ErrInvalidCredentials) {
+		t.Errorf("Authenticate() after revoking = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := u.RefreshShareToken(ctx, &domain.ShareRefreshRequest{AccessToken: viewerToken.AccessToken}); !errors.Is(err, domain.ErrShareUnavailable) {
+		t.Errorf("RefreshShareToken() after revoking = %v, want ErrShareUnavailable", err)
+	}
+	if _, err := u.ExchangeShare(ctx, &domain.ShareExchangeRequest{Token: created.Token}); !errors.Is(err, domain.ErrShareUnavailable) {
+		t.Errorf("ExchangeShare() after revoking = %v, want ErrShareUnavailable", err)
+	}
+}
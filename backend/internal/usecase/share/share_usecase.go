@@ -0,0 +1,42 @@
+package share
+
+import (
+	"time"
+
+	"github.com/maixhashi/nextgo-aps-viewer/backend/internal/domain"
+)
+
+// bcryptで扱えるパスワードの長さの上限（バイト）
+const maxPasswordBytes = 72
+
+// 共有リンクのトークンの長さ（バイト）
+const tokenBytes = 32
+
+// ShareUseCase は共有リンクのユースケース実装
+type ShareUseCase struct {
+	shareRepo   domain.ShareRepository
+	modelRepo   domain.ModelRepository
+	tokens      domain.ShareTokenIssuer
+	derivatives domain.APSDerivativeUseCase
+	audit       domain.AuditLogger
+	policy      domain.SharePolicy
+	now         func() time.Time
+}
+
+// NewShareUseCase は新しいShareUseCaseを作成します
+// tokensは共有リンクと引き換えに、共有したモデルのURNに限ったViewer用トークンを発行します
+// derivativesは埋め込みで公開するサムネイルの取得に使います
+func NewShareUseCase(shareRepo domain.ShareRepository, modelRepo domain.ModelRepository, tokens domain.ShareTokenIssuer, derivatives domain.APSDerivativeUseCase, audit domain.AuditLogger, policy domain.SharePolicy) *ShareUseCase {
+	return &ShareUseCase{
+		shareRepo:   shareRepo,
+		modelRepo:   modelRepo,
+		tokens:      tokens,
+		derivatives: derivatives,
+		audit:       audit,
+		policy:      policy,
+		now:         time.Now,
+	}
+}
+
+// インターフェースの実装を確認
+var _ domain.ShareUseCase = (*ShareUseCase)(nil)